@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/parnexcodes/woof/internal/output"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// batchLimits caps how large a run may be; zero disables a limit
+type batchLimits struct {
+	MaxFiles     int
+	MaxTotalSize int64
+}
+
+// enabled reports whether any limit is set
+func (l batchLimits) enabled() bool {
+	return l.MaxFiles > 0 || l.MaxTotalSize > 0
+}
+
+// checkBatchLimits scans paths the way the upload will, with the same filters,
+// and rejects the run before anything is sent when the planned files exceed a
+// limit. Standard input counts as a file of unknown, so zero, size.
+func checkBatchLimits(ctx context.Context, paths []string, config uploader.UploadConfig, limits batchLimits) error {
+	if !limits.enabled() {
+		return nil
+	}
+
+	scanner := &uploader.DefaultScanner{Filter: config.Filter, IncludeHidden: config.IncludeHidden}
+	fileCh, errCh := scanner.Scan(ctx, paths)
+
+	var files int
+	var totalSize int64
+	for fileCh != nil || errCh != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			return err
+		case fileInfo, ok := <-fileCh:
+			if !ok {
+				fileCh = nil
+				continue
+			}
+			if fileInfo.IsDir {
+				continue
+			}
+			files++
+			if fileInfo.Size > 0 {
+				totalSize += fileInfo.Size
+			}
+		}
+	}
+
+	switch {
+	case limits.MaxFiles > 0 && files > limits.MaxFiles:
+		return fmt.Errorf("batch of %d files (%s) exceeds --max-files %d", files, output.FormatBytes(totalSize), limits.MaxFiles)
+	case limits.MaxTotalSize > 0 && totalSize > limits.MaxTotalSize:
+		return fmt.Errorf("batch of %d files (%s) exceeds --max-total-size %s", files, output.FormatBytes(totalSize), output.FormatBytes(limits.MaxTotalSize))
+	}
+	return nil
+}
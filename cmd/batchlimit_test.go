@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestCheckBatchLimits(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.bin", "b.bin", "c.bin"} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, 100), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		limits  batchLimits
+		wantErr string
+	}{
+		{name: "no limits", limits: batchLimits{}},
+		{name: "under both limits", limits: batchLimits{MaxFiles: 3, MaxTotalSize: 300}},
+		{name: "too many files", limits: batchLimits{MaxFiles: 2}, wantErr: "batch of 3 files (300 B) exceeds --max-files 2"},
+		{name: "too large", limits: batchLimits{MaxTotalSize: 299}, wantErr: "batch of 3 files (300 B) exceeds --max-total-size 299 B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBatchLimits(context.Background(), []string{dir}, uploader.UploadConfig{}, tt.limits)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckBatchLimits_CountsFilteredFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.jpg", "b.txt", ".hidden.jpg"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+	filter, err := uploader.NewPathFilter([]string{"*.jpg"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only a.jpg would be uploaded, so a limit of one file is enough
+	if err := checkBatchLimits(context.Background(), []string{dir}, uploader.UploadConfig{Filter: filter}, batchLimits{MaxFiles: 1}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
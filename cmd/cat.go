@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/signal"
+	"syscall"
+	"unicode/utf8"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/downloader"
+	"github.com/spf13/cobra"
+)
+
+// sniffSize is how much of the content is inspected to detect binary data
+const sniffSize = 8192
+
+var (
+	catForce    bool
+	catMaxBytes string
+)
+
+var catCmd = &cobra.Command{
+	Use:   "cat <url>",
+	Short: "Download an uploaded file and print it to stdout",
+	Long: `Cat downloads a file and streams it to stdout.
+Viewer pages such as gofile.io/d/<id> are resolved to their direct download link.
+
+Binary content is refused unless --force is given, and output stops at --max-bytes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCat,
+}
+
+func init() {
+	catCmd.Flags().BoolVar(&catForce, "force", false, "write binary content to stdout")
+	catCmd.Flags().StringVar(&catMaxBytes, "max-bytes", "10MB", "maximum number of bytes to print, e.g. 500KB or 1GiB (0 = no limit)")
+}
+
+func runCat(cmd *cobra.Command, args []string) error {
+	maxBytes, err := config.ParseByteSize(catMaxBytes)
+	if err != nil {
+		return fmt.Errorf("invalid --max-bytes: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cmd.SilenceUsage = true
+	return catURL(ctx, downloader.New(), args[0], cmd.OutOrStdout(), maxBytes, catForce)
+}
+
+// catURL streams the content behind rawURL to w, refusing binary content unless
+// force is set and stopping with an error after maxBytes
+func catURL(ctx context.Context, d *downloader.Downloader, rawURL string, w io.Writer, maxBytes int64, force bool) error {
+	download, err := d.Open(ctx, rawURL)
+	if err != nil {
+		return err
+	}
+	defer download.Body.Close()
+
+	reader := bufio.NewReaderSize(download.Body, sniffSize)
+	if !force {
+		sample, err := reader.Peek(sniffSize)
+		if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+			return fmt.Errorf("download failed: %w", err)
+		}
+		if !looksLikeText(sample) {
+			return fmt.Errorf("content looks binary (%s); use --force to print it anyway", download.ContentType)
+		}
+	}
+
+	var src io.Reader = reader
+	if maxBytes > 0 {
+		src = io.LimitReader(reader, maxBytes)
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+
+	// Anything left over means the content did not fit in --max-bytes
+	if maxBytes > 0 {
+		if _, err := reader.Peek(1); err == nil {
+			return fmt.Errorf("output truncated at %d bytes (--max-bytes)", maxBytes)
+		}
+	}
+	return nil
+}
+
+// looksLikeText reports whether sample is UTF-8 text without NUL bytes
+func looksLikeText(sample []byte) bool {
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return false
+	}
+	// A multi-byte character may be cut at the end of the sample
+	for i := 0; i < utf8.UTFMax && len(sample) > 0 && !utf8.Valid(sample); i++ {
+		sample = sample[:len(sample)-1]
+	}
+	return utf8.Valid(sample)
+}
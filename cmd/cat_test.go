@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/downloader"
+)
+
+func TestCatURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/text.txt":
+			w.Write([]byte("line one\nline two\n"))
+		case "/binary.bin":
+			w.Write([]byte{0x89, 'P', 'N', 'G', 0x00, 0x01, 0x02})
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		maxBytes int64
+		force    bool
+		expected string
+		errMsg   string
+	}{
+		{name: "text", path: "/text.txt", expected: "line one\nline two\n"},
+		{name: "exact fit", path: "/text.txt", maxBytes: 18, expected: "line one\nline two\n"},
+		{name: "truncated", path: "/text.txt", maxBytes: 8, expected: "line one", errMsg: "truncated at 8 bytes"},
+		{name: "binary refused", path: "/binary.bin", errMsg: "--force"},
+		{name: "binary forced", path: "/binary.bin", force: true, expected: "\x89PNG\x00\x01\x02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := catURL(context.Background(), downloader.New(), server.URL+tt.path, buf, tt.maxBytes, tt.force)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected output %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestCatCommand_StreamsToStdout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("streamed body"))
+	}))
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"cat", server.URL + "/file.txt"})
+	defer rootCmd.SetOut(nil)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "streamed body" {
+		t.Errorf("expected streamed body, got %q", out.String())
+	}
+}
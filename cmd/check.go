@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/config"
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/timefmt"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	checkAll     bool
+	checkTimeout time.Duration
+)
+
+// Health check outcomes
+const (
+	checkOK      = "OK"
+	checkFail    = "FAIL"
+	checkUnknown = "UNKNOWN"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [provider...]",
+	Short: "Check that providers are reachable and accept the configured credentials",
+	Long: `Check runs a lightweight health check against each provider before a big
+batch, without uploading a real file, and reports OK or FAIL with the time it
+took. Providers that have no health check report UNKNOWN.
+
+Without arguments the enabled providers are checked; name providers or
+aliases to check those instead, or use --all for every provider. Exits with 1
+when any check fails. Use -o json for machine-readable output.`,
+	RunE: runCheck,
+}
+
+func init() {
+	checkCmd.Flags().BoolVar(&checkAll, "all", false, "check every available provider regardless of configuration")
+	checkCmd.Flags().DurationVar(&checkTimeout, "timeout", 15*time.Second, "limit on each provider's check")
+}
+
+// checkResult is the outcome of one provider's health check
+type checkResult struct {
+	Provider string        `json:"provider"`
+	Status   string        `json:"status"`
+	Latency  time.Duration `json:"-"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// MarshalJSON renders the latency like other durations in JSON output
+func (r checkResult) MarshalJSON() ([]byte, error) {
+	type result checkResult
+	return json.Marshal(struct {
+		result
+		Latency string `json:"latency"`
+	}{result: result(r), Latency: timefmt.Duration(r.Latency)})
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	if err := initLogging(); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	factory := providerpkg.NewFactory()
+	var providerList []providertypes.Provider
+	switch {
+	case checkAll:
+		providerList, err = factory.CreateAllProviders()
+	case len(args) > 0:
+		var names []string
+		if names, err = cfg.ResolveProviderNames(args); err != nil {
+			return err
+		}
+		providerList, err = factory.CreateProvidersFromNames(names, cfg.Providers)
+	default:
+		providerList, err = factory.CreateProviders(cfg.GetEnabledProviders())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create providers: %w", err)
+	}
+	if len(providerList) == 0 {
+		return fmt.Errorf("no providers to check; name some, use --all or enable providers in the config")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cmd.SilenceUsage = true
+	results := checkProviders(ctx, providerList, checkTimeout)
+	if err := writeCheckResults(cmd.OutOrStdout(), results, viper.GetString("output")); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == checkFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return &ExitError{
+			Code: ExitFailure,
+			Err:  fmt.Errorf("%d of %d providers failed the check", failed, len(results)),
+		}
+	}
+	return nil
+}
+
+// checkProviders runs every provider's health check at once, each limited to
+// timeout, and returns the outcomes in the order of providerList
+func checkProviders(ctx context.Context, providerList []providertypes.Provider, timeout time.Duration) []checkResult {
+	results := make([]checkResult, len(providerList))
+
+	var wg sync.WaitGroup
+	for i, provider := range providerList {
+		wg.Add(1)
+		go func(i int, provider providertypes.Provider) {
+			defer wg.Done()
+
+			checkCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				checkCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			err := providertypes.CheckHealth(checkCtx, provider)
+			result := checkResult{Provider: provider.Name(), Status: checkOK, Latency: time.Since(start)}
+			switch {
+			case errors.Is(err, providertypes.ErrNoHealthCheck):
+				result.Status = checkUnknown
+				result.Latency = 0
+			case err != nil:
+				result.Status = checkFail
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}(i, provider)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// writeCheckResults prints one line per provider, or a JSON array
+func writeCheckResults(w io.Writer, results []checkResult, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "text":
+		for _, result := range results {
+			switch result.Status {
+			case checkUnknown:
+				fmt.Fprintf(w, "%-7s %s: no health check\n", result.Status, result.Provider)
+			case checkFail:
+				fmt.Fprintf(w, "%-7s %s (%s): %s\n", result.Status, result.Provider, timefmt.Duration(result.Latency), result.Error)
+			default:
+				fmt.Fprintf(w, "%-7s %s (%s)\n", result.Status, result.Provider, timefmt.Duration(result.Latency))
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+)
+
+// healthProvider answers health checks with err, or blocks until the check
+// times out when block is set
+type healthProvider struct {
+	failingProvider
+	name  string
+	err   error
+	block bool
+}
+
+func (p *healthProvider) Name() string { return p.name }
+
+func (p *healthProvider) HealthCheck(ctx context.Context) error {
+	if p.block {
+		<-ctx.Done()
+		return providertypes.NewNetworkError("health check aborted", ctx.Err())
+	}
+	return p.err
+}
+
+func TestCheckProviders_ReportsEachOutcome(t *testing.T) {
+	providerList := []providertypes.Provider{
+		&healthProvider{name: "healthy"},
+		&healthProvider{name: "broken", err: providertypes.NewAuthenticationError("invalid token", nil)},
+		&failingProvider{},
+		providertypes.NewConsistencyWrapper(&failingProvider{}, providertypes.DefaultWrapperConfig()),
+	}
+
+	results := checkProviders(context.Background(), providerList, time.Second)
+	expected := []string{checkOK, checkFail, checkUnknown, checkUnknown}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %+v", len(expected), results)
+	}
+	for i, status := range expected {
+		if results[i].Status != status {
+			t.Errorf("%s: expected %s, got %+v", results[i].Provider, status, results[i])
+		}
+	}
+	if results[0].Provider != "healthy" || results[1].Provider != "broken" {
+		t.Errorf("expected results in provider order, got %+v", results)
+	}
+	if !strings.Contains(results[1].Error, "invalid token") {
+		t.Errorf("expected the failure reason, got %q", results[1].Error)
+	}
+}
+
+func TestCheckProviders_ThroughConsistencyWrapper(t *testing.T) {
+	provider := &healthProvider{name: "broken", err: errors.New("unreachable")}
+	wrapped := providertypes.NewConsistencyWrapper(provider, providertypes.DefaultWrapperConfig())
+
+	results := checkProviders(context.Background(), []providertypes.Provider{wrapped}, time.Second)
+	if results[0].Status != checkFail || results[0].Error != "unreachable" {
+		t.Errorf("expected the wrapper to forward the health check, got %+v", results[0])
+	}
+}
+
+func TestCheckProviders_Timeout(t *testing.T) {
+	start := time.Now()
+	results := checkProviders(context.Background(), []providertypes.Provider{&healthProvider{name: "slow", block: true}}, 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the check to stop at the timeout, took %s", elapsed)
+	}
+	if results[0].Status != checkFail {
+		t.Errorf("expected a timed out check to fail, got %+v", results[0])
+	}
+}
+
+func TestWriteCheckResults(t *testing.T) {
+	results := []checkResult{
+		{Provider: "GoFile", Status: checkOK, Latency: 120 * time.Millisecond},
+		{Provider: "Catbox", Status: checkFail, Latency: time.Second, Error: "connection refused"},
+		{Provider: "Uguu", Status: checkUnknown},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeCheckResults(buf, results, "text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "OK      GoFile (") || !strings.HasPrefix(lines[1], "FAIL    Catbox (") ||
+		!strings.HasSuffix(lines[1], "): connection refused") || lines[2] != "UNKNOWN Uguu: no health check" {
+		t.Errorf("unexpected text output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeCheckResults(buf, results, "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded []map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 3 || decoded[1]["status"] != checkFail || decoded[1]["error"] != "connection refused" || decoded[0]["latency"] == "" {
+		t.Errorf("unexpected JSON output %v", decoded)
+	}
+}
+
+func TestCheckCommand_Registered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"check"})
+	if err != nil || cmd != checkCmd {
+		t.Fatalf("expected the check subcommand to be registered, got %v (%v)", cmd, err)
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/uploader"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+)
+
+func TestUpload_MaxConcurrencySettingSerializesProvider(t *testing.T) {
+	logging.Init(false, io.Discard)
+	var active, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		io.Copy(io.Discard, r.Body)
+		time.Sleep(30 * time.Millisecond)
+		fmt.Fprintln(w, "https://files.catbox.moe/abc.txt")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 5; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	providerList, err := providerpkg.NewFactory().CreateProviders([]config.ProviderConfig{
+		{Name: "catbox", Enabled: true, Settings: map[string]interface{}{
+			"upload_url":      server.URL,
+			"max_concurrency": 1,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	resultCh, progressCh, err := uploader.NewDefaultUploader().Upload(context.Background(), paths, uploader.UploadConfig{
+		Concurrency: 10,
+		Providers:   providerList,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	go func() {
+		for range progressCh {
+		}
+	}()
+	for result := range resultCh {
+		if result.Error != nil {
+			t.Errorf("unexpected error for %s: %v", result.FileName, result.Error)
+		}
+	}
+
+	if got := peak.Load(); got != 1 {
+		t.Errorf("expected max_concurrency 1 to serialize uploads, saw %d at once", got)
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultConfigPath is where config init writes when no path is given
+const defaultConfigPath = "woof.yaml"
+
+var configForce bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the configuration file",
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init [path]",
+	Short: "Write a commented config file with the default settings",
+	Long: `Init writes a YAML config file (default ./woof.yaml) holding the built-in
+upload settings and every provider with its default settings, all disabled.
+Enable the providers you want and pass the file with --config.
+
+An existing file is left alone unless --force is given.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigInit,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [path]",
+	Short: "Check a config file for mistakes",
+	Long: `Validate loads a config file, the path given or the one passed with --config,
+and checks every provider: the name is known, required settings such as
+WebDAV's base_url are present, timeouts parse and max_file_size is not
+negative. Every problem is listed with its line, and any problem exits nonzero.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configInitCmd.Flags().BoolVar(&configForce, "force", false, "overwrite an existing file")
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	path := defaultConfigPath
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	cmd.SilenceUsage = true
+	if err := writeConfigFile(path, configForce); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+	return nil
+}
+
+// writeConfigFile writes the default config to path, refusing to replace an
+// existing file unless force is set
+func writeConfigFile(path string, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create config file: %w", err)
+	}
+
+	if err := config.WriteDefaultConfig(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return file.Close()
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	path := cfgFile
+	if len(args) == 1 {
+		path = args[0]
+	}
+	if path == "" {
+		return fmt.Errorf("no config file given, pass a path or --config")
+	}
+
+	cmd.SilenceUsage = true
+	if err := validateConfigFile(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s: OK\n", path)
+	return nil
+}
+
+// validateConfigFile loads the config at path and reports every problem
+// Config.Validate finds, prefixed with the file and line where known
+func validateConfigFile(path string) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	var problems config.ValidationErrors
+	if !errors.As(cfg.Validate(), &problems) {
+		return nil
+	}
+	problems.AnnotateLines(source)
+	lines := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		location := path
+		if problem.Line > 0 {
+			location = fmt.Sprintf("%s:%d", path, problem.Line)
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s: %s", location, problem.Field, problem.Message))
+	}
+	return fmt.Errorf("%d problem(s) in %s:\n%s", len(problems), path, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteConfigFile_Force(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "woof.yaml")
+	if err := os.WriteFile(path, []byte("custom: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	err := writeConfigFile(path, false)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected an existing file to be refused, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "custom: true\n" {
+		t.Error("expected the existing file to be left alone")
+	}
+
+	if err := writeConfigFile(path, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "providers:") || strings.Contains(string(data), "custom: true") {
+		t.Errorf("expected the file to be replaced with the default config, got:\n%s", data)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/signal"
+	"syscall"
+
+	"github.com/parnexcodes/woof/internal/config"
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <provider> <delete-url-or-token>",
+	Short: "Delete an uploaded file",
+	Long: `Delete removes a file from the provider it was uploaded to.
+
+The second argument is the delete URL printed after the upload, or the
+identifier the provider uses instead: the file ID or download URL for
+BuzzHeavier (requires account_id) and the file ID for GoFile (requires token).
+Providers that cannot delete files exit with an unsupported error.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDelete,
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	if err := initLogging(); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	names, err := cfg.ResolveProviderNames(args[:1])
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return fmt.Errorf("%q names %d providers, delete needs exactly one", args[0], len(names))
+	}
+	providerList, err := providerpkg.NewFactory().CreateProvidersFromNames(names, cfg.Providers)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cmd.SilenceUsage = true
+	return deleteUpload(ctx, providerList[0], args[1], cmd.OutOrStdout())
+}
+
+// deleteUpload removes the file identified by deleteToken and confirms on w.
+// Failures carry the exit code of the provider error type.
+func deleteUpload(ctx context.Context, provider providertypes.Provider, deleteToken string, w io.Writer) error {
+	if err := providertypes.DeleteFile(ctx, provider, deleteToken); err != nil {
+		return &ExitError{
+			Code: exitCodeForError(err),
+			Err:  fmt.Errorf("delete failed: %w", err),
+		}
+	}
+	_, err := fmt.Fprintf(w, "Deleted %s from %s\n", deleteToken, provider.Name())
+	return err
+}
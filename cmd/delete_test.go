@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+)
+
+// deletingProvider records the tokens it was asked to delete
+type deletingProvider struct {
+	failingProvider
+	deleted []string
+	err     error
+}
+
+func (p *deletingProvider) Delete(ctx context.Context, deleteToken string) error {
+	p.deleted = append(p.deleted, deleteToken)
+	return p.err
+}
+
+func TestDeleteUpload_CallsProvider(t *testing.T) {
+	provider := &deletingProvider{}
+	buf := &bytes.Buffer{}
+
+	if err := deleteUpload(context.Background(), provider, "abc123", buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(provider.deleted) != 1 || provider.deleted[0] != "abc123" {
+		t.Errorf("expected the token passed to Delete, got %v", provider.deleted)
+	}
+	if buf.String() != "Deleted abc123 from failing\n" {
+		t.Errorf("unexpected confirmation %q", buf.String())
+	}
+}
+
+func TestDeleteUpload_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider providertypes.Provider
+		code     int
+	}{
+		{name: "unsupported", provider: &failingProvider{}, code: ExitUnsupported},
+		{name: "authentication", provider: &deletingProvider{err: providertypes.NewAuthenticationError("bad token", nil)}, code: ExitAuth},
+		{name: "other", provider: &deletingProvider{err: errors.New("boom")}, code: ExitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := deleteUpload(context.Background(), tt.provider, "abc123", &bytes.Buffer{})
+			if code := ExitCode(err); code != tt.code {
+				t.Errorf("expected exit code %d, got %d (%v)", tt.code, code, err)
+			}
+		})
+	}
+}
+
+func TestDeleteUpload_ThroughConsistencyWrapper(t *testing.T) {
+	provider := &deletingProvider{}
+	wrapped := providertypes.NewConsistencyWrapper(provider, providertypes.DefaultWrapperConfig())
+
+	if err := deleteUpload(context.Background(), wrapped, "abc123", &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(provider.deleted) != 1 {
+		t.Error("expected the wrapper to forward Delete to the provider")
+	}
+}
+
+func TestDeleteCommand_Registered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"delete"})
+	if err != nil || cmd != deleteCmd {
+		t.Fatalf("expected the delete subcommand to be registered, got %v (%v)", cmd, err)
+	}
+	if err := deleteCmd.Args(deleteCmd, []string{"gofile"}); err == nil {
+		t.Error("expected delete to require a provider and a token")
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// writeDryRun lists the files a run would upload and the provider each would
+// go to, or every accepting provider when mirroring. Providers are checked
+// with ValidateFile under a dry-run context, so only local limits such as
+// size and extension apply and nothing is sent over the network. It returns
+// the number of files found.
+func writeDryRun(ctx context.Context, w io.Writer, paths []string, providerList []uploader.Provider, config uploader.UploadConfig) (int, error) {
+	ctx = providertypes.WithDryRun(ctx)
+	scanner := &uploader.DefaultScanner{Filter: config.Filter, IncludeHidden: config.IncludeHidden}
+	fileCh, errCh := scanner.Scan(ctx, paths)
+
+	files := 0
+	for fileCh != nil || errCh != nil {
+		select {
+		case <-ctx.Done():
+			return files, ctx.Err()
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			fmt.Fprintf(w, "ERROR %v\n", err)
+		case fileInfo, ok := <-fileCh:
+			if !ok {
+				fileCh = nil
+				continue
+			}
+			if fileInfo.IsDir {
+				continue
+			}
+			files++
+			writeDryRunFile(ctx, w, fileInfo, providerList, config)
+		}
+	}
+	return files, nil
+}
+
+// writeDryRunFile prints the plan for one file
+func writeDryRunFile(ctx context.Context, w io.Writer, fileInfo uploader.FileInfo, providerList []uploader.Provider, config uploader.UploadConfig) {
+	uploadName := uploader.TrimName(fileInfo.Name, config.MaxNameLen)
+	var accepted []string
+	var rejections []string
+	for _, provider := range providerList {
+		if err := provider.ValidateFile(ctx, uploadName, fileInfo.Size); err != nil {
+			rejections = append(rejections, fmt.Sprintf("%s: %v", provider.Name(), err))
+			continue
+		}
+		accepted = append(accepted, provider.Name())
+		if config.Strategy != uploader.StrategyMirror {
+			break
+		}
+	}
+
+	size := "unknown size"
+	if fileInfo.Size >= 0 {
+		size = fmt.Sprintf("%d bytes", fileInfo.Size)
+	}
+	if len(accepted) == 0 {
+		fmt.Fprintf(w, "SKIP %s (%s): %s\n", fileInfo.Name, size, strings.Join(rejections, "; "))
+		return
+	}
+	name := fileInfo.Name
+	if uploadName != fileInfo.Name {
+		name = fmt.Sprintf("%s as %s", fileInfo.Name, uploadName)
+	}
+	fmt.Fprintf(w, "WOULD UPLOAD %s (%s) -> %s\n", name, size, strings.Join(accepted, ", "))
+}
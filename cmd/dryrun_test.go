@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/uploader"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+)
+
+func TestDryRun_MakesNoHTTPCalls(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unexpected request", http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	small := filepath.Join(dir, "notes.txt")
+	large := filepath.Join(dir, "video.bin")
+	if err := os.WriteFile(small, []byte("notes"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(large, make([]byte, 64), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	factoryConfig := providerpkg.DefaultFactoryConfig()
+	factoryConfig.DryRun = true
+	factory := providerpkg.NewFactoryWithConfig(factoryConfig)
+	providerList, err := factory.CreateProviders([]config.ProviderConfig{
+		{Name: "gofile", Enabled: true, Settings: map[string]interface{}{
+			// Server selection queries the API during Initialize
+			"select_server":      true,
+			"servers_url":        server.URL + "/servers",
+			"upload_url":         server.URL + "/uploadFile",
+			"allowed_extensions": ".txt",
+		}},
+		{Name: "buzzheavier", Enabled: true, Settings: map[string]interface{}{
+			"upload_url":    server.URL,
+			"max_file_size": int64(32),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create providers: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := factory.InitializeProviders(ctx, providerList); err != nil {
+		t.Fatalf("unexpected initialize error: %v", err)
+	}
+	var out bytes.Buffer
+	files, err := writeDryRun(ctx, &out, []string{small, large}, providerList, uploader.UploadConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := requests.Load(); got != 0 {
+		t.Errorf("expected no HTTP calls during a dry run, got %d", got)
+	}
+	if files != 2 {
+		t.Errorf("expected 2 files, got %d", files)
+	}
+	output := out.String()
+	if !strings.Contains(output, "WOULD UPLOAD notes.txt (5 bytes) -> GoFile\n") {
+		t.Errorf("expected notes.txt planned for GoFile, got %q", output)
+	}
+	// Rejected by GoFile's extension list and BuzzHeavier's size limit, both checked locally
+	if !strings.Contains(output, "SKIP video.bin (64 bytes): GoFile: ") || !strings.Contains(output, "BuzzHeavier: ") {
+		t.Errorf("expected video.bin to be skipped by both providers, got %q", output)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+)
+
+// Process exit codes. Provider specific codes are only used in single-provider
+// single-file mode, where one failure determines the outcome of the run.
+const (
+	ExitOK          = 0
+	ExitFailure     = 1
+	ExitNoFiles     = 5 // Nothing to upload: patterns and folders matched no files
+	ExitAuth        = 10
+	ExitQuota       = 11
+	ExitTooLarge    = 12
+	ExitUnsupported = 13
+	ExitNetwork     = 14
+	ExitInterrupted = 130 // Stopped by SIGINT or SIGTERM, as shells report for SIGINT
+)
+
+// ExitError carries the process exit code for a command error
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error implements the error interface
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode maps an error returned by Execute to a process exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+// exitCodeForError maps the provider error type behind err to an exit code
+func exitCodeForError(err error) int {
+	switch providertypes.RootErrorType(err) {
+	case providertypes.ErrorTypeAuthentication:
+		return ExitAuth
+	case providertypes.ErrorTypeQuota:
+		return ExitQuota
+	case providertypes.ErrorTypeFileTooLarge:
+		return ExitTooLarge
+	case providertypes.ErrorTypeUnsupported:
+		return ExitUnsupported
+	case providertypes.ErrorTypeNetwork:
+		return ExitNetwork
+	default:
+		return ExitFailure
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/output"
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// failingProvider rejects every upload with the same error
+type failingProvider struct {
+	err error
+}
+
+func (p *failingProvider) Name() string { return "failing" }
+
+func (p *failingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providertypes.ProviderResponse, error) {
+	return nil, p.err
+}
+
+func (p *failingProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *failingProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *failingProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+func runWithProvider(t *testing.T, provider uploader.Provider, names ...string) uploadOutcome {
+	t.Helper()
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	ctx := context.Background()
+	resultCh, progressCh, err := uploader.NewDefaultUploader().Upload(ctx, paths, uploader.UploadConfig{
+		Concurrency: 1,
+		Providers:   []uploader.Provider{provider},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	outcome, err := handleUploadOutputs(resultCh, progressCh, output.NewTextHandler(&bytes.Buffer{}), nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return outcome
+}
+
+func TestSingleUploadExitError_ProviderErrorTypes(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "auth", err: providertypes.NewAuthenticationError("invalid token", nil), expected: ExitAuth},
+		{name: "quota", err: providertypes.NewQuotaError("storage full", nil), expected: ExitQuota},
+		{name: "too large", err: providertypes.ErrFileTooLarge(20, 10), expected: ExitTooLarge},
+		{name: "unsupported", err: providertypes.NewUnsupportedError("type not allowed", nil), expected: ExitUnsupported},
+		{name: "network", err: providertypes.NewNetworkError("connection reset", nil), expected: ExitNetwork},
+		{name: "api", err: providertypes.ErrUploadStatus(500, "oops"), expected: ExitFailure},
+		{name: "plain error", err: errors.New("boom"), expected: ExitFailure},
+		{
+			name:     "retries exhausted",
+			err:      providertypes.NewTemporaryError("all 3 retry attempts failed", providertypes.NewQuotaError("rate limited", nil)),
+			expected: ExitQuota,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := runWithProvider(t, &failingProvider{err: tt.err}, "a.txt")
+
+			err := singleUploadExitError(outcome, 1)
+			if err == nil {
+				t.Fatal("expected an exit error for the failed upload")
+			}
+			if code := ExitCode(err); code != tt.expected {
+				t.Errorf("expected exit code %d, got %d (%v)", tt.expected, code, err)
+			}
+		})
+	}
+}
+
+func TestSingleUploadExitError_OnlyInSingleMode(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	provider := &failingProvider{err: providertypes.NewAuthenticationError("invalid token", nil)}
+
+	if err := singleUploadExitError(runWithProvider(t, provider, "a.txt", "b.txt"), 1); err != nil {
+		t.Errorf("multi-file runs should not map provider error types, got %v", err)
+	}
+	if err := singleUploadExitError(runWithProvider(t, provider, "a.txt"), 2); err != nil {
+		t.Errorf("multi-provider runs should not map provider error types, got %v", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := ExitCode(nil); code != ExitOK {
+		t.Errorf("expected %d for nil, got %d", ExitOK, code)
+	}
+	if code := ExitCode(errors.New("usage error")); code != ExitFailure {
+		t.Errorf("expected %d for plain errors, got %d", ExitFailure, code)
+	}
+	if code := ExitCode(&ExitError{Code: ExitQuota, Err: errors.New("quota")}); code != ExitQuota {
+		t.Errorf("expected %d, got %d", ExitQuota, code)
+	}
+}
+
+func TestInterruptedError(t *testing.T) {
+	err := interruptedError(uploadOutcome{Summary: uploader.Summary{Succeeded: 1, Cancelled: 2}})
+	if err.Error() != "interrupted, 2 uploads cancelled" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if code := ExitCode(err); code != ExitInterrupted {
+		t.Errorf("expected %d, got %d", ExitInterrupted, code)
+	}
+	if err := interruptedError(uploadOutcome{Summary: uploader.Summary{Cancelled: 1}}); err.Error() != "interrupted, 1 upload cancelled" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
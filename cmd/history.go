@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/history"
+	"github.com/parnexcodes/woof/internal/output"
+	"github.com/parnexcodes/woof/internal/timefmt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	historyProvider string
+	historySince    string
+	historyUntil    string
+	historyLimit    int
+)
+
+// historyDateLayout is the date-only form accepted by --since and --until
+const historyDateLayout = "2006-01-02"
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent uploads from the upload history",
+	Long: `History lists the uploads recorded in the upload history, newest first.
+Uploads are recorded when woof upload runs with --history or history.enabled
+is set in the config; the file is history.file, or ~/.woof/history.jsonl.
+
+--since and --until take a duration back from now (24h), a date (2006-01-02)
+or an RFC3339 time. A date given to --until includes that whole day. Use
+-o json for machine-readable output.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyProvider, "provider", "", "only list uploads to this provider")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "only list uploads at or after this time, e.g. 24h, 2006-01-02 or an RFC3339 time")
+	historyCmd.Flags().StringVar(&historyUntil, "until", "", "only list uploads before this time; a date includes that whole day")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "most recent uploads to list (0 = all)")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if err := initLogging(); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if historyLimit < 0 {
+		return fmt.Errorf("--limit must not be negative, got %d", historyLimit)
+	}
+	now := time.Now()
+	filter := history.Filter{Provider: historyProvider, Limit: historyLimit}
+	if filter.Since, err = parseHistoryTime(historySince, now, false); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if filter.Until, err = parseHistoryTime(historyUntil, now, true); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	path, err := historyPath(cfg)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+	records, err := history.NewStore(path).Query(filter)
+	if err != nil {
+		return err
+	}
+	return writeHistory(cmd.OutOrStdout(), records, viper.GetString("output"))
+}
+
+// historyPath returns history.file from the config, or the default location
+func historyPath(cfg *config.Config) (string, error) {
+	if cfg.History.File != "" {
+		return cfg.History.File, nil
+	}
+	return history.DefaultPath()
+}
+
+// uploadHistoryStore returns the store an upload records into, or nil when
+// recording is off. --history, when given, overrides history.enabled.
+func uploadHistoryStore(cmd *cobra.Command, cfg *config.Config) (*history.Store, error) {
+	enabled := cfg.History.Enabled
+	if cmd.Flags().Changed("history") {
+		enabled = recordHistory
+	}
+	if !enabled {
+		return nil, nil
+	}
+	path, err := historyPath(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return history.NewStore(path), nil
+}
+
+// parseHistoryTime reads a --since or --until value: a duration back from
+// now, a date or an RFC3339 time. With endOfDay a date means the end of that
+// day, so --until 2024-05-01 includes uploads made on May 1st.
+func parseHistoryTime(value string, now time.Time, endOfDay bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(historyDateLayout, value, time.Local); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a duration, date (YYYY-MM-DD) or RFC3339 time", value)
+}
+
+// writeHistory prints one line per upload with its delete URL below it, or a
+// JSON array
+func writeHistory(w io.Writer, records []history.Record, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		if records == nil {
+			records = []history.Record{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case "text":
+		if len(records) == 0 {
+			fmt.Fprintln(w, "No uploads recorded")
+			return nil
+		}
+		for _, record := range records {
+			fmt.Fprintf(w, "%s  %-12s %s (%s)  %s\n",
+				timefmt.Timestamp(record.Time),
+				record.Provider,
+				record.FileName,
+				output.FormatBytes(record.Size),
+				record.URL,
+			)
+			if record.DeleteURL != "" {
+				fmt.Fprintf(w, "    delete: %s\n", record.DeleteURL)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/history"
+)
+
+func TestParseHistoryTime(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value    string
+		endOfDay bool
+		expected time.Time
+	}{
+		{"", false, time.Time{}},
+		{"24h", false, now.Add(-24 * time.Hour)},
+		{"2024-05-01", false, day},
+		{"2024-05-01", true, day.AddDate(0, 0, 1)},
+		{"2024-05-01T10:00:00Z", true, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseHistoryTime(tt.value, now, tt.endOfDay)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("%q (end of day %v): expected %v, got %v", tt.value, tt.endOfDay, tt.expected, got)
+		}
+	}
+
+	for _, value := range []string{"yesterday", "-1h", "2024-13-01"} {
+		if _, err := parseHistoryTime(value, now, false); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestWriteHistory(t *testing.T) {
+	records := []history.Record{
+		{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), FileName: "b.txt", Size: 2048, Provider: "GoFile", URL: "https://gofile.example/b", DeleteURL: "https://gofile.example/b/delete"},
+		{Time: time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), FileName: "a.txt", Size: 10, Provider: "Catbox", URL: "https://catbox.example/a"},
+	}
+
+	var text bytes.Buffer
+	if err := writeHistory(&text, records, "text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected two uploads and one delete line, got %q", text.String())
+	}
+	if !strings.Contains(lines[0], "GoFile") || !strings.Contains(lines[0], "b.txt") || !strings.HasSuffix(lines[0], "https://gofile.example/b") {
+		t.Errorf("unexpected first line: %q", lines[0])
+	}
+	if strings.TrimSpace(lines[1]) != "delete: https://gofile.example/b/delete" {
+		t.Errorf("unexpected delete line: %q", lines[1])
+	}
+
+	var out bytes.Buffer
+	if err := writeHistory(&out, records, "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded []history.Record
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not a JSON array: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].DeleteURL != records[0].DeleteURL {
+		t.Errorf("unexpected records: %+v", decoded)
+	}
+
+	var empty bytes.Buffer
+	if err := writeHistory(&empty, nil, "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(empty.String()) != "[]" {
+		t.Errorf("expected an empty array, got %q", empty.String())
+	}
+
+	if err := writeHistory(&bytes.Buffer{}, records, "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
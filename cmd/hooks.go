@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/output"
+	"github.com/parnexcodes/woof/internal/uploader"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// hookConcurrency bounds how many hook processes run at once
+	hookConcurrency = 4
+	// hookTimeout bounds how long a single hook process may run
+	hookTimeout = 2 * time.Minute
+)
+
+// hookCommandRunner executes a hook command; replaced in tests
+type hookCommandRunner func(ctx context.Context, name string, args []string) ([]byte, error)
+
+func execHookCommand(ctx context.Context, name string, args []string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// hookHandler decorates an output handler and runs the --on-success/--on-failure
+// commands for each result. Commands are split into arguments before placeholders
+// are substituted and are executed directly, never through a shell, so values such
+// as URLs or error messages cannot inject extra commands.
+type hookHandler struct {
+	output.Handler
+	onSuccess []string
+	onFailure []string
+	run       hookCommandRunner
+	sem       chan struct{}
+	wg        sync.WaitGroup
+}
+
+// newHookHandler wraps next with hook execution. Empty templates disable the matching hook.
+func newHookHandler(next output.Handler, onSuccess, onFailure string) (*hookHandler, error) {
+	successArgs, err := splitCommandLine(onSuccess)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --on-success command: %w", err)
+	}
+	failureArgs, err := splitCommandLine(onFailure)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --on-failure command: %w", err)
+	}
+
+	return &hookHandler{
+		Handler:   next,
+		onSuccess: successArgs,
+		onFailure: failureArgs,
+		run:       execHookCommand,
+		sem:       make(chan struct{}, hookConcurrency),
+	}, nil
+}
+
+// HandleResult forwards the result and schedules the matching hook
+func (h *hookHandler) HandleResult(result uploader.UploadResult) error {
+	err := h.Handler.HandleResult(result)
+
+	// Interrupted or skipped uploads are neither successes nor failures worth acting on
+	if result.Cancelled || result.Skipped {
+		return err
+	}
+
+	template := h.onSuccess
+	if result.Error != nil {
+		template = h.onFailure
+	}
+	if len(template) > 0 {
+		h.dispatch(expandHookArgs(template, result), result.FileName)
+	}
+
+	return err
+}
+
+// HandleSummary forwards the run summary to the wrapped handler
+func (h *hookHandler) HandleSummary(summary uploader.Summary) error {
+	if sh, ok := h.Handler.(output.SummaryHandler); ok {
+		return sh.HandleSummary(summary)
+	}
+	return nil
+}
+
+// Wait blocks until all scheduled hooks have finished
+func (h *hookHandler) Wait() {
+	h.wg.Wait()
+}
+
+func (h *hookHandler) dispatch(args []string, fileName string) {
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.sem <- struct{}{}
+		defer func() { <-h.sem }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+
+		out, err := h.run(ctx, args[0], args[1:])
+		if err != nil {
+			// Hook failures are reported but never fail the upload itself
+			logging.Warn("Hook command failed", logrus.Fields{
+				"file":    fileName,
+				"command": args[0],
+				"error":   err.Error(),
+				"output":  strings.TrimSpace(string(out)),
+			})
+			return
+		}
+		logging.Debug("Hook command completed", logrus.Fields{
+			"file":    fileName,
+			"command": args[0],
+		})
+	}()
+}
+
+// expandHookArgs substitutes result placeholders into each argument
+func expandHookArgs(template []string, result uploader.UploadResult) []string {
+	errText := ""
+	if result.Error != nil {
+		errText = result.Error.Error()
+	}
+
+	replacer := strings.NewReplacer(
+		"{url}", result.URL,
+		"{file}", result.FilePath,
+		"{name}", result.FileName,
+		"{provider}", result.Provider,
+		"{error}", errText,
+	)
+
+	args := make([]string, len(template))
+	for i, arg := range template {
+		args[i] = replacer.Replace(arg)
+	}
+	return args
+}
+
+// splitCommandLine splits a command template into arguments, honouring single
+// quotes, double quotes and backslash escapes the way a POSIX shell would
+func splitCommandLine(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/output"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "empty", command: "", expected: nil},
+		{name: "simple", command: "notify {url} {file}", expected: []string{"notify", "{url}", "{file}"}},
+		{name: "double quotes", command: `notify "uploaded {name}" {url}`, expected: []string{"notify", "uploaded {name}", "{url}"}},
+		{name: "single quotes", command: `echo 'a  b'`, expected: []string{"echo", "a  b"}},
+		{name: "escaped space", command: `rm my\ file`, expected: []string{"rm", "my file"}},
+		{name: "unterminated quote", command: `echo "oops`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := splitCommandLine(tt.command)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tt.command)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, args)
+			}
+		})
+	}
+}
+
+func TestHookHandler_SubstitutesPlaceholders(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	hooks, err := newHookHandler(output.NewTextHandler(&bytes.Buffer{}), `notify "done: {name}" {url} {file}`, "alert {file} {error}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var calls [][]string
+	hooks.run = func(ctx context.Context, name string, args []string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, append([]string{name}, args...))
+		return nil, nil
+	}
+
+	hooks.HandleResult(uploader.UploadResult{
+		FileName: "a.txt",
+		FilePath: "/tmp/a.txt",
+		URL:      "https://example.com/x; rm -rf ~",
+		Provider: "fake",
+	})
+	hooks.Wait()
+	hooks.HandleResult(uploader.UploadResult{
+		FileName: "b.txt",
+		FilePath: "/tmp/b.txt",
+		Error:    errors.New("quota exceeded"),
+	})
+	hooks.Wait()
+	hooks.HandleResult(uploader.UploadResult{
+		FileName:  "c.txt",
+		FilePath:  "/tmp/c.txt",
+		Cancelled: true,
+		Error:     context.Canceled,
+	})
+	hooks.Wait()
+
+	expected := [][]string{
+		{"notify", "done: a.txt", "https://example.com/x; rm -rf ~", "/tmp/a.txt"},
+		{"alert", "/tmp/b.txt", "quota exceeded"},
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected hook calls %q, got %q", expected, calls)
+	}
+}
+
+func TestHookHandler_FailureDoesNotFailUpload(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	buf := &bytes.Buffer{}
+	hooks, err := newHookHandler(output.NewTextHandler(buf), "notify {url}", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hooks.run = func(ctx context.Context, name string, args []string) ([]byte, error) {
+		return []byte("boom"), errors.New("exit status 1")
+	}
+
+	err = hooks.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a"})
+	hooks.Wait()
+	if err != nil {
+		t.Errorf("hook failure should not surface as an error, got %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("SUCCESS a.txt")) {
+		t.Errorf("result should still reach the wrapped handler, got %q", buf.String())
+	}
+}
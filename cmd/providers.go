@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/parnexcodes/woof/internal/output"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var providersCmd = &cobra.Command{
+	Use:   "providers",
+	Short: "List the available providers and what they accept",
+	Long: `Providers lists every provider woof can upload to with its default maximum
+file size, supported extensions and whether it needs credentials or a server
+configured first. Use -o json for machine-readable output.
+
+Limits shown are the defaults; settings such as max_file_size and
+allowed_extensions in the config file change them (see upload --list-extensions).`,
+	Args: cobra.NoArgs,
+	RunE: runProviders,
+}
+
+var providersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available providers and what they accept",
+	Args:  cobra.NoArgs,
+	RunE:  runProviders,
+}
+
+func init() {
+	providersCmd.AddCommand(providersListCmd)
+}
+
+func runProviders(cmd *cobra.Command, args []string) error {
+	if err := initLogging(); err != nil {
+		return err
+	}
+
+	descriptors, err := providerpkg.NewFactory().ListProviders()
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+	return writeProviderList(cmd.OutOrStdout(), descriptors, viper.GetString("output"))
+}
+
+// writeProviderList prints the provider descriptors as text or JSON, for the
+// providers command and upload --list-extensions
+func writeProviderList(w io.Writer, descriptors []providerpkg.ProviderDescriptor, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(descriptors)
+	case "text":
+		for _, d := range descriptors {
+			maxSize := "unlimited"
+			if d.MaxFileSize > 0 {
+				maxSize = output.FormatBytes(d.MaxFileSize)
+			}
+			auth := "no"
+			if d.RequiresAuth {
+				auth = "yes"
+			}
+			fmt.Fprintf(w, "%s (%s)\n  max file size: %s\n  extensions: %s\n  requires auth: %s\n",
+				d.Name, d.DisplayName, maxSize, strings.Join(d.Extensions, ", "), auth)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
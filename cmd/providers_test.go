@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/uploader"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+)
+
+func TestWriteProviderList_JSON(t *testing.T) {
+	logging.Init(false, io.Discard)
+	descriptors, err := providerpkg.NewFactory().ListProviders()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeProviderList(buf, descriptors, "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var listed []providerpkg.ProviderDescriptor
+	if err := json.Unmarshal(buf.Bytes(), &listed); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	byName := make(map[string]providerpkg.ProviderDescriptor)
+	for _, d := range listed {
+		byName[d.Name] = d
+	}
+
+	buzz, ok := byName["buzzheavier"]
+	if !ok {
+		t.Fatal("expected buzzheavier in the list")
+	}
+	if buzz.MaxFileSize != 10*1024*1024*1024 || buzz.RequiresAuth {
+		t.Errorf("unexpected buzzheavier descriptor: %+v", buzz)
+	}
+	gofile, ok := byName["gofile"]
+	if !ok {
+		t.Fatal("expected gofile in the list")
+	}
+	if gofile.MaxFileSize != 0 || gofile.DisplayName != "GoFile" {
+		t.Errorf("unexpected gofile descriptor: %+v", gofile)
+	}
+	if !byName["webdav"].RequiresAuth {
+		t.Error("expected webdav to require auth")
+	}
+}
+
+func TestWriteProviderList_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := writeProviderList(buf, []providerpkg.ProviderDescriptor{
+		{Name: "catbox", DisplayName: "Catbox", MaxFileSize: 200 * 1024 * 1024, Extensions: []string{"*"}},
+	}, "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "max file size: 200.0 MiB") || !strings.Contains(buf.String(), "requires auth: no") {
+		t.Errorf("unexpected text output:\n%s", buf.String())
+	}
+}
+
+func TestWriteProviderList_ReflectsConfig(t *testing.T) {
+	logging.Init(false, io.Discard)
+	provider, err := providerpkg.NewFactory().CreateProvider(config.ProviderConfig{
+		Name: "catbox",
+		Settings: map[string]interface{}{
+			"allowed_extensions": []interface{}{"PNG", ".jpg"},
+			"max_file_size":      int64(1024),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	descriptors := providerpkg.DescribeProviders([]uploader.Provider{provider})
+
+	buf := &bytes.Buffer{}
+	if err := writeProviderList(buf, descriptors, "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var listed []providerpkg.ProviderDescriptor
+	if err := json.Unmarshal(buf.Bytes(), &listed); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if len(listed) != 1 {
+		t.Fatalf("expected one provider, got %d", len(listed))
+	}
+	got := listed[0]
+	if got.Name != "catbox" || got.DisplayName != "Catbox" || got.MaxFileSize != 1024 {
+		t.Errorf("unexpected descriptor %+v", got)
+	}
+	if strings.Join(got.Extensions, ",") != ".jpg,.png" {
+		t.Errorf("expected the configured extensions, got %v", got.Extensions)
+	}
+
+	buf.Reset()
+	if err := writeProviderList(buf, descriptors, "text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "extensions: .jpg, .png") || !strings.Contains(buf.String(), "max file size: 1.0 KiB") {
+		t.Errorf("unexpected text output %q", buf.String())
+	}
+}
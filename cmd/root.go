@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/parnexcodes/woof/internal/config"
 	"github.com/parnexcodes/woof/internal/logging"
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/timefmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -14,6 +17,13 @@ var (
 	verbose     bool
 	concurrency int
 	outputFormat string
+	timeFormat  string
+	outputTemplate string
+	useUTC      bool
+	logFile     string
+	logMaxSize  string
+	logLevel    string
+	userAgent   string
 
 	rootCmd = &cobra.Command{
 		Use:   "woof",
@@ -36,20 +46,41 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (required to use YAML configuration)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().IntVarP(&concurrency, "concurrency", "c", 5, "maximum number of parallel uploads")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "output format (text, json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "output format (text, json, jsonl, markdown, template, urls)")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "output-template", "", "Go text/template rendered per result with -o template, e.g. '{{.FileName}} {{.URL}}'")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", "rfc3339", "timestamp format for metadata and output (rfc3339, unix, local)")
+	rootCmd.PersistentFlags().BoolVar(&useUTC, "utc", false, "emit timestamps in UTC instead of local time")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "error", "lowest level logged: error, warn, info or debug (--verbose is the same as debug)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "also write logs as JSON to this file")
+	rootCmd.PersistentFlags().StringVar(&logMaxSize, "log-max-size", "10MB", "rotate the log file to <log-file>.1 once it would grow past this size")
+	rootCmd.PersistentFlags().StringVar(&userAgent, "user-agent", "", "User-Agent sent to providers (default woof/<version>)")
 
 	// Bind flags to viper
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("concurrency", rootCmd.PersistentFlags().Lookup("concurrency"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	viper.BindPFlag("output-template", rootCmd.PersistentFlags().Lookup("output-template"))
+	viper.BindPFlag("time-format", rootCmd.PersistentFlags().Lookup("time-format"))
+	viper.BindPFlag("utc", rootCmd.PersistentFlags().Lookup("utc"))
+	viper.BindPFlag("log_level", rootCmd.PersistentFlags().Lookup("log-level"))
+	viper.BindPFlag("log_file", rootCmd.PersistentFlags().Lookup("log-file"))
+	viper.BindPFlag("log_max_size", rootCmd.PersistentFlags().Lookup("log-max-size"))
+	viper.BindPFlag("user_agent", rootCmd.PersistentFlags().Lookup("user-agent"))
 
 	// Set default values
 	viper.SetDefault("concurrency", 5)
 	viper.SetDefault("output", "text")
+	viper.SetDefault("time-format", "rfc3339")
 
 	// Add subcommands
 	rootCmd.AddCommand(uploadCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(catCmd)
+	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(providersCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(historyCmd)
 }
 
 func initConfig() {
@@ -77,4 +108,68 @@ func initConfig() {
 			logging.ConfigLoad("CLI flags only", nil)
 		}
 	}
-}
\ No newline at end of file
+
+	if err := configureLogFile(viper.GetString("log_file"), viper.GetString("log_max_size")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening log file: %v\n", err)
+		os.Exit(1)
+	}
+	providertypes.SetUserAgent(userAgentString())
+}
+
+// userAgentString returns the User-Agent from --user-agent or user_agent,
+// defaulting to woof/<version>. A provider's user_agent setting still wins.
+func userAgentString() string {
+	if agent := viper.GetString("user_agent"); agent != "" {
+		return agent
+	}
+	return "woof/" + version
+}
+
+// initLogging initializes logging to stderr at the level from --log-level or
+// log_level; --verbose always logs down to debug
+func initLogging() error {
+	// log_redact_params and log_redact_headers add to what verbose HTTP logs mask
+	rules := logging.DefaultRedactionRules()
+	rules.QueryParams = append(rules.QueryParams, viper.GetStringSlice("log_redact_params")...)
+	rules.Headers = append(rules.Headers, viper.GetStringSlice("log_redact_headers")...)
+	logging.SetRedactionRules(rules)
+
+	if viper.GetBool("verbose") {
+		logging.Init(true, os.Stderr)
+		return nil
+	}
+	level, err := logging.ParseLevel(viper.GetString("log_level"))
+	if err != nil {
+		return fmt.Errorf("invalid --log-level: %w", err)
+	}
+	logging.InitWithLevel(level, os.Stderr)
+	return nil
+}
+
+// configureLogFile copies log entries to path, rotating it at maxSize; an
+// empty path leaves logging to stderr only
+func configureLogFile(path, maxSize string) error {
+	if path == "" {
+		return nil
+	}
+	size, err := config.ParseByteSize(maxSize)
+	if err != nil {
+		return fmt.Errorf("invalid --log-max-size: %w", err)
+	}
+	file, err := logging.OpenRotatingFile(path, size)
+	if err != nil {
+		return err
+	}
+	logging.SetFileOutput(file)
+	return nil
+}
+
+// configureTimeFormat applies --time-format and --utc to all emitted timestamps
+func configureTimeFormat() error {
+	format, err := timefmt.ParseFormat(viper.GetString("time-format"))
+	if err != nil {
+		return err
+	}
+	timefmt.SetDefault(timefmt.Formatter{Format: format, UTC: viper.GetBool("utc")})
+	return nil
+}
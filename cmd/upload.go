@@ -2,17 +2,21 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/parnexcodes/woof/internal/config"
 	"github.com/parnexcodes/woof/internal/logging"
 	"github.com/parnexcodes/woof/internal/output"
+	providertypes "github.com/parnexcodes/woof/internal/providers"
 	"github.com/parnexcodes/woof/internal/uploader"
 	providerpkg "github.com/parnexcodes/woof/pkg/providers"
 	"github.com/spf13/cobra"
@@ -27,8 +31,46 @@ var (
 	retryAttempts int
 	retryDelay    time.Duration
 	progress      bool
+	deadline      time.Duration
+	onSuccessCmd  string
+	onFailureCmd  string
+	stripExif     bool
+	backoff       string
+	maxTotalBytes string
+	abortOverBudget bool
+	follow        bool
+	noValidate    bool
+	maxNameLen    int
+	groupResults  bool
+	dnsServer     string
+	fallbackDelay time.Duration
+	timeout       time.Duration
+	connectTimeout time.Duration
+	listExtensions bool
+	mirror        bool
+	raceProviders bool
+	prehash       bool
+	checksum      bool
+	dryRun        bool
+	quiet         bool
+	ioBufferSize  string
+	outputFile    string
+	clipboard     bool
+	manifestPath  string
+	manifestFormat string
+	showQR        bool
+	recordHistory bool
+	includeGlobs  []string
+	excludeGlobs  []string
+	includeHidden bool
+	maxFiles      int
+	maxTotalSize  string
+	totalProgress bool
 )
 
+// followInterval is how often the --follow dashboard is redrawn
+const followInterval = 500 * time.Millisecond
+
 var uploadCmd = &cobra.Command{
 	Use:   "upload",
 	Short: "Upload files and directories to hosting providers",
@@ -45,14 +87,52 @@ func init() {
 	uploadCmd.Flags().BoolVar(&useAll, "all", false, "use all available providers regardless of configuration")
 	uploadCmd.Flags().StringSliceVarP(&files, "file", "f", []string{}, "files to upload (can be used multiple times, supports glob patterns)")
 	uploadCmd.Flags().StringSliceVarP(&folders, "folder", "d", []string{}, "folders to upload (can be used multiple times)")
+	uploadCmd.Flags().StringSliceVar(&includeGlobs, "include", []string{}, "only upload files in folders matching these globs, e.g. '*.jpg' or 'docs/**/*.md' (can be used multiple times)")
+	uploadCmd.Flags().StringSliceVar(&excludeGlobs, "exclude", []string{}, "skip files and directories in folders matching these globs; wins over --include (can be used multiple times)")
+	uploadCmd.Flags().BoolVar(&includeHidden, "hidden", false, "also upload files and directories inside folders whose name starts with '.', such as .git and .env")
 	uploadCmd.Flags().IntVar(&retryAttempts, "retry-attempts", 3, "number of retry attempts per file")
 	uploadCmd.Flags().DurationVar(&retryDelay, "retry-delay", 2*time.Second, "delay between retry attempts")
 	uploadCmd.Flags().BoolVar(&progress, "progress", true, "show upload progress")
+	uploadCmd.Flags().BoolVar(&totalProgress, "total-progress", false, "show one progress bar for the whole run (files done and share of all bytes) instead of one per file; scans everything before the first upload")
+	uploadCmd.Flags().StringVar(&ioBufferSize, "io-buffer-size", "256KiB", "read buffer for files when uploading and hashing, e.g. 1MiB (minimum 4KiB)")
+	uploadCmd.Flags().StringVar(&outputFile, "output-file", "", "append the URL (and delete URL, if any) of each successful upload to this file as uploads finish")
+	uploadCmd.Flags().BoolVar(&clipboard, "clipboard", false, "copy the URLs of successful uploads to the clipboard when the run finishes")
+	uploadCmd.Flags().StringVar(&manifestPath, "manifest", "", "write a manifest of the successful uploads (name, size, sha256, provider, URL, expiry) to this file after the run")
+	uploadCmd.Flags().StringVar(&manifestFormat, "manifest-format", "json", "manifest format: json or sha256sums")
+	uploadCmd.Flags().BoolVar(&recordHistory, "history", false, "record successful uploads in the upload history shown by woof history (default from history.enabled)")
+	uploadCmd.Flags().BoolVar(&showQR, "qr", false, "print the URL of each successful upload as a QR code on stderr when the run finishes (terminal only)")
+	uploadCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "print only the URL of each successful upload; failures go to stderr (same as -o urls)")
+	uploadCmd.Flags().BoolVar(&follow, "follow", false, "show a live dashboard updating in place (text output on a terminal only)")
+	uploadCmd.Flags().DurationVar(&deadline, "deadline", 0, "wall-clock limit for the whole run; remaining uploads are cancelled once it passes (0 = no limit)")
+
+	uploadCmd.Flags().StringVar(&onSuccessCmd, "on-success", "", "command to run after each successful upload; placeholders: {url} {file} {name} {provider}")
+	uploadCmd.Flags().StringVar(&onFailureCmd, "on-failure", "", "command to run after each failed upload; placeholders: {file} {name} {provider} {error}")
+
+	uploadCmd.Flags().StringVar(&backoff, "backoff", "", "retry backoff strategy: constant, linear or exponential (default from config, exponential)")
+	uploadCmd.Flags().StringVar(&maxTotalBytes, "max-total-bytes", "", "stop starting new uploads once this many bytes would be transferred, e.g. 5GB (empty = no limit)")
+	uploadCmd.Flags().BoolVar(&abortOverBudget, "abort-over-budget", false, "abort in-flight uploads that cross --max-total-bytes instead of letting them finish")
+	uploadCmd.Flags().IntVar(&maxFiles, "max-files", 0, "refuse to start when the files to upload number more than this (0 = no limit)")
+	uploadCmd.Flags().StringVar(&maxTotalSize, "max-total-size", "", "refuse to start when the files to upload add up to more than this, e.g. 10GB (empty = no limit)")
+	uploadCmd.Flags().BoolVar(&noValidate, "no-validate", false, "skip pre-upload and response validation to exercise raw provider behavior")
+	uploadCmd.Flags().IntVar(&maxNameLen, "max-name-len", 0, "trim uploaded file names to this many bytes, keeping the extension and adding a short hash (0 = no limit)")
+	uploadCmd.Flags().BoolVar(&mirror, "mirror", false, "upload every file to all selected providers instead of stopping at the first that succeeds")
+	uploadCmd.Flags().BoolVar(&raceProviders, "race", false, "upload every file to all selected providers at once, keeping the first link and cancelling the other uploads")
+	uploadCmd.MarkFlagsMutuallyExclusive("mirror", "race")
+	uploadCmd.Flags().BoolVar(&groupResults, "group", false, "write one record per file listing every provider's link instead of one per upload")
+	uploadCmd.Flags().StringVar(&dnsServer, "dns-server", "", "resolve provider hosts with this DNS server (IP or host:port) instead of the system resolver")
+	uploadCmd.Flags().DurationVar(&fallbackDelay, "fallback-delay", 0, "how long a dual-stack connection waits before racing the other IP family (0 = 300ms default, negative disables)")
+	uploadCmd.Flags().DurationVar(&timeout, "timeout", 0, "limit on each provider request, replacing the timeout setting of every provider (0 = use the config)")
+	uploadCmd.Flags().DurationVar(&connectTimeout, "connect-timeout", 0, "limit on establishing each provider connection (0 = use the config, or 30s)")
+	uploadCmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the files that would be uploaded and the provider each would go to, without any network calls")
+	uploadCmd.Flags().BoolVar(&listExtensions, "list-extensions", false, "print the supported extensions and size limit of each selected provider and exit")
+	uploadCmd.Flags().BoolVar(&checksum, "checksum", false, "compute a SHA-256 of each file while it uploads and include it in the results")
+	uploadCmd.Flags().BoolVar(&prehash, "prehash", false, "compute SHA-256 and MD5 of every file before uploading so providers can use them up front")
+	uploadCmd.Flags().BoolVar(&stripExif, "strip-exif", false, "remove EXIF/XMP metadata from JPEG, PNG and TIFF images before upload")
 
-	viper.BindPFlag("providers", uploadCmd.Flags().Lookup("providers"))
 	viper.BindPFlag("retry-attempts", uploadCmd.Flags().Lookup("retry-attempts"))
 	viper.BindPFlag("retry-delay", uploadCmd.Flags().Lookup("retry-delay"))
 	viper.BindPFlag("progress", uploadCmd.Flags().Lookup("progress"))
+	viper.BindPFlag("deadline", uploadCmd.Flags().Lookup("deadline"))
 
 	viper.SetDefault("retry-attempts", 3)
 	viper.SetDefault("retry-delay", 2*time.Second)
@@ -116,14 +196,20 @@ func validatePaths(files []string, folders []string) error {
 }
 
 func runUpload(cmd *cobra.Command, args []string) error {
-	// Initialize logging system with verbose flag
-	logging.Init(viper.GetBool("verbose"), os.Stderr)
+	// Initialize logging at the level from --log-level or --verbose
+	if err := initLogging(); err != nil {
+		return err
+	}
 
 	// Validate flags
-	if len(files) == 0 && len(folders) == 0 {
+	if len(files) == 0 && len(folders) == 0 && !listExtensions {
 		return fmt.Errorf("no files or folders specified. Use --file/-f for files or --folder/-d for directories")
 	}
 
+	if err := configureTimeFormat(); err != nil {
+		return err
+	}
+
 	logging.FlagProcessing("files", len(files))
 	logging.FlagProcessing("folders", len(folders))
 
@@ -140,6 +226,9 @@ func runUpload(cmd *cobra.Command, args []string) error {
 
 	// Combine all paths for the uploader
 	paths := append(expandedFiles, folders...)
+	if len(paths) == 0 && !listExtensions {
+		return noFilesMatched(cmd)
+	}
 
 	// Load configuration
 	configSource := "CLI flags only"
@@ -163,54 +252,59 @@ func runUpload(cmd *cobra.Command, args []string) error {
 		"providers_count": len(cfg.Providers),
 	})
 
-	// Create context with cancellation
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create context with cancellation, bounded by the batch deadline if set
+	batchDeadline := viper.GetDuration("deadline")
+	ctx, cancel := withDeadline(context.Background(), batchDeadline)
 	defer cancel()
 
-	// Handle signals for graceful shutdown
+	// Handle signals for graceful shutdown: in-flight uploads are cancelled and
+	// still reported, then the run ends with an interrupted error
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	var interrupted atomic.Bool
 	go func() {
 		<-sigChan
+		interrupted.Store(true)
 		cancel()
 	}()
 
 	// Create uploader
 	upldr := uploader.NewDefaultUploader()
 
-	// Create provider factory
-	factory := providerpkg.NewFactory()
-
-	// Get provider instances using the new hierarchy
-	var providerList []uploader.Provider
-	var providerMode string
-	var providerNames []string
+	// Dial settings apply to every provider client, so set them before creating providers
+	if err := providertypes.ConfigureTransport(providertypes.DialConfig{
+		FallbackDelay: fallbackDelay,
+		DNSServer:     dnsServer,
+	}); err != nil {
+		return fmt.Errorf("invalid --dns-server: %w", err)
+	}
 
-	if useAll {
-		// Use all available providers regardless of configuration
-		providerList, err = factory.CreateAllProviders()
-		providerMode = "all"
-	} else if len(providers) > 0 {
-		// Use specified providers
-		providerList, err = factory.CreateProvidersFromNames(providers, cfg.Providers)
-		providerMode = "specified"
-		providerNames = providers
-	} else {
-		// Use all enabled providers from configuration
-		providerList, err = factory.CreateProviders(cfg.GetEnabledProviders())
-		providerMode = "enabled"
+	if timeout < 0 || connectTimeout < 0 {
+		return fmt.Errorf("--timeout and --connect-timeout must not be negative")
 	}
 
+	// Create provider factory with retry behaviour from flags and configuration
+	wrapperConfig, err := buildWrapperConfig(cmd, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create providers: %w", err)
+		return err
 	}
+	factoryConfig := providerpkg.DefaultFactoryConfig()
+	factoryConfig.WrapperConfig = wrapperConfig
+	factoryConfig.DryRun = dryRun
+	factoryConfig.Timeout = timeout
+	factoryConfig.ConnectTimeout = connectTimeout
+	factory := providerpkg.NewFactoryWithConfig(factoryConfig)
 
-	// Extract provider names for debug output
-	for _, provider := range providerList {
-		providerNames = append(providerNames, provider.Name())
+	// Get provider instances using the new hierarchy
+	providerList, err := selectProviders(factory, cfg)
+	if err != nil {
+		return err
 	}
 
-	logging.ProviderSelection(providerMode, providerNames)
+	if listExtensions {
+		return writeProviderList(cmd.OutOrStdout(), providerpkg.DescribeProviders(providerList), viper.GetString("output"))
+	}
 
 	if len(providerList) == 0 {
 		var helpMsg strings.Builder
@@ -227,6 +321,39 @@ func runUpload(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%s", helpMsg.String())
 	}
 
+	if err := uploader.ValidateMaxNameLen(maxNameLen); err != nil {
+		return fmt.Errorf("invalid --max-name-len: %w", err)
+	}
+
+	var byteCap int64
+	if maxTotalBytes != "" {
+		byteCap, err = config.ParseByteSize(maxTotalBytes)
+		if err != nil {
+			return fmt.Errorf("invalid --max-total-bytes: %w", err)
+		}
+	}
+
+	limits := batchLimits{MaxFiles: maxFiles}
+	if maxTotalSize != "" {
+		limits.MaxTotalSize, err = config.ParseByteSize(maxTotalSize)
+		if err != nil {
+			return fmt.Errorf("invalid --max-total-size: %w", err)
+		}
+	}
+
+	bufferSize, err := config.ParseByteSize(ioBufferSize)
+	if err == nil {
+		err = uploader.ValidateIOBufferSize(bufferSize)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid --io-buffer-size: %w", err)
+	}
+
+	pathFilter, err := uploader.NewPathFilter(includeGlobs, excludeGlobs)
+	if err != nil {
+		return fmt.Errorf("invalid --include/--exclude: %w", err)
+	}
+
 	uploadConfig := uploader.UploadConfig{
 		Concurrency:   viper.GetInt("concurrency"),
 		Providers:     providerList,
@@ -234,6 +361,127 @@ func runUpload(cmd *cobra.Command, args []string) error {
 		Verbose:       viper.GetBool("verbose"),
 		RetryAttempts: cfg.Upload.RetryAttempts,
 		RetryDelay:    cfg.Upload.RetryDelay,
+		StripMetadata: stripExif,
+		MaxTotalBytes: byteCap,
+		AbortOverBudget: abortOverBudget,
+		MaxNameLen:    maxNameLen,
+		PreHash:       prehash,
+		IOBufferSize:  int(bufferSize),
+		Checksum:      checksum || manifestPath != "", // The manifest records each file's SHA-256
+		ChunkSize:     cfg.Upload.ChunkSize,
+		Filter:        pathFilter,
+		IncludeHidden: includeHidden,
+		PlanTotals:    totalProgress,
+	}
+	if mirror {
+		uploadConfig.Strategy = uploader.StrategyMirror
+	}
+	if raceProviders {
+		uploadConfig.Strategy = uploader.StrategyRace
+	}
+
+	// A dry run stops after planning; providers initialize without network calls
+	if dryRun {
+		if err := factory.InitializeProviders(ctx, providerList); err != nil {
+			return err
+		}
+		files, err := writeDryRun(ctx, cmd.OutOrStdout(), paths, providerList, uploadConfig)
+		if err != nil {
+			return err
+		}
+		if files == 0 {
+			return noFilesMatched(cmd)
+		}
+		return nil
+	}
+
+	// Refuse an accidentally huge batch before any output or upload starts
+	if err := checkBatchLimits(ctx, paths, uploadConfig, limits); err != nil {
+		return err
+	}
+
+	// Create output handler; --follow falls back to line output when stdout is not a terminal
+	outputFormat := viper.GetString("output")
+	if quiet {
+		outputFormat = "urls"
+	}
+	var outputHandler output.Handler
+	if follow && strings.EqualFold(outputFormat, "text") && output.IsTerminal(os.Stdout) {
+		aggregator := output.NewAggregator()
+		uploadConfig.ProgressListeners = append(uploadConfig.ProgressListeners, aggregator)
+		followHandler := output.NewFollowHandler(os.Stdout, aggregator, followInterval)
+		defer followHandler.Close()
+		outputHandler = followHandler
+	} else {
+		outputHandler, err = output.NewHandlerWithConfig(outputFormat, output.HandlerConfig{
+			Template:      viper.GetString("output-template"),
+			TotalProgress: totalProgress,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create output handler: %w", err)
+		}
+		if groupResults {
+			// Without mirroring providers are tried until one succeeds, so every file yields one result
+			resultsPerFile := 1
+			if mirror {
+				resultsPerFile = len(providerList)
+			}
+			outputHandler, err = output.NewGroupingHandler(outputHandler, resultsPerFile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Attach post-upload hooks
+	if onSuccessCmd != "" || onFailureCmd != "" {
+		hooks, err := newHookHandler(outputHandler, onSuccessCmd, onFailureCmd)
+		if err != nil {
+			return err
+		}
+		defer hooks.Wait()
+		outputHandler = hooks
+	}
+
+	// Copy links once the run ends
+	if clipboard {
+		outputHandler = output.NewClipboardHandler(outputHandler, output.NewClipboard(), os.Stderr)
+	}
+
+	// QR codes are only useful to someone looking at the terminal
+	if showQR && output.IsTerminal(os.Stderr) {
+		outputHandler = output.NewQRHandler(outputHandler, output.NewQRRenderer(os.Stderr))
+	}
+
+	if manifestPath != "" {
+		outputHandler, err = output.NewManifestHandler(outputHandler, manifestPath, manifestFormat)
+		if err != nil {
+			return err
+		}
+	}
+
+	historyStore, err := uploadHistoryStore(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	if historyStore != nil {
+		outputHandler = output.NewHistoryHandler(outputHandler, historyStore, os.Stderr)
+	}
+
+	// Open the URL file before uploading so a bad path fails the run up front
+	var urlFile io.Writer
+	if outputFile != "" {
+		file, err := openURLFile(outputFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		urlFile = file
+	}
+
+	// Run one-time provider setup (server selection, token checks) before any upload
+	if err := factory.InitializeProviders(ctx, providerList); err != nil {
+		return err
 	}
 
 	// Start uploads
@@ -242,15 +490,157 @@ func runUpload(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to start upload: %w", err)
 	}
 
-	// Create output handler
-	outputHandler, err := output.NewHandler(viper.GetString("output"))
+	// Handle progress and results
+	progressConfig := loadUploadConfig()
+	outcome, err := handleUploadOutputs(resultCh, progressCh, outputHandler, urlFile, progressConfig.Progress)
+	if err != nil {
+		return err
+	}
+
+	if interrupted.Load() {
+		cmd.SilenceUsage = true
+		return interruptedError(outcome)
+	}
+
+	// A deadline that passes before anything is found says nothing about the files
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("batch deadline of %s exceeded: %d uploads cancelled", batchDeadline, outcome.Cancelled)
+	}
+
+	// Folders can be empty or hold only ignored files
+	if outcome.Total() == 0 {
+		return noFilesMatched(cmd)
+	}
+
+	if outcome.Skipped > 0 {
+		return fmt.Errorf("byte budget of %s exhausted: %d uploads skipped", maxTotalBytes, outcome.Skipped)
+	}
+
+	if err := singleUploadExitError(outcome, len(providerList)); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
+	// Scripts reading URLs only learn about failures from the exit status
+	if strings.EqualFold(outputFormat, "urls") && outcome.Failed > 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{
+			Code: ExitFailure,
+			Err:  fmt.Errorf("%d of %d uploads failed", outcome.Failed, outcome.Total()),
+		}
+	}
+
+	return nil
+}
+
+// ErrNoFilesMatched reports a run that found nothing to upload
+var ErrNoFilesMatched = errors.New("no files matched the given patterns/folders")
+
+// noFilesMatched warns that there is nothing to upload and returns an error
+// carrying ExitNoFiles, so scripts can tell an empty run from a successful one
+func noFilesMatched(cmd *cobra.Command) error {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", ErrNoFilesMatched)
+	return &ExitError{Code: ExitNoFiles, Err: ErrNoFilesMatched}
+}
+
+// selectProviders creates the providers for the run: every available provider
+// with --all, the named ones with --providers, otherwise those enabled in the
+// configuration
+func selectProviders(factory *providerpkg.Factory, cfg *config.Config) ([]uploader.Provider, error) {
+	var providerList []uploader.Provider
+	var providerMode string
+	var providerNames []string
+	var err error
+
+	if useAll {
+		// Use all available providers regardless of configuration
+		providerList, err = factory.CreateAllProviders()
+		providerMode = "all"
+	} else if len(providers) > 0 {
+		// Use specified providers, resolving configured aliases first
+		var resolvedNames []string
+		resolvedNames, err = cfg.ResolveProviderNames(providers)
+		if err != nil {
+			return nil, err
+		}
+		providerList, err = factory.CreateProvidersFromNames(resolvedNames, cfg.Providers)
+		providerMode = "specified"
+		providerNames = resolvedNames
+	} else {
+		// Use all enabled providers from configuration
+		providerList, err = factory.CreateProviders(cfg.GetEnabledProviders())
+		providerMode = "enabled"
+	}
+
 	if err != nil {
-		return fmt.Errorf("failed to create output handler: %w", err)
+		return nil, fmt.Errorf("failed to create providers: %w", err)
 	}
 
-	// Handle progress and results
-	progressConfig := loadUploadConfig()
-	return handleUploadOutputs(ctx, resultCh, progressCh, outputHandler, progressConfig.Progress)
+	// Extract provider names for debug output
+	for _, provider := range providerList {
+		providerNames = append(providerNames, provider.Name())
+	}
+
+	logging.ProviderSelection(providerMode, providerNames)
+	return providerList, nil
+}
+
+// buildWrapperConfig derives the consistency wrapper settings. Explicitly set
+// flags take precedence over the upload section of the configuration.
+func buildWrapperConfig(cmd *cobra.Command, cfg *config.Config) (providertypes.WrapperConfig, error) {
+	wrapperConfig := providertypes.DefaultWrapperConfig()
+	wrapperConfig.MaxRetries = cfg.Upload.RetryAttempts
+	wrapperConfig.RetryDelay = cfg.Upload.RetryDelay
+	wrapperConfig.BackoffFactor = cfg.Upload.BackoffFactor
+	wrapperConfig.MaxRetryDelay = cfg.Upload.MaxRetryDelay
+	wrapperConfig.MaxRetryElapsed = cfg.Upload.MaxRetryElapsed
+
+	if cmd.Flags().Changed("retry-attempts") {
+		wrapperConfig.MaxRetries = retryAttempts
+	}
+	if cmd.Flags().Changed("retry-delay") {
+		wrapperConfig.RetryDelay = retryDelay
+	}
+
+	strategyName := cfg.Upload.Backoff
+	if cmd.Flags().Changed("backoff") {
+		strategyName = backoff
+	}
+	strategy, err := providertypes.ParseBackoffStrategy(strategyName)
+	if err != nil {
+		return wrapperConfig, err
+	}
+	wrapperConfig.Backoff = strategy
+
+	// --no-validate passes files and responses through exactly as the provider handles them
+	if noValidate {
+		wrapperConfig.PreUploadValidation = false
+		wrapperConfig.ValidateResponses = false
+	}
+
+	return wrapperConfig, nil
+}
+
+// interruptedError reports a run stopped by a signal and how many uploads it cut short
+func interruptedError(outcome uploadOutcome) error {
+	noun := "uploads"
+	if outcome.Cancelled == 1 {
+		noun = "upload"
+	}
+	return &ExitError{
+		Code: ExitInterrupted,
+		Err:  fmt.Errorf("interrupted, %d %s cancelled", outcome.Cancelled, noun),
+	}
+}
+
+// withDeadline derives the batch context, applying an overall timeout when deadline is positive
+func withDeadline(parent context.Context, deadline time.Duration) (context.Context, context.CancelFunc) {
+	if deadline > 0 {
+		return context.WithTimeout(parent, deadline)
+	}
+	return context.WithCancel(parent)
 }
 
 func loadUploadConfig() struct {
@@ -270,27 +660,67 @@ func loadUploadConfig() struct {
 }
 
 
-func handleUploadOutputs(ctx context.Context, resultCh <-chan uploader.UploadResult, progressCh <-chan uploader.ProgressInfo, outputHandler output.Handler, showProgress bool) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
+// uploadOutcome tallies the results seen by handleUploadOutputs
+type uploadOutcome struct {
+	uploader.Summary
+	LastError error // Error of the most recent failed upload
+}
 
-		case result, ok := <-resultCh:
-			if !ok {
-				return nil // All results processed
-			}
-			if err := outputHandler.HandleResult(result); err != nil {
-				return err
-			}
+// singleUploadExitError reports the failure of a single-provider single-file run
+// with an exit code derived from the provider error type
+func singleUploadExitError(outcome uploadOutcome, providerCount int) error {
+	if providerCount != 1 || outcome.Failed != 1 || outcome.Succeeded+outcome.Cancelled+outcome.Skipped > 0 {
+		return nil
+	}
+	return &ExitError{
+		Code: exitCodeForError(outcome.LastError),
+		Err:  fmt.Errorf("upload failed: %w", outcome.LastError),
+	}
+}
 
-		case progress, ok := <-progressCh:
-			if !ok || !showProgress {
-				continue
-			}
-			if err := outputHandler.HandleProgress(progress); err != nil {
-				return err
-			}
-		}
+// handlerSink adapts an output handler to the uploader's Sink interface
+type handlerSink struct {
+	handler      output.Handler
+	showProgress bool
+	lastError    error
+}
+
+func (s *handlerSink) OnResult(result uploader.UploadResult) error {
+	if result.Error != nil && !result.Cancelled && !result.Skipped {
+		s.lastError = result.Error
 	}
-}
\ No newline at end of file
+	return s.handler.HandleResult(result)
+}
+
+func (s *handlerSink) OnProgress(progress uploader.ProgressInfo) error {
+	if !s.showProgress {
+		return nil
+	}
+	return s.handler.HandleProgress(progress)
+}
+
+// OnSummary renders the run summary for handlers that support it
+func (s *handlerSink) OnSummary(summary uploader.Summary) error {
+	if sh, ok := s.handler.(output.SummaryHandler); ok {
+		return sh.HandleSummary(summary)
+	}
+	return nil
+}
+
+// handleUploadOutputs drains results until the uploader closes the channel, so
+// uploads interrupted by cancellation are still reported, then closes the
+// handler. When urlFile is set, successful uploads are also recorded there as
+// they complete.
+func handleUploadOutputs(resultCh <-chan uploader.UploadResult, progressCh <-chan uploader.ProgressInfo, outputHandler output.Handler, urlFile io.Writer, showProgress bool) (uploadOutcome, error) {
+	if urlFile != nil {
+		outputHandler = newURLFileHandler(outputHandler, urlFile)
+	}
+	sink := &handlerSink{handler: outputHandler, showProgress: showProgress}
+	summary, err := uploader.Drain(resultCh, progressCh, sink)
+
+	// Closing writes whatever the handler holds back until the end of the run
+	if closeErr := outputHandler.Close(); err == nil {
+		err = closeErr
+	}
+	return uploadOutcome{Summary: summary, LastError: sink.lastError}, err
+}
@@ -2,11 +2,53 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/output"
+	providertypes "github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/timefmt"
+	"github.com/parnexcodes/woof/internal/uploader"
+	providerpkg "github.com/parnexcodes/woof/pkg/providers"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
+// slowProvider blocks each upload until the delay passes or the context is cancelled
+type slowProvider struct {
+	delay time.Duration
+}
+
+func (p *slowProvider) Name() string { return "slow" }
+
+func (p *slowProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providertypes.ProviderResponse, error) {
+	select {
+	case <-time.After(p.delay):
+		return &providertypes.ProviderResponse{URL: "https://example.com/" + filepath.Base(filePath)}, nil
+	case <-ctx.Done():
+		return nil, providertypes.NewNetworkError("request aborted", ctx.Err())
+	}
+}
+
+func (p *slowProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *slowProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *slowProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
 func TestUploadCommand_NoFlagsError(t *testing.T) {
 	// Test that running upload without any flags shows the expected error
 	root := rootCmd
@@ -190,4 +232,398 @@ func TestValidatePaths(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUploadDeadline_CancelsRemainingUploads(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("content"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	ctx, cancel := withDeadline(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resultCh, progressCh, err := uploader.NewDefaultUploader().Upload(ctx, []string{dir}, uploader.UploadConfig{
+		Concurrency: 2,
+		Providers:   []uploader.Provider{&slowProvider{delay: 5 * time.Second}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	start := time.Now()
+	outcome, err := handleUploadOutputs(resultCh, progressCh, output.NewTextHandler(buf), nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("uploads were not cancelled at the deadline, took %s", elapsed)
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", ctx.Err())
+	}
+	if outcome.Cancelled != 2 || outcome.Failed != 0 || outcome.Succeeded != 0 {
+		t.Errorf("expected 2 cancelled uploads, got %+v", outcome)
+	}
+	if strings.Count(buf.String(), "CANCELLED") != 2 {
+		t.Errorf("expected cancelled uploads in output, got:\n%s", buf.String())
+	}
+}
+
+func TestUploadTimeout_OverridesProviderSetting(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	factoryConfig := providerpkg.DefaultFactoryConfig()
+	factoryConfig.Timeout = time.Second
+	provider, err := providerpkg.NewFactoryWithConfig(factoryConfig).CreateProviderWithWrapper(config.ProviderConfig{
+		Name:     "buzzheavier",
+		Settings: map[string]interface{}{"upload_url": server.URL, "timeout": "10m"},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	_, err = provider.Upload(context.Background(), "slow.txt", strings.NewReader("content"), 7)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected the 1s timeout to replace the 10m setting, took %s", elapsed)
+	}
+	var providerErr *providertypes.ProviderError
+	if !errors.As(err, &providerErr) || providerErr.Type != providertypes.ErrorTypeNetwork {
+		t.Errorf("expected a network error, got %v", err)
+	}
+}
+
+// quirkyProvider accepts tiny files only and answers without a URL
+type quirkyProvider struct{}
+
+func (p *quirkyProvider) Name() string { return "quirky" }
+
+func (p *quirkyProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providertypes.ProviderResponse, error) {
+	return &providertypes.ProviderResponse{ID: "abc", Metadata: map[string]string{"path": "/f/abc"}}, nil
+}
+
+func (p *quirkyProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *quirkyProvider) GetMaxFileSize() int64 { return 1 }
+
+func (p *quirkyProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+func TestBuildWrapperConfig_NoValidate(t *testing.T) {
+	logging.Init(false, io.Discard)
+	cfg := &config.Config{Upload: config.UploadConfig{RetryAttempts: 0, Backoff: "constant"}}
+
+	wrapperConfig, err := buildWrapperConfig(uploadCmd, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wrapped := providertypes.NewConsistencyWrapper(&quirkyProvider{}, wrapperConfig)
+	if _, err := wrapped.Upload(context.Background(), "a.txt", strings.NewReader("content"), 7); err == nil {
+		t.Fatal("expected validation to reject the upload by default")
+	}
+
+	noValidate = true
+	t.Cleanup(func() { noValidate = false })
+
+	wrapperConfig, err = buildWrapperConfig(uploadCmd, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wrapperConfig.PreUploadValidation || wrapperConfig.ValidateResponses {
+		t.Fatalf("expected validation to be disabled, got %+v", wrapperConfig)
+	}
+	wrapped = providertypes.NewConsistencyWrapper(&quirkyProvider{}, wrapperConfig)
+	response, err := wrapped.Upload(context.Background(), "a.txt", strings.NewReader("content"), 7)
+	if err != nil {
+		t.Fatalf("expected the raw response to pass through, got %v", err)
+	}
+	if response.ID != "abc" || response.URL != "" {
+		t.Errorf("expected the provider's response unchanged, got %+v", response)
+	}
+}
+
+// deletableExpiry is the expiry reported by deletableProvider
+var deletableExpiry = time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// deletableProvider answers with a delete URL and an expiry
+type deletableProvider struct{}
+
+func (p *deletableProvider) Name() string { return "deletable" }
+
+func (p *deletableProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providertypes.ProviderResponse, error) {
+	expires := deletableExpiry
+	return &providertypes.ProviderResponse{
+		URL:       "https://example.com/f/abc",
+		DeleteURL: "https://example.com/delete/abc?token=secret",
+		ID:        "abc",
+		Expires:   &expires,
+		Metadata:  map[string]string{"provider": "deletable"},
+	}, nil
+}
+
+func (p *deletableProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *deletableProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *deletableProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+func TestUpload_DeleteURLReachesOutput(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	path := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	for _, format := range []string{"json", "text"} {
+		t.Run(format, func(t *testing.T) {
+			wrapped := providertypes.NewConsistencyWrapper(&deletableProvider{}, providertypes.DefaultWrapperConfig())
+			resultCh, progressCh, err := uploader.NewDefaultUploader().Upload(context.Background(), []string{path}, uploader.UploadConfig{
+				Concurrency: 1,
+				Providers:   []uploader.Provider{wrapped},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			buf := &bytes.Buffer{}
+			var handler output.Handler = output.NewTextHandler(buf)
+			if format == "json" {
+				handler = output.NewJSONHandler(buf)
+			}
+			if _, err := handleUploadOutputs(resultCh, progressCh, handler, nil, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expires := timefmt.Timestamp(deletableExpiry)
+			expected := []string{`"delete_url":"https://example.com/delete/abc?token=secret"`, `"id":"abc"`, `"expires":"` + expires + `"`}
+			if format == "text" {
+				expected = []string{"  delete: https://example.com/delete/abc?token=secret\n", "  expires: " + expires + "\n"}
+			}
+			for _, want := range expected {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected %q in output, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestUpload_OutputFileRecordsURLs(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"a.txt", "b.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	urlPath := filepath.Join(t.TempDir(), "urls.txt")
+	if err := os.WriteFile(urlPath, []byte("https://example.com/earlier\n"), 0644); err != nil {
+		t.Fatalf("failed to seed output file: %v", err)
+	}
+	file, err := openURLFile(urlPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	resultCh, progressCh, err := uploader.NewDefaultUploader().Upload(context.Background(), paths, uploader.UploadConfig{
+		Concurrency: 2,
+		Providers:   []uploader.Provider{&deletableProvider{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := handleUploadOutputs(resultCh, progressCh, output.NewJSONHandler(buf), file, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(urlPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	line := "https://example.com/f/abc\thttps://example.com/delete/abc?token=secret\n"
+	if expected := "https://example.com/earlier\n" + line + line; string(data) != expected {
+		t.Errorf("expected URLs appended to the file, got %q", data)
+	}
+	if !strings.Contains(buf.String(), `"url":"https://example.com/f/abc"`) {
+		t.Errorf("expected stdout output to be unaffected, got:\n%s", buf.String())
+	}
+}
+
+func TestHandleUploadOutputs_ClosesJSONOutput(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"a.txt", "b.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	resultCh, progressCh, err := uploader.NewDefaultUploader().Upload(context.Background(), paths, uploader.UploadConfig{
+		Concurrency: 2,
+		Providers:   []uploader.Provider{&deletableProvider{}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := handleUploadOutputs(resultCh, progressCh, output.NewJSONHandler(buf), io.Discard, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Results []map[string]interface{} `json:"results"`
+		Summary map[string]interface{}   `json:"summary"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON output is incomplete: %v\n%s", err, buf.String())
+	}
+	if len(decoded.Results) != 2 || decoded.Summary["succeeded"] != float64(2) {
+		t.Errorf("unexpected JSON output:\n%s", buf.String())
+	}
+}
+
+// failingCloseHandler reports an error when closed
+type failingCloseHandler struct {
+	output.Handler
+}
+
+func (h failingCloseHandler) Close() error {
+	return errors.New("flush failed")
+}
+
+func TestHandleUploadOutputs_ReturnsCloseError(t *testing.T) {
+	resultCh := make(chan uploader.UploadResult)
+	progressCh := make(chan uploader.ProgressInfo)
+	close(resultCh)
+	close(progressCh)
+
+	handler := failingCloseHandler{Handler: output.NewJSONHandler(io.Discard)}
+	if _, err := handleUploadOutputs(resultCh, progressCh, handler, nil, false); err == nil || err.Error() != "flush failed" {
+		t.Errorf("expected the close error, got %v", err)
+	}
+}
+
+func TestUploadCommand_NoMatchingFiles(t *testing.T) {
+	t.Cleanup(func() {
+		uploadCmd.Flags().Lookup("file").Value.(pflag.SliceValue).Replace(nil)
+		rootCmd.SilenceErrors = false
+		uploadCmd.SilenceErrors = false
+		uploadCmd.SilenceUsage = false
+	})
+
+	// Flag values persist across Execute calls; an earlier --help would win
+	if help := uploadCmd.Flags().Lookup("help"); help != nil {
+		help.Value.Set("false")
+	}
+
+	root := rootCmd
+	root.SetArgs([]string{"upload", "-f", filepath.Join(t.TempDir(), "*.xyz")})
+	stderr := &bytes.Buffer{}
+	root.SetErr(stderr)
+	defer root.SetErr(nil)
+
+	err := root.Execute()
+	if !errors.Is(err, ErrNoFilesMatched) {
+		t.Fatalf("expected ErrNoFilesMatched, got %v", err)
+	}
+	if code := ExitCode(err); code != ExitNoFiles {
+		t.Errorf("expected exit code %d, got %d", ExitNoFiles, code)
+	}
+	if got := stderr.String(); got != "Warning: no files matched the given patterns/folders\n" {
+		t.Errorf("expected only the warning on stderr, got %q", got)
+	}
+}
+
+func TestUploadCommand_DeadlineBeforeAnyResult(t *testing.T) {
+	t.Cleanup(func() {
+		uploadCmd.Flags().Lookup("folder").Value.(pflag.SliceValue).Replace(nil)
+		uploadCmd.Flags().Lookup("deadline").Value.Set("0s")
+		rootCmd.SilenceErrors = false
+		uploadCmd.SilenceErrors = false
+		uploadCmd.SilenceUsage = false
+	})
+	if help := uploadCmd.Flags().Lookup("help"); help != nil {
+		help.Value.Set("false")
+	}
+
+	root := rootCmd
+	// An empty folder gets past path validation but yields no results
+	root.SetArgs([]string{"upload", "--deadline", "1ns", "-d", t.TempDir()})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	defer root.SetOut(nil)
+	defer root.SetErr(nil)
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "batch deadline of 1ns exceeded") {
+		t.Fatalf("expected the deadline to be reported, got %v", err)
+	}
+	if errors.Is(err, ErrNoFilesMatched) {
+		t.Error("expected the deadline instead of no files matched")
+	}
+}
+
+func TestUploadCommand_ProvidersFlagResolvesAliases(t *testing.T) {
+	viper.Set("aliases", map[string]interface{}{"bh": "buzzheavier"})
+	t.Cleanup(func() {
+		viper.Set("aliases", map[string]interface{}{})
+		uploadCmd.Flags().Lookup("file").Value.(pflag.SliceValue).Replace(nil)
+		uploadCmd.Flags().Lookup("providers").Value.(pflag.SliceValue).Replace(nil)
+		uploadCmd.Flags().Lookup("dry-run").Value.Set("false")
+		rootCmd.SilenceErrors = false
+		uploadCmd.SilenceErrors = false
+		uploadCmd.SilenceUsage = false
+	})
+	if help := uploadCmd.Flags().Lookup("help"); help != nil {
+		help.Value.Set("false")
+	}
+
+	path := filepath.Join(t.TempDir(), "x.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	root := rootCmd
+	root.SetArgs([]string{"upload", "--dry-run", "-p", "bh", "-f", path})
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	defer root.SetOut(nil)
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "WOULD UPLOAD x.txt (3 bytes) -> BuzzHeavier") {
+		t.Errorf("expected the alias to select BuzzHeavier, got %q", stdout.String())
+	}
+}
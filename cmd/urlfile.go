@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/output"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// urlFileHandler decorates an output handler and appends the URL of every
+// successful upload to a file, followed by a tab and the delete URL when the
+// provider returned one. Each line is written with a single unbuffered write
+// as its result arrives, so uploads finished before a crash stay recorded.
+type urlFileHandler struct {
+	output.Handler
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newURLFileHandler wraps next so successful uploads are also recorded in w
+func newURLFileHandler(next output.Handler, w io.Writer) *urlFileHandler {
+	return &urlFileHandler{Handler: next, w: w}
+}
+
+// openURLFile opens the --output-file for appending, creating it if needed
+func openURLFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file: %w", err)
+	}
+	return file, nil
+}
+
+// HandleResult records a successful upload, then forwards the result
+func (h *urlFileHandler) HandleResult(result uploader.UploadResult) error {
+	if result.Error == nil && result.URL != "" {
+		line := result.URL
+		if result.DeleteURL != "" {
+			line += "\t" + result.DeleteURL
+		}
+		h.mu.Lock()
+		_, err := io.WriteString(h.w, line+"\n")
+		h.mu.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+	}
+	return h.Handler.HandleResult(result)
+}
+
+// HandleSummary forwards the summary to handlers that render one
+func (h *urlFileHandler) HandleSummary(summary uploader.Summary) error {
+	if sh, ok := h.Handler.(output.SummaryHandler); ok {
+		return sh.HandleSummary(summary)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveProviderName resolves a provider name through the configured aliases.
+// Aliases may point at other aliases; names are matched case-insensitively.
+// Names that are neither configured providers nor aliases are returned lowercased
+// so the caller can report them as unknown providers.
+func (c *Config) ResolveProviderName(name string) (string, error) {
+	aliases := make(map[string]string, len(c.Aliases))
+	for alias, target := range c.Aliases {
+		aliases[strings.ToLower(alias)] = strings.ToLower(strings.TrimSpace(target))
+	}
+
+	current := strings.ToLower(strings.TrimSpace(name))
+	seen := []string{current}
+	for {
+		if c.hasProvider(current) {
+			return current, nil
+		}
+		target, ok := aliases[current]
+		if !ok {
+			if len(seen) > 1 {
+				return "", fmt.Errorf("alias %q resolves to unknown provider %q (via %s)", name, current, strings.Join(seen, " -> "))
+			}
+			return current, nil
+		}
+		for _, visited := range seen {
+			if visited == target {
+				return "", fmt.Errorf("alias %q forms a cycle: %s -> %s", name, strings.Join(seen, " -> "), target)
+			}
+		}
+		seen = append(seen, target)
+		current = target
+	}
+}
+
+// ResolveProviderNames resolves every name through the configured aliases,
+// dropping duplicates that resolve to the same provider
+func (c *Config) ResolveProviderNames(names []string) ([]string, error) {
+	var resolved []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		provider, err := c.ResolveProviderName(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[provider] {
+			seen[provider] = true
+			resolved = append(resolved, provider)
+		}
+	}
+	return resolved, nil
+}
+
+func (c *Config) hasProvider(name string) bool {
+	for _, provider := range c.Providers {
+		if strings.EqualFold(provider.Name, name) {
+			return true
+		}
+	}
+	return false
+}
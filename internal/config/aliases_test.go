@@ -0,0 +1,69 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func aliasTestConfig() *Config {
+	return &Config{
+		Providers: []ProviderConfig{{Name: "buzzheavier"}, {Name: "gofile"}},
+		Aliases: map[string]string{
+			"bh":      "buzzheavier",
+			"gf":      "gofile",
+			"default": "bh",
+			"team":    "default",
+			"broken":  "buzzheavy",
+			"loop-a":  "loop-b",
+			"loop-b":  "loop-a",
+		},
+	}
+}
+
+func TestResolveProviderName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		errMsg   string
+	}{
+		{name: "provider name", input: "gofile", expected: "gofile"},
+		{name: "case insensitive", input: "GoFile", expected: "gofile"},
+		{name: "alias", input: "bh", expected: "buzzheavier"},
+		{name: "alias case insensitive", input: "GF", expected: "gofile"},
+		{name: "chain", input: "team", expected: "buzzheavier"},
+		{name: "not an alias", input: "dropbox", expected: "dropbox"},
+		{name: "alias to unknown provider", input: "broken", errMsg: `resolves to unknown provider "buzzheavy"`},
+		{name: "cycle", input: "loop-a", errMsg: "forms a cycle"},
+	}
+
+	cfg := aliasTestConfig()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ResolveProviderName(tt.input)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveProviderNames_Deduplicates(t *testing.T) {
+	got, err := aliasTestConfig().ResolveProviderNames([]string{"bh", "gf", "buzzheavier", "team"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"buzzheavier", "gofile"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -14,6 +14,8 @@ type Config struct {
 	Output      string          `mapstructure:"output"`
 	Providers   []ProviderConfig `mapstructure:"providers"`
 	Upload      UploadConfig    `mapstructure:"upload"`
+	History     HistoryConfig   `mapstructure:"history"`
+	Aliases     map[string]string `mapstructure:"aliases"` // Nickname -> provider name or another alias
 }
 
 // ProviderConfig holds configuration for a file hosting provider
@@ -25,10 +27,20 @@ type ProviderConfig struct {
 
 // UploadConfig holds upload-specific configuration
 type UploadConfig struct {
-	RetryAttempts int           `mapstructure:"retry_attempts"`
-	RetryDelay    time.Duration `mapstructure:"retry_delay"`
-	ChunkSize     int64         `mapstructure:"chunk_size"`
-	Timeout       time.Duration `mapstructure:"timeout"`
+	RetryAttempts   int           `mapstructure:"retry_attempts"`
+	RetryDelay      time.Duration `mapstructure:"retry_delay"`
+	Backoff         string        `mapstructure:"backoff"`           // constant, linear or exponential
+	BackoffFactor   float64       `mapstructure:"backoff_factor"`    // step/growth multiplier, 0 for the strategy default
+	MaxRetryDelay   time.Duration `mapstructure:"max_retry_delay"`   // cap for a single retry delay
+	MaxRetryElapsed time.Duration `mapstructure:"max_retry_elapsed"` // stop retrying after this long in total
+	ChunkSize       int64         `mapstructure:"chunk_size"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+}
+
+// HistoryConfig controls the local history of successful uploads
+type HistoryConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	File    string `mapstructure:"file"` // ~/.woof/history.jsonl when empty
 }
 
 // LoadConfig loads configuration from file and environment
@@ -43,24 +55,67 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// WOOF_ENABLE_<NAME> environment variables override the file
+	if err := applyProviderEnvOverrides(config); err != nil {
+		return nil, err
+	}
+
+	// WOOF_<NAME>_<SETTING> environment variables supply credentials
+	applyProviderSecretEnv(config)
+
 	return config, nil
 }
 
-func setDefaults() {
-	// Global defaults
-	viper.SetDefault("concurrency", 5)
-	viper.SetDefault("verbose", false)
-	viper.SetDefault("output", "text")
+// defaultSetting is a built-in configuration value with the note written
+// next to it in generated config files
+type defaultSetting struct {
+	key   string
+	value interface{}
+	note  string
+}
 
-	// Upload defaults
-	viper.SetDefault("upload.retry_attempts", 3)
-	viper.SetDefault("upload.retry_delay", "2s")
-	viper.SetDefault("upload.chunk_size", 1024*1024) // 1MB
-	viper.SetDefault("upload.timeout", "30m")
+// globalDefaults are the top-level defaults, in the order config init writes them
+var globalDefaults = []defaultSetting{
+	{key: "concurrency", value: 5},
+	{key: "verbose", value: false},
+	{key: "output", value: "text"},
+	{key: "log_level", value: "error", note: "error, warn, info or debug"},
+	{key: "log_file", value: "", note: "also write logs as JSON to this file"},
+	{key: "log_max_size", value: "10MB", note: "rotate log_file to <log_file>.1 past this size"},
+	{key: "log_redact_params", value: []string{}, note: "extra query parameters masked in verbose HTTP logs"},
+	{key: "log_redact_headers", value: []string{}, note: "extra request headers masked in verbose HTTP logs"},
+	{key: "user_agent", value: "", note: "User-Agent sent to providers, woof/<version> when empty"},
+}
 
-	// Provider defaults
-	viper.SetDefault("providers", []ProviderConfig{
-		{
+// uploadDefaults are the upload.* defaults, in the order config init writes them
+var uploadDefaults = []defaultSetting{
+	{key: "retry_attempts", value: 3},
+	{key: "retry_delay", value: "2s"},
+	{key: "backoff", value: "exponential", note: "constant, linear or exponential"},
+	{key: "backoff_factor", value: 0.0, note: "linear step / exponential growth multiplier, 0 uses 1 for linear and 2 for exponential"},
+	{key: "max_retry_delay", value: "1m", note: "cap for a single retry delay"},
+	{key: "max_retry_elapsed", value: "0s", note: "stop retrying after this long in total, 0 for no limit"},
+	{key: "chunk_size", value: 1024 * 1024, note: "1MB"},
+	{key: "timeout", value: "30m"},
+}
+
+// historyDefaults are the history.* defaults, in the order config init writes them
+var historyDefaults = []defaultSetting{
+	{key: "enabled", value: false, note: "record successful uploads for woof history"},
+	{key: "file", value: "", note: "~/.woof/history.jsonl when empty"},
+}
+
+// defaultProvider is a built-in provider entry with the note written above
+// it in generated config files
+type defaultProvider struct {
+	note   string
+	config ProviderConfig
+}
+
+// defaultProviders are the providers configured when no config file lists any
+var defaultProviders = []defaultProvider{
+	{
+		config: ProviderConfig{
 			Name:    "buzzheavier",
 			Enabled: true,
 			Settings: map[string]interface{}{
@@ -69,7 +124,9 @@ func setDefaults() {
 				"timeout":           "10m",
 			},
 		},
-		{
+	},
+	{
+		config: ProviderConfig{
 			Name:    "gofile",
 			Enabled: true,
 			Settings: map[string]interface{}{
@@ -77,7 +134,98 @@ func setDefaults() {
 				"timeout":    "10m",
 			},
 		},
-	})
+	},
+	{
+		note: "Opt-in: 0x0.st files expire, so it is only used when selected",
+		config: ProviderConfig{
+			Name:    "0x0",
+			Enabled: false,
+			Settings: map[string]interface{}{
+				"upload_url": "https://0x0.st",
+				"timeout":    "10m",
+			},
+		},
+	},
+	{
+		note: "Opt-in: set userhash to upload into a catbox account",
+		config: ProviderConfig{
+			Name:    "catbox",
+			Enabled: false,
+			Settings: map[string]interface{}{
+				"upload_url": "https://catbox.moe/user/api.php",
+				"timeout":    "10m",
+			},
+		},
+	},
+	{
+		note: "Opt-in: file.io links work for a single download",
+		config: ProviderConfig{
+			Name:    "fileio",
+			Enabled: false,
+			Settings: map[string]interface{}{
+				"upload_url": "https://file.io",
+				"timeout":    "10m",
+			},
+		},
+	},
+	{
+		note: "Opt-in: uguu.se keeps files for a few hours only",
+		config: ProviderConfig{
+			Name:    "uguu",
+			Enabled: false,
+			Settings: map[string]interface{}{
+				"upload_url": "https://uguu.se/upload.php",
+				"timeout":    "10m",
+			},
+		},
+	},
+	{
+		note: "Opt-in: litterbox deletes files after the retention window",
+		config: ProviderConfig{
+			Name:    "litterbox",
+			Enabled: false,
+			Settings: map[string]interface{}{
+				"upload_url": "https://litterbox.catbox.moe/resources/internals/api.php",
+				"timeout":    "10m",
+				"retention":  "1h",
+			},
+		},
+	},
+	{
+		note: "Opt-in: tmpfiles.org deletes files after an hour",
+		config: ProviderConfig{
+			Name:    "tmpfiles",
+			Enabled: false,
+			Settings: map[string]interface{}{
+				"upload_url": "https://tmpfiles.org/api/v1/upload",
+				"timeout":    "10m",
+			},
+		},
+	},
+}
+
+func setDefaults() {
+	// Global defaults
+	for _, setting := range globalDefaults {
+		viper.SetDefault(setting.key, setting.value)
+	}
+
+	// Upload defaults
+	for _, setting := range uploadDefaults {
+		viper.SetDefault("upload."+setting.key, setting.value)
+	}
+
+	// History defaults
+	for _, setting := range historyDefaults {
+		viper.SetDefault("history."+setting.key, setting.value)
+	}
+
+	// Provider defaults
+	providers := make([]ProviderConfig, 0, len(defaultProviders))
+	for _, provider := range defaultProviders {
+		providers = append(providers, provider.config)
+	}
+	viper.SetDefault("providers", providers)
 }
 
 // GetEnabledProviders returns a list of enabled provider configurations
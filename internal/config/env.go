@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// providerEnablePrefix prefixes the environment variables that toggle providers
+const providerEnablePrefix = "WOOF_ENABLE_"
+
+// providerSecretPrefix prefixes the environment variables holding provider credentials
+const providerSecretPrefix = "WOOF_"
+
+// providerSecretSettings are the credential settings read from the environment;
+// each maps to WOOF_<PROVIDER>_<SETTING>, e.g. gofile's token to WOOF_GOFILE_TOKEN
+var providerSecretSettings = []string{
+	"api_key",
+	"bearer_token",
+	"username",
+	"password",
+	"token",
+	"account_id",
+	"userhash",
+	"signing_key",
+}
+
+// ProviderEnableEnvVar returns the environment variable that toggles a provider,
+// e.g. WOOF_ENABLE_GOFILE. Characters other than letters and digits become '_'.
+func ProviderEnableEnvVar(name string) string {
+	return providerEnablePrefix + envName(name)
+}
+
+// ProviderSecretEnvVar returns the environment variable holding a provider
+// credential, e.g. WOOF_0X0_API_KEY for the 0x0 provider's api_key
+func ProviderSecretEnvVar(provider, setting string) string {
+	return providerSecretPrefix + envName(provider) + "_" + envName(setting)
+}
+
+// envName uppercases name and replaces characters other than letters and digits with '_'
+func envName(name string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	return strings.ToUpper(normalized)
+}
+
+// applyProviderEnvOverrides sets Enabled for every configured provider whose
+// WOOF_ENABLE_<NAME> variable is set, overriding the configuration file
+func applyProviderEnvOverrides(config *Config) error {
+	for i := range config.Providers {
+		key := ProviderEnableEnvVar(config.Providers[i].Name)
+		value, ok := os.LookupEnv(key)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: expected true or false", value, key)
+		}
+		config.Providers[i].Enabled = enabled
+	}
+	return nil
+}
+
+// applyProviderSecretEnv copies every non-empty WOOF_<PROVIDER>_<SETTING>
+// credential into the provider's settings, taking precedence over the file.
+// A <setting>_file entry for the same setting is dropped so the two do not
+// conflict. Settings maps are copied, never modified in place.
+func applyProviderSecretEnv(config *Config) {
+	for i := range config.Providers {
+		provider := &config.Providers[i]
+		var settings map[string]interface{}
+		for _, setting := range providerSecretSettings {
+			value := os.Getenv(ProviderSecretEnvVar(provider.Name, setting))
+			if value == "" {
+				continue
+			}
+			if settings == nil {
+				settings = make(map[string]interface{}, len(provider.Settings)+1)
+				for key, existing := range provider.Settings {
+					settings[key] = existing
+				}
+			}
+			settings[setting] = value
+			delete(settings, setting+settingFileSuffix)
+		}
+		if settings != nil {
+			provider.Settings = settings
+		}
+	}
+}
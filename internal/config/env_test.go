@@ -0,0 +1,127 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func envTestConfig() *Config {
+	return &Config{
+		Providers: []ProviderConfig{
+			{Name: "buzzheavier", Enabled: true},
+			{Name: "gofile", Enabled: false},
+		},
+	}
+}
+
+func enabledNames(cfg *Config) string {
+	var names []string
+	for _, provider := range cfg.GetEnabledProviders() {
+		names = append(names, provider.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestApplyProviderEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{name: "no overrides", expected: "buzzheavier"},
+		{name: "enable", env: map[string]string{"WOOF_ENABLE_GOFILE": "true"}, expected: "buzzheavier,gofile"},
+		{name: "disable", env: map[string]string{"WOOF_ENABLE_BUZZHEAVIER": "false"}, expected: ""},
+		{name: "swap", env: map[string]string{"WOOF_ENABLE_BUZZHEAVIER": "0", "WOOF_ENABLE_GOFILE": "1"}, expected: "gofile"},
+		{name: "empty value ignored", env: map[string]string{"WOOF_ENABLE_GOFILE": ""}, expected: "buzzheavier"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg := envTestConfig()
+			if err := applyProviderEnvOverrides(cfg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := enabledNames(cfg); got != tt.expected {
+				t.Errorf("expected enabled providers %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyProviderEnvOverrides_InvalidValue(t *testing.T) {
+	t.Setenv("WOOF_ENABLE_GOFILE", "maybe")
+
+	err := applyProviderEnvOverrides(envTestConfig())
+	if err == nil || !strings.Contains(err.Error(), "WOOF_ENABLE_GOFILE") {
+		t.Errorf("expected error naming the variable, got %v", err)
+	}
+}
+
+func TestProviderEnableEnvVar(t *testing.T) {
+	if got := ProviderEnableEnvVar("my-host.v2"); got != "WOOF_ENABLE_MY_HOST_V2" {
+		t.Errorf("unexpected variable name %q", got)
+	}
+}
+
+func TestApplyProviderSecretEnv(t *testing.T) {
+	t.Setenv("WOOF_GOFILE_TOKEN", "env-token")
+	t.Setenv("WOOF_WEBDAV_PASSWORD", "env-password")
+	t.Setenv("WOOF_WEBDAV_USERNAME", "")
+
+	cfg := &Config{Providers: []ProviderConfig{
+		{Name: "gofile"},
+		{Name: "webdav", Settings: map[string]interface{}{
+			"username":      "alice",
+			"password_file": "/run/secrets/webdav",
+		}},
+		{Name: "catbox", Settings: map[string]interface{}{"userhash": "file-hash"}},
+	}}
+	fileSettings := cfg.Providers[1].Settings
+	applyProviderSecretEnv(cfg)
+
+	if got := cfg.Providers[0].Settings["token"]; got != "env-token" {
+		t.Errorf("expected the gofile token from the environment, got %v", got)
+	}
+	webdav := cfg.Providers[1].Settings
+	if webdav["password"] != "env-password" || webdav["username"] != "alice" {
+		t.Errorf("expected the environment password next to the file username, got %v", webdav)
+	}
+	if _, ok := webdav["password_file"]; ok {
+		t.Error("expected password_file to give way to the environment variable")
+	}
+	if _, ok := fileSettings["password"]; ok {
+		t.Error("expected the original settings map to be left alone")
+	}
+	if got := cfg.Providers[2].Settings["userhash"]; got != "file-hash" {
+		t.Errorf("expected the file value without an environment variable, got %v", got)
+	}
+}
+
+func TestLoadConfig_SecretEnvOverridesFile(t *testing.T) {
+	t.Setenv("WOOF_GOFILE_TOKEN", "env-token")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, provider := range cfg.Providers {
+		if provider.Name == "gofile" && provider.Settings["token"] != "env-token" {
+			t.Errorf("expected the token from the environment, got %v", provider.Settings["token"])
+		}
+	}
+	for _, provider := range defaultProviders {
+		if _, ok := provider.config.Settings["token"]; ok {
+			t.Error("expected the built-in defaults to be left alone")
+		}
+	}
+}
+
+func TestProviderSecretEnvVar(t *testing.T) {
+	if got := ProviderSecretEnvVar("0x0", "api_key"); got != "WOOF_0X0_API_KEY" {
+		t.Errorf("unexpected variable name %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// configHeader opens every generated config file
+const configHeader = `# woof configuration, generated by "woof config init"
+# Use it with: woof --config <this file> upload ...
+#
+# Every provider is disabled; set enabled: true on the ones uploads without
+# --providers should use, or toggle them with WOOF_ENABLE_<NAME>=true.
+# Credentials can be kept out of this file with <setting>_file settings,
+# e.g. password_file pointing at a mounted secret.
+
+`
+
+// webdavExample ends every generated config file; WebDAV has no defaults to fill in
+const webdavExample = `  # WebDAV needs a server of your own:
+  # - name: "webdav"
+  #   enabled: false
+  #   settings:
+  #     base_url: "https://dav.example.com/remote.php/dav/files/me"
+  #     username: "me"
+  #     password_file: "/run/secrets/webdav_password"
+  #     remote_dir: "uploads/woof"
+  #     create_dirs: true
+`
+
+// WriteDefaultConfig writes a commented YAML config holding the built-in
+// defaults, with every provider disabled
+func WriteDefaultConfig(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprint(out, configHeader)
+	writeSettings(out, "", globalDefaults)
+	fmt.Fprint(out, "\n# Upload settings\nupload:\n")
+	writeSettings(out, "  ", uploadDefaults)
+	fmt.Fprint(out, "\n# Upload history\nhistory:\n")
+	writeSettings(out, "  ", historyDefaults)
+
+	fmt.Fprint(out, "\n# File hosting providers\nproviders:\n")
+	for _, provider := range defaultProviders {
+		if provider.note != "" {
+			fmt.Fprintf(out, "  # %s\n", provider.note)
+		}
+		fmt.Fprintf(out, "  - name: %s\n    enabled: false\n    settings:\n", yamlValue(provider.config.Name))
+
+		keys := make([]string, 0, len(provider.config.Settings))
+		for key := range provider.config.Settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(out, "      %s: %s\n", key, yamlValue(provider.config.Settings[key]))
+		}
+		fmt.Fprintln(out)
+	}
+	fmt.Fprint(out, webdavExample)
+
+	return out.Flush()
+}
+
+// writeSettings writes one "key: value" line per setting with its note as a comment
+func writeSettings(out io.Writer, indent string, settings []defaultSetting) {
+	for _, setting := range settings {
+		line := fmt.Sprintf("%s%s: %s", indent, setting.key, yamlValue(setting.value))
+		if setting.note != "" {
+			line += "  # " + setting.note
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+// yamlValue renders a default as a YAML scalar; strings are always quoted so
+// values such as "0x0" stay strings
+func yamlValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
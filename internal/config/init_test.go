@@ -0,0 +1,57 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestWriteDefaultConfig_RoundTrips(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteDefaultConfig(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("generated config is not valid YAML: %v\n%s", err, buf.String())
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatalf("failed to unmarshal generated config: %v", err)
+	}
+
+	if cfg.Concurrency != 5 || cfg.Output != "text" {
+		t.Errorf("unexpected global settings: concurrency %d, output %q", cfg.Concurrency, cfg.Output)
+	}
+	if cfg.Upload.RetryAttempts != 3 || cfg.Upload.RetryDelay != 2*time.Second || cfg.Upload.ChunkSize != 1024*1024 {
+		t.Errorf("unexpected upload settings: %+v", cfg.Upload)
+	}
+	if cfg.Upload.BackoffFactor != 0 || cfg.Upload.Timeout != 30*time.Minute {
+		t.Errorf("unexpected upload settings: %+v", cfg.Upload)
+	}
+
+	if cfg.History.Enabled || cfg.History.File != "" {
+		t.Errorf("unexpected history settings: %+v", cfg.History)
+	}
+
+	if len(cfg.Providers) != len(defaultProviders) {
+		t.Fatalf("expected %d providers, got %d", len(defaultProviders), len(cfg.Providers))
+	}
+	for i, provider := range cfg.Providers {
+		want := defaultProviders[i].config
+		if provider.Name != want.Name {
+			t.Errorf("provider %d: expected %s, got %s", i, want.Name, provider.Name)
+		}
+		if provider.Enabled {
+			t.Errorf("expected %s to be disabled", provider.Name)
+		}
+		if !reflect.DeepEqual(provider.Settings, want.Settings) {
+			t.Errorf("%s settings: expected %v, got %v", provider.Name, want.Settings, provider.Settings)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// settingFileSuffix marks a provider setting whose value is read from a file
+const settingFileSuffix = "_file"
+
+// ResolveSettingFiles returns a copy of provider settings in which every
+// "<name>_file" entry is replaced by a "<name>" entry holding the trimmed
+// contents of that file, e.g. api_key_file: /run/secrets/key sets api_key.
+// This lets secrets mounted as files (Docker, Kubernetes) stay out of the
+// configuration. Setting both forms of the same key is an error.
+func ResolveSettingFiles(settings map[string]interface{}) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		resolved[key] = value
+	}
+
+	for key, value := range settings {
+		if !strings.HasSuffix(key, settingFileSuffix) || len(key) == len(settingFileSuffix) {
+			continue
+		}
+		path, ok := value.(string)
+		if !ok || path == "" {
+			continue
+		}
+
+		target := strings.TrimSuffix(key, settingFileSuffix)
+		if existing, ok := settings[target]; ok && existing != "" {
+			return nil, fmt.Errorf("settings %q and %q are both set, use only one", target, key)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s for setting %q: %w", key, target, err)
+		}
+		resolved[target] = strings.TrimSpace(string(data))
+		delete(resolved, key)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSettingFiles_ReadsKeyFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte("  secret-key\n"), 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	settings := map[string]interface{}{
+		"api_key_file": path,
+		"timeout":      "5m",
+	}
+	resolved, err := ResolveSettingFiles(settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resolved["api_key"] != "secret-key" {
+		t.Errorf("expected trimmed key from file, got %q", resolved["api_key"])
+	}
+	if _, ok := resolved["api_key_file"]; ok {
+		t.Error("expected the _file entry to be replaced")
+	}
+	if resolved["timeout"] != "5m" {
+		t.Errorf("expected other settings to be kept, got %v", resolved["timeout"])
+	}
+	if _, ok := settings["api_key"]; ok {
+		t.Error("expected the original settings to be left untouched")
+	}
+}
+
+func TestResolveSettingFiles_UnreadableFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	_, err := ResolveSettingFiles(map[string]interface{}{"userhash_file": missing})
+	if err == nil {
+		t.Fatal("expected an error for an unreadable file")
+	}
+	if !strings.Contains(err.Error(), "userhash_file") || !strings.Contains(err.Error(), missing) {
+		t.Errorf("expected the setting and path in the error, got %q", err.Error())
+	}
+}
+
+func TestResolveSettingFiles_BothSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(path, []byte("from-file"), 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	_, err := ResolveSettingFiles(map[string]interface{}{
+		"api_key":      "inline",
+		"api_key_file": path,
+	})
+	if err == nil {
+		t.Fatal("expected an error when both forms are set")
+	}
+}
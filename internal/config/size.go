@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// byteUnits maps size suffixes to their multiplier; decimal and binary units are both accepted
+var byteUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KIB", 1 << 10},
+	{"MIB", 1 << 20},
+	{"GIB", 1 << 30},
+	{"TIB", 1 << 40},
+	{"KB", 1000},
+	{"MB", 1000 * 1000},
+	{"GB", 1000 * 1000 * 1000},
+	{"TB", 1000 * 1000 * 1000 * 1000},
+	{"K", 1000},
+	{"M", 1000 * 1000},
+	{"G", 1000 * 1000 * 1000},
+	{"T", 1000 * 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// ParseByteSize parses a human readable size such as "500MB", "1.5GiB" or "1024"
+func ParseByteSize(s string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multiplier := int64(1)
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			multiplier = unit.multiplier
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(number * float64(multiplier)), nil
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// knownProviders maps every provider name the factory accepts to the settings
+// it cannot be created without
+var knownProviders = map[string][]string{
+	"buzzheavier": nil,
+	"gofile":      nil,
+	"0x0":         nil,
+	"null0x0":     nil,
+	"catbox":      nil,
+	"fileio":      nil,
+	"file.io":     nil,
+	"uguu":        nil,
+	"litterbox":   nil,
+	"tmpfiles":    nil,
+	"webdav":      {"base_url"},
+}
+
+// durationSettings are provider settings parsed with time.ParseDuration
+var durationSettings = []string{"timeout", "connect_timeout"}
+
+// ValidationError is one problem found in the configuration
+type ValidationError struct {
+	Field   string // Where the problem is, e.g. providers[2].settings.timeout
+	Line    int    // Line in the config file, 0 when unknown
+	Message string
+
+	path []interface{} // Field as map keys and sequence indexes, used to find Line
+}
+
+func (e ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", e.Line, e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors holds every problem Validate found
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks every provider entry: the name is known, required settings
+// are present, durations parse, priority is a whole number and max_file_size
+// is not negative. All problems are returned together as ValidationErrors, or
+// nil when there are none.
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+	add := func(message string, path ...interface{}) {
+		errs = append(errs, ValidationError{Field: fieldName(path), Message: message, path: path})
+	}
+
+	for i, provider := range c.Providers {
+		required, known := knownProviders[strings.ToLower(provider.Name)]
+		if provider.Name == "" {
+			add("name is required", "providers", i)
+			continue
+		}
+		if !known {
+			add(fmt.Sprintf("unknown provider %q", provider.Name), "providers", i, "name")
+			continue
+		}
+
+		for _, key := range required {
+			value, ok := provider.Settings[key]
+			if !ok || value == "" || value == nil {
+				add(fmt.Sprintf("%s requires setting %q", provider.Name, key), "providers", i, "settings")
+			}
+		}
+		for _, key := range durationSettings {
+			value, ok := provider.Settings[key]
+			if !ok {
+				continue
+			}
+			if _, err := time.ParseDuration(fmt.Sprint(value)); err != nil {
+				add(fmt.Sprintf("invalid duration %q", fmt.Sprint(value)), "providers", i, "settings", key)
+			}
+		}
+		if value, ok := provider.Settings["priority"]; ok {
+			if _, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(value))); err != nil {
+				add(fmt.Sprintf("invalid priority %v: must be a whole number", value), "providers", i, "settings", "priority")
+			}
+		}
+		if value, ok := provider.Settings["max_file_size"]; ok {
+			if message := checkMaxFileSize(value); message != "" {
+				add(message, "providers", i, "settings", "max_file_size")
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// checkMaxFileSize describes what is wrong with a max_file_size value, or returns "" if nothing is
+func checkMaxFileSize(value interface{}) string {
+	var size int64
+	switch v := value.(type) {
+	case int:
+		size = int64(v)
+	case int64:
+		size = v
+	case float64:
+		size = int64(v)
+	case string:
+		parsed, err := ParseByteSize(v)
+		if err != nil {
+			return fmt.Sprintf("invalid size %q", v)
+		}
+		size = parsed
+	default:
+		return fmt.Sprintf("invalid size %v", value)
+	}
+	if size < 0 {
+		return fmt.Sprintf("must not be negative, got %d", size)
+	}
+	return ""
+}
+
+// fieldName renders a path such as providers[2].settings.timeout
+func fieldName(path []interface{}) string {
+	var b strings.Builder
+	for _, part := range path {
+		switch p := part.(type) {
+		case int:
+			fmt.Fprintf(&b, "[%d]", p)
+		default:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			fmt.Fprint(&b, p)
+		}
+	}
+	return b.String()
+}
+
+// AnnotateLines sets Line on every error whose field can be found in source,
+// the YAML the configuration was read from. Errors are left as they are when
+// source does not parse.
+func (e ValidationErrors) AnnotateLines(source []byte) {
+	var root yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(source)).Decode(&root); err != nil || len(root.Content) == 0 {
+		return
+	}
+	for i := range e {
+		e[i].Line = lineOf(root.Content[0], e[i].path)
+	}
+}
+
+// lineOf follows path from node and returns the line of the deepest key or
+// sequence item it reaches
+func lineOf(node *yaml.Node, path []interface{}) int {
+	line := 0
+	for _, part := range path {
+		var next *yaml.Node
+		switch p := part.(type) {
+		case int:
+			if node.Kind == yaml.SequenceNode && p < len(node.Content) {
+				next = node.Content[p]
+				line = next.Line
+			}
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if strings.EqualFold(node.Content[i].Value, p) {
+						next = node.Content[i+1]
+						line = node.Content[i].Line
+						break
+					}
+				}
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
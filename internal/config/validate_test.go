@@ -0,0 +1,92 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ProviderConfig
+		field  string
+	}{
+		{
+			name:   "unknown provider",
+			config: ProviderConfig{Name: "dropbox"},
+			field:  "providers[0].name",
+		},
+		{
+			name:   "unparseable timeout",
+			config: ProviderConfig{Name: "gofile", Settings: map[string]interface{}{"timeout": "ten minutes"}},
+			field:  "providers[0].settings.timeout",
+		},
+		{
+			name:   "missing required setting",
+			config: ProviderConfig{Name: "webdav", Settings: map[string]interface{}{}},
+			field:  "providers[0].settings",
+		},
+		{
+			name:   "fractional priority",
+			config: ProviderConfig{Name: "uguu", Settings: map[string]interface{}{"priority": 1.5}},
+			field:  "providers[0].settings.priority",
+		},
+		{
+			name:   "negative max_file_size",
+			config: ProviderConfig{Name: "catbox", Settings: map[string]interface{}{"max_file_size": -1}},
+			field:  "providers[0].settings.max_file_size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Providers: []ProviderConfig{tt.config}}
+			var problems ValidationErrors
+			if !errors.As(cfg.Validate(), &problems) {
+				t.Fatal("expected validation errors")
+			}
+			if len(problems) != 1 || problems[0].Field != tt.field {
+				t.Errorf("expected one problem at %s, got %v", tt.field, problems)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := &Config{Providers: []ProviderConfig{
+		{Name: "gofile", Settings: map[string]interface{}{"timeout": "10m"}},
+		{Name: "dropbox"},
+		{Name: "uguu", Settings: map[string]interface{}{"timeout": "soon"}},
+	}}
+
+	var problems ValidationErrors
+	if !errors.As(cfg.Validate(), &problems) {
+		t.Fatal("expected validation errors")
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %v", problems)
+	}
+
+	problems.AnnotateLines([]byte(`providers:
+  - name: gofile
+    settings:
+      timeout: 10m
+  - name: dropbox
+  - name: uguu
+    settings:
+      timeout: soon
+`))
+	if problems[0].Line != 5 || problems[1].Line != 8 {
+		t.Errorf("expected lines 5 and 8, got %d and %d", problems[0].Line, problems[1].Line)
+	}
+}
+
+func TestValidate_DefaultsAreValid(t *testing.T) {
+	cfg := &Config{}
+	for _, provider := range defaultProviders {
+		cfg.Providers = append(cfg.Providers, provider.config)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected the default providers to be valid, got %v", err)
+	}
+}
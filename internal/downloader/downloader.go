@@ -0,0 +1,104 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout bounds a whole download, including link resolution
+const DefaultTimeout = 10 * time.Minute
+
+// Target is a direct download location and any headers it requires
+type Target struct {
+	URL    string
+	Header http.Header
+}
+
+// Resolver turns a provider viewer page into a direct download link
+type Resolver interface {
+	// Match reports whether the resolver handles the URL
+	Match(u *url.URL) bool
+	// Resolve returns the direct download target for the URL
+	Resolve(ctx context.Context, client *http.Client, u *url.URL) (*Target, error)
+}
+
+// Download is an open download stream
+type Download struct {
+	Body          io.ReadCloser
+	URL           string // Direct URL the content is served from
+	ContentType   string
+	ContentLength int64 // -1 when unknown
+}
+
+// Downloader fetches uploaded files, resolving viewer pages to direct links
+type Downloader struct {
+	Client    *http.Client
+	Resolvers []Resolver
+}
+
+// New creates a Downloader with the built-in resolvers
+func New() *Downloader {
+	return &Downloader{
+		Client:    &http.Client{Timeout: DefaultTimeout},
+		Resolvers: []Resolver{NewGoFileResolver()},
+	}
+}
+
+// Resolve returns the direct download target for rawURL. URLs no resolver
+// matches are assumed to be direct links already.
+func (d *Downloader) Resolve(ctx context.Context, rawURL string) (*Target, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: expected an absolute http(s) URL", rawURL)
+	}
+
+	for _, resolver := range d.Resolvers {
+		if resolver.Match(u) {
+			target, err := resolver.Resolve(ctx, d.Client, u)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve direct link for %s: %w", rawURL, err)
+			}
+			return target, nil
+		}
+	}
+
+	return &Target{URL: rawURL}, nil
+}
+
+// Open resolves rawURL and starts downloading it. The caller must close Body.
+func (d *Downloader) Open(ctx context.Context, rawURL string) (*Download, error) {
+	target, err := d.Resolve(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range target.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	return &Download{
+		Body:          resp.Body,
+		URL:           target.URL,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}, nil
+}
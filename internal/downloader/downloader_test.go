@@ -0,0 +1,106 @@
+package downloader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestOpen_DirectLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "hello from woof\n")
+	}))
+	defer server.Close()
+
+	download, err := New().Open(context.Background(), server.URL+"/notes.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer download.Body.Close()
+
+	body, _ := io.ReadAll(download.Body)
+	if string(body) != "hello from woof\n" {
+		t.Errorf("unexpected body %q", body)
+	}
+	if download.ContentType != "text/plain" {
+		t.Errorf("unexpected content type %q", download.ContentType)
+	}
+}
+
+func TestOpen_StatusError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := New().Open(context.Background(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+}
+
+func TestGoFileResolver_ResolvesViewerPage(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/accounts":
+			io.WriteString(w, `{"status":"ok","data":{"token":"guest-token"}}`)
+		case r.URL.Path == "/contents/abc123":
+			if r.Header.Get("Authorization") != "Bearer guest-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				io.WriteString(w, `{"status":"error-notPremium"}`)
+				return
+			}
+			io.WriteString(w, `{"status":"ok","data":{"type":"folder","children":{"f1":{"type":"file","name":"a.txt","link":"`+server.URL+`/download/a.txt"}}}}`)
+		case r.URL.Path == "/download/a.txt":
+			if cookie, err := r.Cookie("accountToken"); err != nil || cookie.Value != "guest-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			io.WriteString(w, "resolved content")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	resolver := NewGoFileResolver()
+	resolver.APIBase = server.URL
+	resolver.Hosts = append(resolver.Hosts, serverURL.Hostname())
+
+	d := New()
+	d.Resolvers = []Resolver{resolver}
+
+	download, err := d.Open(context.Background(), server.URL+"/d/abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer download.Body.Close()
+
+	body, _ := io.ReadAll(download.Body)
+	if string(body) != "resolved content" {
+		t.Errorf("unexpected body %q", body)
+	}
+	if download.URL != server.URL+"/download/a.txt" {
+		t.Errorf("expected the direct link, got %q", download.URL)
+	}
+}
+
+func TestGoFileResolver_Match(t *testing.T) {
+	resolver := NewGoFileResolver()
+	for rawURL, expected := range map[string]bool{
+		"https://gofile.io/d/abc123":          true,
+		"https://www.gofile.io/d/abc123":      true,
+		"https://gofile.io/uploadFile":        false,
+		"https://store1.gofile.io/download/x": false,
+		"https://example.com/d/abc123":        false,
+	} {
+		u, _ := url.Parse(rawURL)
+		if got := resolver.Match(u); got != expected {
+			t.Errorf("Match(%s) = %v, expected %v", rawURL, got, expected)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package downloader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GoFileResolver resolves gofile.io/d/<id> viewer pages through the GoFile API.
+// Without a token a guest account is created for the lookup.
+type GoFileResolver struct {
+	APIBase string
+	Token   string
+	// Hosts lists the viewer hosts handled by the resolver
+	Hosts []string
+}
+
+// NewGoFileResolver creates a resolver for the public GoFile API
+func NewGoFileResolver() *GoFileResolver {
+	return &GoFileResolver{
+		APIBase: "https://api.gofile.io",
+		Hosts:   []string{"gofile.io", "www.gofile.io"},
+	}
+}
+
+// goFileAPIResponse is the envelope shared by GoFile API responses
+type goFileAPIResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type goFileContent struct {
+	Type     string                   `json:"type"`
+	Name     string                   `json:"name"`
+	Link     string                   `json:"link"`
+	Children map[string]goFileContent `json:"children"`
+}
+
+// Match reports whether u is a GoFile viewer page
+func (r *GoFileResolver) Match(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, h := range r.Hosts {
+		if host == h {
+			return strings.HasPrefix(u.Path, "/d/")
+		}
+	}
+	return false
+}
+
+// Resolve returns the direct link of the single file behind the viewer page
+func (r *GoFileResolver) Resolve(ctx context.Context, client *http.Client, u *url.URL) (*Target, error) {
+	id := strings.Trim(strings.TrimPrefix(u.Path, "/d/"), "/")
+	if id == "" {
+		return nil, fmt.Errorf("missing content ID in %s", u)
+	}
+
+	token := r.Token
+	if token == "" {
+		var err error
+		if token, err = r.createGuestToken(ctx, client); err != nil {
+			return nil, err
+		}
+	}
+
+	var content goFileContent
+	if err := r.call(ctx, client, http.MethodGet, "/contents/"+url.PathEscape(id), token, &content); err != nil {
+		return nil, err
+	}
+
+	link := content.Link
+	if content.Type != "file" {
+		var files []goFileContent
+		for _, child := range content.Children {
+			if child.Type == "file" {
+				files = append(files, child)
+			}
+		}
+		if len(files) != 1 {
+			return nil, fmt.Errorf("folder %s contains %d files, expected exactly one", id, len(files))
+		}
+		link = files[0].Link
+	}
+	if link == "" {
+		return nil, fmt.Errorf("no download link for %s", id)
+	}
+
+	// Direct links only serve requests carrying the account token
+	header := http.Header{}
+	header.Set("Cookie", "accountToken="+token)
+	return &Target{URL: link, Header: header}, nil
+}
+
+func (r *GoFileResolver) createGuestToken(ctx context.Context, client *http.Client) (string, error) {
+	var account struct {
+		Token string `json:"token"`
+	}
+	if err := r.call(ctx, client, http.MethodPost, "/accounts", "", &account); err != nil {
+		return "", fmt.Errorf("failed to create guest account: %w", err)
+	}
+	if account.Token == "" {
+		return "", fmt.Errorf("guest account response missing token")
+	}
+	return account.Token, nil
+}
+
+func (r *GoFileResolver) call(ctx context.Context, client *http.Client, method, path, token string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(r.APIBase, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var envelope goFileAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to parse GoFile API response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || envelope.Status != "ok" {
+		return fmt.Errorf("GoFile API returned status %d: %s", resp.StatusCode, envelope.Status)
+	}
+	return json.Unmarshal(envelope.Data, target)
+}
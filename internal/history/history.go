@@ -0,0 +1,153 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileName is the history file woof keeps in its directory under the home directory
+const FileName = "history.jsonl"
+
+// Record describes one successful upload
+type Record struct {
+	Time      time.Time `json:"time"`
+	FileName  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	Provider  string    `json:"provider"`
+	URL       string    `json:"url"`
+	DeleteURL string    `json:"delete_url,omitempty"`
+}
+
+// Filter selects records from the history; zero fields match everything
+type Filter struct {
+	Provider string    // Case-insensitive provider name
+	Since    time.Time // Records at or after this time
+	Until    time.Time // Records before this time
+	Limit    int       // Most recent records to return, 0 for all
+}
+
+// Match reports whether record passes the filter's provider and time bounds
+func (f Filter) Match(record Record) bool {
+	if f.Provider != "" && !strings.EqualFold(record.Provider, f.Provider) {
+		return false
+	}
+	if !f.Since.IsZero() && record.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !record.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// DefaultPath returns ~/.woof/history.jsonl
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the history file: %w", err)
+	}
+	return filepath.Join(home, ".woof", FileName), nil
+}
+
+// Store appends upload records to a JSON Lines file and reads them back.
+// It is safe for concurrent use.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a store for the history file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the history file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append adds records to the end of the history, creating the file and its
+// directory when needed. The file is private to the user since delete URLs
+// let anyone remove the upload.
+func (s *Store) Append(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var data []byte
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create the history directory: %w", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open the history file: %w", err)
+	}
+	// One write keeps the lines whole when several runs append at once
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write the history file: %w", err)
+	}
+	return file.Close()
+}
+
+// Query returns the records matching filter, newest first. A missing history
+// file holds no records, and lines that do not parse, such as one cut short
+// by a crash, are skipped.
+func (s *Store) Query(filter Filter) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the history file: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	lines := bufio.NewScanner(file)
+	lines.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lines.Scan() {
+		var record Record
+		if err := json.Unmarshal(lines.Bytes(), &record); err != nil {
+			continue
+		}
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the history file: %w", err)
+	}
+
+	// Appends are in completion order, which concurrent runs may interleave
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.After(records[j].Time)
+	})
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
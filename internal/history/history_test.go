@@ -0,0 +1,154 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func testRecords() []Record {
+	return []Record{
+		{Time: base, FileName: "a.txt", Size: 10, Provider: "Catbox", URL: "https://catbox.example/a"},
+		{Time: base.Add(time.Hour), FileName: "b.txt", Size: 20, Provider: "GoFile", URL: "https://gofile.example/b", DeleteURL: "https://gofile.example/b/delete"},
+		{Time: base.Add(2 * time.Hour), FileName: "c.txt", Size: 30, Provider: "Catbox", URL: "https://catbox.example/c"},
+	}
+}
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	return NewStore(filepath.Join(t.TempDir(), "woof", FileName))
+}
+
+func TestStore_AppendAndQuery(t *testing.T) {
+	store := newTestStore(t)
+	records := testRecords()
+	if err := store.Append(records[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Append(records[1:]...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := store.Query(Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(got))
+	}
+	// Newest first
+	for i, name := range []string{"c.txt", "b.txt", "a.txt"} {
+		if got[i].FileName != name {
+			t.Errorf("record %d: expected %s, got %s", i, name, got[i].FileName)
+		}
+	}
+	if got[1].DeleteURL != records[1].DeleteURL || got[1].Size != 20 || !got[1].Time.Equal(records[1].Time) {
+		t.Errorf("record did not round-trip: %+v", got[1])
+	}
+
+	info, err := os.Stat(store.Path())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected a private history file, got mode %v", perm)
+	}
+}
+
+func TestStore_QueryFilters(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Append(testRecords()...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{"provider ignores case", Filter{Provider: "catbox"}, []string{"c.txt", "a.txt"}},
+		{"since is inclusive", Filter{Since: base.Add(time.Hour)}, []string{"c.txt", "b.txt"}},
+		{"until is exclusive", Filter{Until: base.Add(time.Hour)}, []string{"a.txt"}},
+		{"limit keeps the newest", Filter{Limit: 2}, []string{"c.txt", "b.txt"}},
+		{"combined", Filter{Provider: "Catbox", Since: base.Add(time.Minute), Limit: 5}, []string{"c.txt"}},
+		{"no match", Filter{Provider: "Uguu"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Query(tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %d records", tt.expected, len(got))
+			}
+			for i, name := range tt.expected {
+				if got[i].FileName != name {
+					t.Errorf("record %d: expected %s, got %s", i, name, got[i].FileName)
+				}
+			}
+		})
+	}
+}
+
+func TestStore_QueryMissingFile(t *testing.T) {
+	got, err := newTestStore(t).Query(Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no records, got %v", got)
+	}
+}
+
+func TestStore_QuerySkipsBrokenLines(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Append(testRecords()[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file, err := os.OpenFile(store.Path(), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file.WriteString("{\"time\":\"2024-05-01T1\n")
+	file.Close()
+	if err := store.Append(testRecords()[1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := store.Query(Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected the 2 whole records, got %d", len(got))
+	}
+}
+
+func TestStore_ConcurrentAppends(t *testing.T) {
+	store := newTestStore(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			record := Record{Time: base.Add(time.Duration(i) * time.Second), FileName: "f.txt", Provider: "Catbox", URL: "https://catbox.example/f"}
+			if err := store.Append(record); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := store.Query(Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 20 {
+		t.Errorf("expected 20 records, got %d", len(got))
+	}
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultMaxLogSize is the size a log file may reach before it is rotated
+const DefaultMaxLogSize = 10 * 1024 * 1024
+
+// fileOutput receives a JSON copy of every log entry when set, see SetFileOutput
+var fileOutput io.Writer
+
+// SetFileOutput copies every entry the logger emits to w as JSON, whatever
+// format the main output uses. It applies to the current logger and to every
+// later Init; nil stops the copying.
+func SetFileOutput(w io.Writer) {
+	fileOutput = w
+	if defaultLogger != nil {
+		defaultLogger.ReplaceHooks(make(logrus.LevelHooks))
+		addFileHook(defaultLogger.Logger)
+	}
+}
+
+// addFileHook attaches the file output to logger, if one is set
+func addFileHook(logger *logrus.Logger) {
+	if fileOutput == nil {
+		return
+	}
+	logger.AddHook(&fileHook{
+		output: fileOutput,
+		formatter: &logrus.JSONFormatter{
+			TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
+		},
+	})
+}
+
+// fileHook writes each entry to output with its own formatter
+type fileHook struct {
+	output    io.Writer
+	formatter logrus.Formatter
+}
+
+func (h *fileHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *fileHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.output.Write(line)
+	return err
+}
+
+// RotatingFile is an append-only log file that is renamed to <path>.1, replacing
+// any previous one, when a write would take it past maxSize
+type RotatingFile struct {
+	path    string
+	maxSize int64
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens path for appending, creating it if needed. A maxSize
+// of 0 or less uses DefaultMaxLogSize.
+func OpenRotatingFile(path string, maxSize int64) (*RotatingFile, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxLogSize
+	}
+	r := &RotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first if p would not fit. A single write larger
+// than maxSize still goes to a fresh file whole.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// open opens the log file and records its current size
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// rotate moves the full file aside and starts a new one
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return r.open()
+}
@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetFileOutput_WritesJSONAlongsideOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "woof.log")
+	file, err := OpenRotatingFile(path, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	var buf bytes.Buffer
+	SetFileOutput(file)
+	Init(true, &buf)
+	defer Init(false, nil)
+	defer SetFileOutput(nil)
+
+	ProviderConfig("WebDAV", map[string]interface{}{
+		"username": "alice",
+		"password": "hunter2",
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(data), &entry); err != nil {
+		t.Fatalf("expected one JSON entry in the log file, got %q: %v", data, err)
+	}
+	if entry["msg"] != "Provider configuration" || entry["provider"] != "WebDAV" {
+		t.Errorf("unexpected log file entry: %v", entry)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("credentials leaked into the log file: %s", data)
+	}
+	if !strings.Contains(buf.String(), "Provider configuration") {
+		t.Errorf("expected the entry on the main output too, got %q", buf.String())
+	}
+}
+
+func TestRotatingFile_RotatesAtThreshold(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "woof.log")
+	file, err := OpenRotatingFile(path, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	current, _ := os.ReadFile(path)
+	rotated, _ := os.ReadFile(path + ".1")
+	if string(current) != "third\n" {
+		t.Errorf("expected the current file to hold the last line, got %q", current)
+	}
+	if string(rotated) != "second\n" {
+		t.Errorf("expected the previous file to be kept as .1, got %q", rotated)
+	}
+}
+
+func TestRotatingFile_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "woof.log")
+	if err := os.WriteFile(path, []byte("12345678"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	file, err := OpenRotatingFile(path, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write([]byte("abc")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Error("expected the existing size to count towards the threshold")
+	}
+}
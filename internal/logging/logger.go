@@ -1,8 +1,10 @@
 package logging
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -26,8 +28,19 @@ const (
 	CategoryError    = "ERROR"
 )
 
-// Init initializes the logging system with verbose flag and output destination
+// Init initializes the logging system with verbose flag and output destination.
+// Verbose logs everything down to debug, otherwise only errors are logged.
 func Init(verbose bool, output io.Writer) {
+	level := logrus.ErrorLevel
+	if verbose {
+		level = logrus.DebugLevel
+	}
+	InitWithLevel(level, output)
+}
+
+// InitWithLevel initializes the logging system to log entries at level and above
+func InitWithLevel(level logrus.Level, output io.Writer) {
+	verbose := level >= logrus.DebugLevel
 	logger := logrus.New()
 
 	// Configure output
@@ -60,23 +73,37 @@ func Init(verbose bool, output io.Writer) {
 		})
 	}
 
-	// Set log level
-	if verbose {
-		logger.SetLevel(logrus.DebugLevel)
-	} else {
-		// Only show errors and above in non-verbose mode
-		logger.SetLevel(logrus.ErrorLevel)
-	}
+	logger.SetLevel(level)
 
 	// Disable caller reporting to keep output cleaner
 	logger.SetReportCaller(false)
 
+	// Copy entries to the log file, if one is set, in JSON whatever the format above
+	addFileHook(logger)
+
 	defaultLogger = &Logger{
 		Logger:  logger,
 		verbose: verbose,
 	}
 }
 
+// logLevels are the names ParseLevel accepts
+var logLevels = map[string]logrus.Level{
+	"error": logrus.ErrorLevel,
+	"warn":  logrus.WarnLevel,
+	"info":  logrus.InfoLevel,
+	"debug": logrus.DebugLevel,
+}
+
+// ParseLevel parses a log level name: error, warn, info or debug
+func ParseLevel(name string) (logrus.Level, error) {
+	level, ok := logLevels[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q (use error, warn, info or debug)", name)
+	}
+	return level, nil
+}
+
 // isTTY checks if the output is a terminal
 func isTTY(output io.Writer) bool {
 	// Simple check - could be enhanced with more sophisticated detection
@@ -109,10 +136,10 @@ func HTTPRequest(method, url string, headers map[string]string) {
 	}
 	fields := logrus.Fields{
 		"method": method,
-		"url":    url,
+		"url":    RedactURLs(url),
 	}
 	if headers != nil && len(headers) > 0 {
-		fields["headers"] = headers
+		fields["headers"] = RedactHeaders(headers)
 	}
 	defaultLogger.logWithCategory(logrus.DebugLevel, CategoryNetwork, "HTTP request", fields)
 }
@@ -126,7 +153,8 @@ func HTTPResponse(statusCode int, body string, duration time.Duration) {
 		"duration_ms": duration.Milliseconds(),
 	}
 	if body != "" {
-		// Limit body length for readability
+		// Limit body length for readability, after redacting so a cut-off URL is still masked
+		body = RedactURLs(body)
 		if len(body) > 200 {
 			body = body[:200] + "..."
 		}
@@ -141,10 +169,37 @@ func ProviderConfig(providerName string, config map[string]interface{}) {
 	}
 	defaultLogger.logWithCategory(logrus.DebugLevel, CategoryProvider, "Provider configuration", logrus.Fields{
 		"provider": providerName,
-		"config":   config,
+		"config":   RedactSettings(config),
 	})
 }
 
+// Redacted replaces credential values in logged settings
+const Redacted = "[REDACTED]"
+
+// sensitiveSettings are provider settings whose values never reach the logs
+var sensitiveSettings = map[string]bool{
+	"api_key":      true,
+	"bearer_token": true,
+	"password":     true,
+	"token":        true,
+	"signing_key":  true,
+	"userhash":     true,
+	"account_id":   true,
+}
+
+// RedactSettings returns a copy of settings with every non-empty credential
+// replaced by Redacted; empty values are kept so the logs still show them unset
+func RedactSettings(settings map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		if sensitiveSettings[key] && value != nil && value != "" {
+			value = Redacted
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
 // File Operations Logging Functions
 func FileScan(paths []string) {
 	if !IsVerbose() {
@@ -190,6 +245,9 @@ func FileValidation(path string, validationType string, err error) {
 
 // Configuration Logging Functions
 func ConfigLoad(source string, values interface{}) {
+	if settings, ok := values.(map[string]interface{}); ok {
+		values = RedactSettings(settings)
+	}
 	defaultLogger.logWithCategory(logrus.DebugLevel, CategoryConfig, "Loading configuration", logrus.Fields{
 		"source":  source,
 		"values":  values,
@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedactSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"username":     "alice",
+		"password":     "secret",
+		"api_key":      "key",
+		"bearer_token": "",
+		"timeout":      "5s",
+	}
+
+	redacted := RedactSettings(settings)
+	if redacted["password"] != Redacted || redacted["api_key"] != Redacted {
+		t.Errorf("expected credentials to be redacted, got %v", redacted)
+	}
+	if redacted["username"] != "alice" || redacted["timeout"] != "5s" {
+		t.Errorf("expected other settings to be kept, got %v", redacted)
+	}
+	if redacted["bearer_token"] != "" {
+		t.Errorf("expected an unset credential to stay empty, got %v", redacted["bearer_token"])
+	}
+	if settings["password"] != "secret" {
+		t.Error("RedactSettings must not modify the settings it is given")
+	}
+}
+
+func TestProviderConfig_RedactsCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	Init(true, &buf)
+	defer Init(false, nil)
+
+	ProviderConfig("WebDAV", map[string]interface{}{
+		"username": "alice",
+		"password": "hunter2",
+		"api_key":  "sk-live-123",
+	})
+
+	logged := buf.String()
+	if strings.Contains(logged, "hunter2") || strings.Contains(logged, "sk-live-123") {
+		t.Errorf("credentials leaked into the log: %s", logged)
+	}
+	if !strings.Contains(logged, Redacted) || !strings.Contains(logged, "alice") {
+		t.Errorf("expected redacted credentials next to the other settings, got %s", logged)
+	}
+}
+
+func TestConfigLoad_RedactsCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	Init(true, &buf)
+	defer Init(false, nil)
+
+	ConfigLoad("environment", map[string]interface{}{
+		"token":   "env-token-123",
+		"timeout": "10m",
+	})
+
+	logged := buf.String()
+	if strings.Contains(logged, "env-token-123") {
+		t.Errorf("credentials leaked into the log: %s", logged)
+	}
+	if !strings.Contains(logged, Redacted) || !strings.Contains(logged, "10m") {
+		t.Errorf("expected the redacted token next to the other values, got %s", logged)
+	}
+}
+
+func TestInitWithLevel_InfoSkipsDebugLines(t *testing.T) {
+	level, err := ParseLevel("info")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	InitWithLevel(level, &buf)
+	defer Init(false, nil)
+
+	UploadStart("a.txt", 7)
+	HTTPRequest("PUT", "https://example.com/a.txt", nil)
+	UploadComplete("a.txt", "https://example.com/a.txt", time.Second)
+
+	logged := buf.String()
+	if !strings.Contains(logged, "Starting upload") || !strings.Contains(logged, "Upload completed") {
+		t.Errorf("expected upload lines at info level, got %s", logged)
+	}
+	if strings.Contains(logged, "HTTP request") {
+		t.Errorf("expected no debug lines at info level, got %s", logged)
+	}
+	if IsVerbose() {
+		t.Error("expected info level not to count as verbose")
+	}
+}
+
+func TestParseLevel_RejectsUnknownLevel(t *testing.T) {
+	if _, err := ParseLevel("trace"); err == nil {
+		t.Error("expected an error for an unsupported level")
+	}
+	if level, err := ParseLevel("WARN"); err != nil || level != logrus.WarnLevel {
+		t.Errorf("expected warn level, got %v (%v)", level, err)
+	}
+}
@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactionRules decide which parts of logged HTTP requests and responses are
+// replaced by Redacted
+type RedactionRules struct {
+	// QueryParams masks the value of every query parameter whose name
+	// contains one of these, in logged URLs and response bodies
+	QueryParams []string
+	// Headers masks request headers with one of these names; * matches any
+	// run of characters, e.g. X-*-Delete
+	Headers []string
+}
+
+// DefaultRedactionRules masks signed URL parameters and credential headers
+func DefaultRedactionRules() RedactionRules {
+	return RedactionRules{
+		QueryParams: []string{"token", "signature", "key", "password"},
+		Headers:     []string{"Authorization", "X-*-Delete", "X-API-Key", "X-Token"},
+	}
+}
+
+var (
+	redactionMu    sync.RWMutex
+	redactionRules = DefaultRedactionRules()
+)
+
+// SetRedactionRules replaces the rules used by HTTPRequest and HTTPResponse
+func SetRedactionRules(rules RedactionRules) {
+	redactionMu.Lock()
+	defer redactionMu.Unlock()
+	redactionRules = rules
+}
+
+// queryParamPattern finds name=value pairs that follow ? or & in a URL
+var queryParamPattern = regexp.MustCompile(`([?&])([^=&\s"'<>]+)=([^&\s"'<>]*)`)
+
+// RedactURLs masks the values of matching query parameters in every URL in
+// text, which may be a single URL or a response body containing some
+func RedactURLs(text string) string {
+	redactionMu.RLock()
+	params := redactionRules.QueryParams
+	redactionMu.RUnlock()
+	if len(params) == 0 || !strings.ContainsAny(text, "?&") {
+		return text
+	}
+
+	return queryParamPattern.ReplaceAllStringFunc(text, func(pair string) string {
+		match := queryParamPattern.FindStringSubmatch(pair)
+		name := strings.ToLower(match[2])
+		for _, param := range params {
+			if param != "" && strings.Contains(name, strings.ToLower(param)) {
+				return match[1] + match[2] + "=" + Redacted
+			}
+		}
+		return pair
+	})
+}
+
+// RedactHeaders returns a copy of headers with the values of matching
+// headers replaced by Redacted
+func RedactHeaders(headers map[string]string) map[string]string {
+	redactionMu.RLock()
+	patterns := redactionRules.Headers
+	redactionMu.RUnlock()
+
+	redacted := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if value != "" && headerMatches(patterns, name) {
+			value = Redacted
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// headerMatches reports whether name matches one of patterns, ignoring case
+func headerMatches(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
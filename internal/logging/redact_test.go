@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPResponse_RedactsSignedURLs(t *testing.T) {
+	var buf bytes.Buffer
+	Init(true, &buf)
+	defer Init(false, nil)
+
+	HTTPResponse(200, `{"url":"https://cdn.example.com/f/abc?X-Amz-Signature=deadbeef&expires=60&token=s3cr3t"}`, time.Second)
+
+	logged := buf.String()
+	if strings.Contains(logged, "deadbeef") || strings.Contains(logged, "s3cr3t") {
+		t.Errorf("signed URL leaked into the log: %s", logged)
+	}
+	if !strings.Contains(logged, "expires=60") {
+		t.Errorf("expected other query parameters to be kept, got %s", logged)
+	}
+}
+
+func TestHTTPRequest_RedactsURLAndHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	Init(true, &buf)
+	defer Init(false, nil)
+
+	HTTPRequest("DELETE", "https://api.example.com/files/abc?api_key=k-123", map[string]string{
+		"Authorization":  "Bearer t-456",
+		"X-Token-Delete": "d-789",
+		"Content-Type":   "application/json",
+	})
+
+	logged := buf.String()
+	for _, secret := range []string{"k-123", "t-456", "d-789"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("%s leaked into the log: %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "application/json") {
+		t.Errorf("expected other headers to be kept, got %s", logged)
+	}
+}
+
+func TestSetRedactionRules_AddsNames(t *testing.T) {
+	rules := DefaultRedactionRules()
+	rules.QueryParams = append(rules.QueryParams, "sid")
+	rules.Headers = append(rules.Headers, "X-Session")
+	SetRedactionRules(rules)
+	defer SetRedactionRules(DefaultRedactionRules())
+
+	if got := RedactURLs("https://example.com/f?sid=abc&page=2"); got != "https://example.com/f?sid="+Redacted+"&page=2" {
+		t.Errorf("expected the configured parameter to be masked, got %q", got)
+	}
+	if got := RedactHeaders(map[string]string{"x-session": "abc"}); got["x-session"] != Redacted {
+		t.Errorf("expected the configured header to be masked, got %v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package output
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// recentResultsLimit bounds how many completed uploads a snapshot keeps
+const recentResultsLimit = 5
+
+// ProviderStats summarises the successful uploads of one provider
+type ProviderStats struct {
+	Name       string
+	Files      int
+	Bytes      int64
+	Throughput float64 // bytes per second over the run's elapsed time
+}
+
+// Snapshot is a point-in-time view of an upload run
+type Snapshot struct {
+	Elapsed       time.Duration
+	Succeeded     int
+	Failed        int
+	Cancelled     int
+	Skipped       int
+	BytesUploaded int64 // Bytes of successfully completed uploads
+	BytesInFlight int64 // Bytes transferred so far by uploads still running
+	Retry         uploader.RetryStats
+	Providers     []ProviderStats         // Sorted by name
+	Recent        []uploader.UploadResult // Most recent first
+	InFlight      []uploader.ProgressInfo // Sorted by file name
+}
+
+// Completed returns the number of files that reached a final state
+func (s Snapshot) Completed() int {
+	return s.Succeeded + s.Failed + s.Cancelled + s.Skipped
+}
+
+// Aggregator collects results and progress events of a run. It implements
+// uploader.ProgressListener so it sees every progress event, and is safe for
+// concurrent use.
+type Aggregator struct {
+	mu        sync.Mutex
+	start     time.Time
+	now       func() time.Time
+	succeeded int
+	failed    int
+	cancelled int
+	skipped   int
+	bytes     int64
+	retry     uploader.RetryStats
+	providers map[string]*ProviderStats
+	recent    []uploader.UploadResult
+	inFlight  map[string]uploader.ProgressInfo
+}
+
+var _ uploader.ProgressListener = (*Aggregator)(nil)
+
+// NewAggregator creates an Aggregator whose clock starts now
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		start:     time.Now(),
+		now:       time.Now,
+		providers: make(map[string]*ProviderStats),
+		inFlight:  make(map[string]uploader.ProgressInfo),
+	}
+}
+
+// OnProgress records the latest progress of an in-flight file
+func (a *Aggregator) OnProgress(info uploader.ProgressInfo) {
+	if info.Plan != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inFlight[info.FileName] = info
+}
+
+// AddResult records a finished upload
+func (a *Aggregator) AddResult(result uploader.UploadResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.inFlight, result.FileName)
+	a.retry.Add(result)
+
+	switch {
+	case result.Cancelled:
+		a.cancelled++
+	case result.Skipped:
+		a.skipped++
+	case result.Error != nil:
+		a.failed++
+	default:
+		a.succeeded++
+		a.bytes += result.Size
+		stats, ok := a.providers[result.Provider]
+		if !ok {
+			stats = &ProviderStats{Name: result.Provider}
+			a.providers[result.Provider] = stats
+		}
+		stats.Files++
+		stats.Bytes += result.Size
+	}
+
+	a.recent = append([]uploader.UploadResult{result}, a.recent...)
+	if len(a.recent) > recentResultsLimit {
+		a.recent = a.recent[:recentResultsLimit]
+	}
+}
+
+// Snapshot returns a copy of the current state
+func (a *Aggregator) Snapshot() Snapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	elapsed := a.now().Sub(a.start)
+	snapshot := Snapshot{
+		Elapsed:       elapsed,
+		Succeeded:     a.succeeded,
+		Failed:        a.failed,
+		Cancelled:     a.cancelled,
+		Skipped:       a.skipped,
+		BytesUploaded: a.bytes,
+		Retry:         a.retry,
+		Recent:        append([]uploader.UploadResult(nil), a.recent...),
+	}
+
+	for _, stats := range a.providers {
+		entry := *stats
+		if elapsed > 0 {
+			entry.Throughput = float64(entry.Bytes) / elapsed.Seconds()
+		}
+		snapshot.Providers = append(snapshot.Providers, entry)
+	}
+	sort.Slice(snapshot.Providers, func(i, j int) bool {
+		return snapshot.Providers[i].Name < snapshot.Providers[j].Name
+	})
+
+	for _, info := range a.inFlight {
+		snapshot.BytesInFlight += info.BytesUploaded
+		snapshot.InFlight = append(snapshot.InFlight, info)
+	}
+	sort.Slice(snapshot.InFlight, func(i, j int) bool {
+		return snapshot.InFlight[i].FileName < snapshot.InFlight[j].FileName
+	})
+
+	return snapshot
+}
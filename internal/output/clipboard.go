@@ -0,0 +1,117 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// ErrNoClipboardTool is returned when none of the platform's clipboard commands is installed
+var ErrNoClipboardTool = errors.New("no clipboard tool found")
+
+// clipboardCommands lists the commands that accept clipboard contents on
+// stdin for a GOOS, in order of preference
+func clipboardCommands(goos string) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	default:
+		return [][]string{
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+			{"wl-copy"},
+		}
+	}
+}
+
+// Clipboard copies text to the system clipboard by running the first
+// available clipboard command. The fields are replaceable for tests.
+type Clipboard struct {
+	GOOS     string
+	LookPath func(file string) (string, error)
+	Run      func(name string, args []string, stdin string) error
+}
+
+// NewClipboard returns a Clipboard for the running platform
+func NewClipboard() *Clipboard {
+	return &Clipboard{
+		GOOS:     runtime.GOOS,
+		LookPath: exec.LookPath,
+		Run:      runClipboardCommand,
+	}
+}
+
+func runClipboardCommand(name string, args []string, stdin string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Copy places text on the clipboard, returning ErrNoClipboardTool when no
+// clipboard command is installed
+func (c *Clipboard) Copy(text string) error {
+	for _, command := range clipboardCommands(c.GOOS) {
+		path, err := c.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+		return c.Run(path, command[1:], text)
+	}
+	return ErrNoClipboardTool
+}
+
+// ClipboardHandler decorates a handler and copies the URLs of successful
+// uploads to the clipboard once the run summary arrives, one per line. A
+// missing clipboard tool or a failing copy only produces a warning.
+type ClipboardHandler struct {
+	Handler
+	mu        sync.Mutex
+	clipboard *Clipboard
+	warnings  io.Writer
+	urls      []string
+}
+
+// NewClipboardHandler wraps inner, writing clipboard warnings to warnings
+func NewClipboardHandler(inner Handler, clipboard *Clipboard, warnings io.Writer) *ClipboardHandler {
+	return &ClipboardHandler{Handler: inner, clipboard: clipboard, warnings: warnings}
+}
+
+// HandleResult remembers the URL of a successful upload and forwards the result
+func (c *ClipboardHandler) HandleResult(result uploader.UploadResult) error {
+	if result.Error == nil && result.URL != "" {
+		c.mu.Lock()
+		c.urls = append(c.urls, result.URL)
+		c.mu.Unlock()
+	}
+	return c.Handler.HandleResult(result)
+}
+
+// HandleSummary forwards the summary, then copies the collected URLs
+func (c *ClipboardHandler) HandleSummary(summary uploader.Summary) error {
+	if sh, ok := c.Handler.(SummaryHandler); ok {
+		if err := sh.HandleSummary(summary); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.urls) == 0 {
+		return nil
+	}
+	if err := c.clipboard.Copy(strings.Join(c.urls, "\n")); err != nil {
+		fmt.Fprintf(c.warnings, "Warning: could not copy URLs to the clipboard: %v\n", err)
+	}
+	return nil
+}
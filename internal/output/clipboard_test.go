@@ -0,0 +1,92 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// fakeClipboard returns a Clipboard for goos where only the installed commands
+// exist, recording every command it runs
+func fakeClipboard(goos string, installed ...string) (*Clipboard, *[][]string) {
+	var calls [][]string
+	return &Clipboard{
+		GOOS: goos,
+		LookPath: func(file string) (string, error) {
+			for _, name := range installed {
+				if name == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		},
+		Run: func(name string, args []string, stdin string) error {
+			calls = append(calls, append([]string{name, stdin}, args...))
+			return nil
+		},
+	}, &calls
+}
+
+func TestClipboard_PicksPlatformCommand(t *testing.T) {
+	tests := []struct {
+		goos      string
+		installed []string
+		expected  []string
+	}{
+		{goos: "darwin", installed: []string{"pbcopy"}, expected: []string{"/usr/bin/pbcopy", "url"}},
+		{goos: "windows", installed: []string{"clip"}, expected: []string{"/usr/bin/clip", "url"}},
+		{goos: "linux", installed: []string{"xclip", "xsel"}, expected: []string{"/usr/bin/xclip", "url", "-selection", "clipboard"}},
+		{goos: "linux", installed: []string{"wl-copy"}, expected: []string{"/usr/bin/wl-copy", "url"}},
+	}
+
+	for _, tt := range tests {
+		clipboard, calls := fakeClipboard(tt.goos, tt.installed...)
+		if err := clipboard.Copy("url"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.goos, err)
+		}
+		if len(*calls) != 1 || !reflect.DeepEqual((*calls)[0], tt.expected) {
+			t.Errorf("%s with %v: expected %q, got %q", tt.goos, tt.installed, tt.expected, *calls)
+		}
+	}
+}
+
+func TestClipboard_NoToolInstalled(t *testing.T) {
+	clipboard, _ := fakeClipboard("linux")
+	if err := clipboard.Copy("url"); !errors.Is(err, ErrNoClipboardTool) {
+		t.Errorf("expected ErrNoClipboardTool, got %v", err)
+	}
+}
+
+func TestClipboardHandler_CopiesSuccessfulURLs(t *testing.T) {
+	clipboard, calls := fakeClipboard("darwin", "pbcopy")
+	handler := NewClipboardHandler(NewTextHandler(&bytes.Buffer{}), clipboard, &bytes.Buffer{})
+
+	handler.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a"})
+	handler.HandleResult(uploader.UploadResult{FileName: "b.txt", Error: errors.New("denied")})
+	handler.HandleResult(uploader.UploadResult{FileName: "c.txt", URL: "https://example.com/c"})
+	if err := handler.HandleSummary(uploader.Summary{Succeeded: 2, Failed: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*calls) != 1 || (*calls)[0][1] != "https://example.com/a\nhttps://example.com/c" {
+		t.Errorf("expected the successful URLs copied once, got %q", *calls)
+	}
+}
+
+func TestClipboardHandler_WarnsWithoutTool(t *testing.T) {
+	clipboard, _ := fakeClipboard("linux")
+	warnings := &bytes.Buffer{}
+	handler := NewClipboardHandler(NewTextHandler(&bytes.Buffer{}), clipboard, warnings)
+
+	handler.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a"})
+	if err := handler.HandleSummary(uploader.Summary{Succeeded: 1}); err != nil {
+		t.Fatalf("a missing clipboard tool should not fail the run, got %v", err)
+	}
+	if expected := "Warning: could not copy URLs to the clipboard: no clipboard tool found\n"; warnings.String() != expected {
+		t.Errorf("expected %q, got %q", expected, warnings.String())
+	}
+}
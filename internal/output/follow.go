@@ -0,0 +1,154 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// ANSI sequences used to redraw the dashboard in place
+const (
+	ansiCursorUp   = "\x1b[%dA"
+	ansiClearBelow = "\x1b[J"
+	ansiClearLine  = "\x1b[K"
+)
+
+// FollowHandler renders a dashboard of the run from an Aggregator on a ticker,
+// redrawing it in place instead of printing a line per result
+type FollowHandler struct {
+	output     io.Writer
+	aggregator *Aggregator
+	interval   time.Duration
+
+	mu        sync.Mutex
+	lastLines int
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFollowHandler starts rendering aggregator snapshots to w every interval
+func NewFollowHandler(w io.Writer, aggregator *Aggregator, interval time.Duration) *FollowHandler {
+	f := &FollowHandler{
+		output:     w,
+		aggregator: aggregator,
+		interval:   interval,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go f.loop()
+	return f
+}
+
+func (f *FollowHandler) loop() {
+	defer close(f.done)
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.render()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// render replaces the previous frame with the current snapshot
+func (f *FollowHandler) render() {
+	frame := RenderFrame(f.aggregator.Snapshot())
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lastLines > 0 {
+		fmt.Fprintf(f.output, ansiCursorUp, f.lastLines)
+		fmt.Fprint(f.output, ansiClearBelow)
+	}
+	fmt.Fprint(f.output, frame)
+	f.lastLines = strings.Count(frame, "\n")
+}
+
+// HandleResult records the result; it appears in the next frame
+func (f *FollowHandler) HandleResult(result uploader.UploadResult) error {
+	f.aggregator.AddResult(result)
+	return nil
+}
+
+// HandleProgress is a no-op; the aggregator receives progress as a listener
+func (f *FollowHandler) HandleProgress(progress uploader.ProgressInfo) error {
+	return nil
+}
+
+// Close stops the ticker and draws the final frame
+func (f *FollowHandler) Close() error {
+	f.closeOnce.Do(func() {
+		close(f.stop)
+		<-f.done
+		f.render()
+	})
+	return nil
+}
+
+// RenderFrame builds the dashboard text for a snapshot
+func RenderFrame(s Snapshot) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "woof - %s elapsed\n", s.Elapsed.Round(time.Second))
+	fmt.Fprintf(&b, "Files: %d done (%d ok, %d failed, %d cancelled, %d skipped), %d in flight\n",
+		s.Completed(), s.Succeeded, s.Failed, s.Cancelled, s.Skipped, len(s.InFlight))
+	fmt.Fprintf(&b, "Bytes: %s uploaded, %s in flight\n", FormatBytes(s.BytesUploaded), FormatBytes(s.BytesInFlight))
+	if s.Retry.FirstTry+s.Retry.Retried > 0 {
+		fmt.Fprintf(&b, "Retries: %s\n", formatRetryStats(s.Retry))
+	}
+
+	if len(s.Providers) > 0 {
+		b.WriteString("\nProviders:\n")
+		for _, p := range s.Providers {
+			fmt.Fprintf(&b, "  %-14s %4d files  %10s  %s/s\n", p.Name, p.Files, FormatBytes(p.Bytes), FormatBytes(int64(p.Throughput)))
+		}
+	}
+
+	if len(s.InFlight) > 0 {
+		b.WriteString("\nIn flight:\n")
+		for _, info := range s.InFlight {
+			fmt.Fprintf(&b, "  %-30s %5.1f%% (%s/%s)\n", info.FileName, info.Percentage, FormatBytes(info.BytesUploaded), FormatBytes(info.TotalBytes))
+		}
+	}
+
+	if len(s.Recent) > 0 {
+		b.WriteString("\nRecent:\n")
+		for _, result := range s.Recent {
+			switch {
+			case result.Cancelled:
+				fmt.Fprintf(&b, "  CANCELLED %s\n", result.FileName)
+			case result.Skipped:
+				fmt.Fprintf(&b, "  SKIPPED   %s\n", result.FileName)
+			case result.Error != nil:
+				fmt.Fprintf(&b, "  ERROR     %s: %v\n", result.FileName, result.Error)
+			default:
+				fmt.Fprintf(&b, "  SUCCESS   %s -> %s\n", result.FileName, result.URL)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// IsTerminal reports whether w is an interactive terminal
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
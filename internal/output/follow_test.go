@@ -0,0 +1,136 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestRenderFrame(t *testing.T) {
+	snapshot := Snapshot{
+		Elapsed:       12*time.Second + 400*time.Millisecond,
+		Succeeded:     2,
+		Failed:        1,
+		BytesUploaded: 3 * 1024 * 1024,
+		BytesInFlight: 512,
+		Providers: []ProviderStats{
+			{Name: "GoFile", Files: 2, Bytes: 3 * 1024 * 1024, Throughput: 256 * 1024},
+		},
+		InFlight: []uploader.ProgressInfo{
+			{FileName: "video.mp4", BytesUploaded: 512, TotalBytes: 2048, Percentage: 25},
+		},
+		Recent: []uploader.UploadResult{
+			{FileName: "c.txt", Error: errors.New("quota exceeded")},
+			{FileName: "b.txt", URL: "https://gofile.io/d/b", Provider: "GoFile"},
+		},
+	}
+
+	expected := strings.Join([]string{
+		"woof - 12s elapsed",
+		"Files: 3 done (2 ok, 1 failed, 0 cancelled, 0 skipped), 1 in flight",
+		"Bytes: 3.0 MiB uploaded, 512 B in flight",
+		"",
+		"Providers:",
+		"  GoFile            2 files     3.0 MiB  256.0 KiB/s",
+		"",
+		"In flight:",
+		"  video.mp4                       25.0% (512 B/2.0 KiB)",
+		"",
+		"Recent:",
+		"  ERROR     c.txt: quota exceeded",
+		"  SUCCESS   b.txt -> https://gofile.io/d/b",
+		"",
+	}, "\n")
+
+	if frame := RenderFrame(snapshot); frame != expected {
+		t.Errorf("unexpected frame:\n%s\nexpected:\n%s", frame, expected)
+	}
+}
+
+func TestAggregator_Snapshot(t *testing.T) {
+	aggregator := NewAggregator()
+	start := aggregator.start
+	aggregator.now = func() time.Time { return start.Add(2 * time.Second) }
+
+	aggregator.OnProgress(uploader.ProgressInfo{FileName: "a.txt", BytesUploaded: 50, TotalBytes: 100})
+	aggregator.OnProgress(uploader.ProgressInfo{FileName: "b.txt", BytesUploaded: 10, TotalBytes: 100})
+	aggregator.AddResult(uploader.UploadResult{FileName: "a.txt", Size: 100, Provider: "GoFile", URL: "https://gofile.io/d/a"})
+
+	snapshot := aggregator.Snapshot()
+	if snapshot.Succeeded != 1 || snapshot.BytesUploaded != 100 {
+		t.Errorf("expected 1 success of 100 bytes, got %+v", snapshot)
+	}
+	if len(snapshot.InFlight) != 1 || snapshot.InFlight[0].FileName != "b.txt" || snapshot.BytesInFlight != 10 {
+		t.Errorf("expected only b.txt in flight, got %+v", snapshot.InFlight)
+	}
+	if len(snapshot.Providers) != 1 || snapshot.Providers[0].Throughput != 50 {
+		t.Errorf("expected GoFile throughput of 50 B/s, got %+v", snapshot.Providers)
+	}
+}
+
+func TestFollowHandler_RedrawsInPlace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	follow := NewFollowHandler(buf, NewAggregator(), time.Hour)
+	follow.render()
+	follow.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a"})
+	follow.Close()
+
+	if !strings.Contains(buf.String(), "\x1b[3A\x1b[J") {
+		t.Errorf("expected the first frame to be erased before redrawing, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "SUCCESS   a.txt") {
+		t.Errorf("final frame should include the result, got %q", buf.String())
+	}
+}
+
+// resultWithAttempts builds a successful result whose response records attempts
+func resultWithAttempts(name, attempts string) uploader.UploadResult {
+	return uploader.UploadResult{
+		FileName: name,
+		Provider: "GoFile",
+		Response: &providers.ProviderResponse{Metadata: map[string]string{providers.MetadataAttempts: attempts}},
+	}
+}
+
+func TestAggregator_RetryStats(t *testing.T) {
+	aggregator := NewAggregator()
+	aggregator.AddResult(resultWithAttempts("a.txt", "1"))
+	aggregator.AddResult(resultWithAttempts("b.txt", "3"))
+	aggregator.AddResult(resultWithAttempts("c.txt", "2"))
+	aggregator.AddResult(uploader.UploadResult{FileName: "d.txt", Provider: "GoFile"})
+	aggregator.AddResult(uploader.UploadResult{FileName: "e.txt", Error: errors.New("quota exceeded")})
+
+	snapshot := aggregator.Snapshot()
+	expected := uploader.RetryStats{FirstTry: 2, Retried: 2, Retries: 3}
+	if snapshot.Retry != expected {
+		t.Errorf("expected %+v, got %+v", expected, snapshot.Retry)
+	}
+	if frame := RenderFrame(snapshot); !strings.Contains(frame, "Retries: 2 first try, 2 after retries (3 retries total)") {
+		t.Errorf("expected retry stats in the frame, got %q", frame)
+	}
+}
+
+func TestHandleSummary_RetryStats(t *testing.T) {
+	summary := uploader.Summary{Succeeded: 3, Failed: 1, RetryStats: uploader.RetryStats{FirstTry: 2, Retried: 1, Retries: 2}}
+
+	text := &bytes.Buffer{}
+	NewTextHandler(text).HandleSummary(summary)
+	if !strings.Contains(text.String(), "Summary: 3 succeeded, 1 failed") || !strings.Contains(text.String(), "Retries: 2 first try, 1 after retries (2 retries total)") {
+		t.Errorf("unexpected text summary %q", text.String())
+	}
+
+	jsonOut := &bytes.Buffer{}
+	handler := NewJSONHandler(jsonOut)
+	handler.HandleSummary(summary)
+	handler.Close()
+	for _, field := range []string{`"type":"summary"`, `"first_try":2`, `"retried":1`, `"retries":2`} {
+		if !strings.Contains(jsonOut.String(), field) {
+			t.Errorf("expected %s in JSON summary %q", field, jsonOut.String())
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// GroupedUpload is the outcome of one provider for a file
+type GroupedUpload struct {
+	Provider string `json:"provider"`
+	URL      string `json:"url,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// FileGroup lists every provider result of one file
+type FileGroup struct {
+	File    string          `json:"file"`
+	Name    string          `json:"filename"`
+	Size    int64           `json:"size"`
+	Uploads []GroupedUpload `json:"uploads"`
+}
+
+// GroupHandler is implemented by handlers that can render a FileGroup
+type GroupHandler interface {
+	HandleGroup(group FileGroup) error
+}
+
+// Grouper collects results by file path and releases a file's group once the
+// expected number of results for it arrived
+type Grouper struct {
+	expected int
+	pending  map[string]*FileGroup
+	order    []string // File paths in the order their first result arrived
+}
+
+// NewGrouper creates a Grouper expecting the given number of results per file
+func NewGrouper(expected int) *Grouper {
+	if expected < 1 {
+		expected = 1
+	}
+	return &Grouper{
+		expected: expected,
+		pending:  make(map[string]*FileGroup),
+	}
+}
+
+// Add records a result and returns the file's group once it is complete.
+// Results without a file path (scan errors) form a group of their own.
+func (g *Grouper) Add(result uploader.UploadResult) (FileGroup, bool) {
+	upload := GroupedUpload{Provider: result.Provider, URL: result.URL}
+	if result.Error != nil {
+		upload.URL = ""
+		upload.Error = result.Error.Error()
+	}
+
+	if result.FilePath == "" {
+		return FileGroup{Name: result.FileName, Size: result.Size, Uploads: []GroupedUpload{upload}}, true
+	}
+
+	group, ok := g.pending[result.FilePath]
+	if !ok {
+		group = &FileGroup{File: result.FilePath, Name: result.FileName, Size: result.Size}
+		g.pending[result.FilePath] = group
+		g.order = append(g.order, result.FilePath)
+	}
+	group.Uploads = append(group.Uploads, upload)
+
+	if len(group.Uploads) < g.expected {
+		return FileGroup{}, false
+	}
+	g.remove(result.FilePath)
+	return *group, true
+}
+
+// Flush returns the groups still waiting for results, in arrival order, and
+// forgets them. Files whose remaining uploads were cancelled end up here.
+func (g *Grouper) Flush() []FileGroup {
+	groups := make([]FileGroup, 0, len(g.order))
+	for _, path := range g.order {
+		groups = append(groups, *g.pending[path])
+	}
+	g.pending = make(map[string]*FileGroup)
+	g.order = nil
+	return groups
+}
+
+// remove forgets a completed group
+func (g *Grouper) remove(path string) {
+	delete(g.pending, path)
+	for i, pending := range g.order {
+		if pending == path {
+			g.order = append(g.order[:i], g.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// GroupingHandler renders one record per file instead of one per result. Progress
+// and the summary pass through to the wrapped handler; groups still incomplete
+// when the summary or Close arrives are written as they are.
+type GroupingHandler struct {
+	inner   Handler
+	groups  GroupHandler
+	grouper *Grouper
+}
+
+// NewGroupingHandler wraps a handler that implements GroupHandler, expecting
+// the given number of results per file
+func NewGroupingHandler(inner Handler, expected int) (*GroupingHandler, error) {
+	groups, ok := inner.(GroupHandler)
+	if !ok {
+		return nil, fmt.Errorf("output handler %T does not support grouped results", inner)
+	}
+	return &GroupingHandler{
+		inner:   inner,
+		groups:  groups,
+		grouper: NewGrouper(expected),
+	}, nil
+}
+
+// HandleResult buffers the result and writes the file's group once complete
+func (g *GroupingHandler) HandleResult(result uploader.UploadResult) error {
+	if group, ok := g.grouper.Add(result); ok {
+		return g.groups.HandleGroup(group)
+	}
+	return nil
+}
+
+// HandleProgress forwards progress to the wrapped handler
+func (g *GroupingHandler) HandleProgress(progress uploader.ProgressInfo) error {
+	return g.inner.HandleProgress(progress)
+}
+
+// HandleSummary writes incomplete groups, then forwards the summary
+func (g *GroupingHandler) HandleSummary(summary uploader.Summary) error {
+	if err := g.flush(); err != nil {
+		return err
+	}
+	if sh, ok := g.inner.(SummaryHandler); ok {
+		return sh.HandleSummary(summary)
+	}
+	return nil
+}
+
+// Close writes incomplete groups and closes the wrapped handler
+func (g *GroupingHandler) Close() error {
+	if err := g.flush(); err != nil {
+		return err
+	}
+	return g.inner.Close()
+}
+
+// flush writes every incomplete group
+func (g *GroupingHandler) flush() error {
+	for _, group := range g.grouper.Flush() {
+		if err := g.groups.HandleGroup(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
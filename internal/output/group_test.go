@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestGrouper_EmitsWhenAllProvidersReported(t *testing.T) {
+	grouper := NewGrouper(2)
+
+	if _, ok := grouper.Add(uploader.UploadResult{FilePath: "/a.txt", FileName: "a.txt", Provider: "one", URL: "https://one/a"}); ok {
+		t.Fatal("group should wait for the second provider")
+	}
+	if _, ok := grouper.Add(uploader.UploadResult{FilePath: "/b.txt", FileName: "b.txt", Provider: "one", URL: "https://one/b"}); ok {
+		t.Fatal("group for b.txt should not be complete")
+	}
+
+	group, ok := grouper.Add(uploader.UploadResult{FilePath: "/a.txt", FileName: "a.txt", Provider: "two", Error: errors.New("boom")})
+	if !ok {
+		t.Fatal("expected a.txt to be complete after two results")
+	}
+	if group.File != "/a.txt" || len(group.Uploads) != 2 {
+		t.Fatalf("unexpected group %+v", group)
+	}
+	if group.Uploads[0].URL != "https://one/a" || group.Uploads[1].Error != "boom" || group.Uploads[1].URL != "" {
+		t.Errorf("expected one success and one failure, got %+v", group.Uploads)
+	}
+
+	pending := grouper.Flush()
+	if len(pending) != 1 || pending[0].File != "/b.txt" || len(pending[0].Uploads) != 1 {
+		t.Errorf("expected the partial b.txt group on flush, got %+v", pending)
+	}
+	if len(grouper.Flush()) != 0 {
+		t.Error("flush should forget the groups it returned")
+	}
+}
+
+func TestGroupingHandler_JSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler, err := NewGroupingHandler(NewJSONHandler(buf), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler.HandleResult(uploader.UploadResult{FilePath: "/a.txt", FileName: "a.txt", Size: 3, Provider: "one", URL: "https://one/a"})
+	handler.HandleResult(uploader.UploadResult{FilePath: "/b.txt", FileName: "b.txt", Provider: "one", Error: errors.New("denied")})
+	handler.HandleResult(uploader.UploadResult{FilePath: "/a.txt", FileName: "a.txt", Size: 3, Provider: "two", URL: "https://two/a"})
+	if err := handler.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	var decoded struct {
+		Results []FileGroup `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	groups := decoded.Results
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].File != "/a.txt" || len(groups[0].Uploads) != 2 || groups[0].Uploads[1].URL != "https://two/a" {
+		t.Errorf("unexpected complete group %+v", groups[0])
+	}
+	if groups[1].File != "/b.txt" || len(groups[1].Uploads) != 1 || groups[1].Uploads[0].Error != "denied" {
+		t.Errorf("unexpected partial group %+v", groups[1])
+	}
+}
+
+func TestGroupingHandler_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler, err := NewGroupingHandler(NewTextHandler(buf), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler.HandleResult(uploader.UploadResult{FilePath: "/a.txt", FileName: "a.txt", Size: 3, Provider: "one", URL: "https://one/a"})
+	if buf.Len() != 0 {
+		t.Fatalf("nothing should be written before the group is complete, got %q", buf.String())
+	}
+	handler.HandleResult(uploader.UploadResult{FilePath: "/a.txt", FileName: "a.txt", Size: 3, Provider: "two", Error: errors.New("timeout")})
+
+	expected := "a.txt (3 B)\n  one -> https://one/a\n  ERROR two: timeout\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestNewGroupingHandler_Unsupported(t *testing.T) {
+	if _, err := NewGroupingHandler(&FollowHandler{}, 1); err == nil || !strings.Contains(err.Error(), "grouped") {
+		t.Errorf("expected an unsupported handler error, got %v", err)
+	}
+}
@@ -15,13 +15,46 @@ type Handler interface {
 	Close() error
 }
 
+// SummaryHandler is implemented by handlers that render the end-of-run summary
+type SummaryHandler interface {
+	HandleSummary(summary uploader.Summary) error
+}
+
+// formatRetryStats describes how many successful uploads needed retries
+func formatRetryStats(stats uploader.RetryStats) string {
+	return fmt.Sprintf("%d first try, %d after retries (%d retries total)", stats.FirstTry, stats.Retried, stats.Retries)
+}
+
+// HandlerConfig holds settings needed by some output formats
+type HandlerConfig struct {
+	Template      string // text/template source for the template format
+	TotalProgress bool   // Text format shows one bar for the whole run instead of one per file
+}
+
 // NewHandler creates a new output handler for the specified format
 func NewHandler(format string) (Handler, error) {
+	return NewHandlerWithConfig(format, HandlerConfig{})
+}
+
+// NewHandlerWithConfig creates a new output handler for the specified format,
+// validating format specific settings such as the template up front
+func NewHandlerWithConfig(format string, config HandlerConfig) (Handler, error) {
 	switch strings.ToLower(format) {
 	case "json":
 		return NewJSONHandler(os.Stdout), nil
+	case "jsonl":
+		return NewJSONLHandler(os.Stdout), nil
 	case "text":
+		if config.TotalProgress {
+			return NewTotalTextHandler(os.Stdout), nil
+		}
 		return NewTextHandler(os.Stdout), nil
+	case "markdown":
+		return NewMarkdownHandler(os.Stdout), nil
+	case "urls":
+		return NewURLsHandler(os.Stdout, os.Stderr), nil
+	case "template":
+		return NewTemplateHandler(os.Stdout, config.Template)
 	default:
 		return nil, fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/history"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// HistoryHandler decorates a handler and appends every successful upload to
+// the upload history as its result arrives, so an interrupted run keeps the
+// uploads that finished. A failing write only produces a warning, once per run.
+type HistoryHandler struct {
+	Handler
+	mu       sync.Mutex
+	store    *history.Store
+	warnings io.Writer
+	warned   bool
+}
+
+// NewHistoryHandler wraps inner, recording uploads in store and writing
+// history warnings to warnings
+func NewHistoryHandler(inner Handler, store *history.Store, warnings io.Writer) *HistoryHandler {
+	return &HistoryHandler{Handler: inner, store: store, warnings: warnings}
+}
+
+// HandleResult records a successful upload and forwards the result
+func (h *HistoryHandler) HandleResult(result uploader.UploadResult) error {
+	if result.Error == nil && result.URL != "" {
+		h.record(result)
+	}
+	return h.Handler.HandleResult(result)
+}
+
+func (h *HistoryHandler) record(result uploader.UploadResult) {
+	uploadTime := result.UploadTime
+	if uploadTime.IsZero() {
+		uploadTime = time.Now()
+	}
+	err := h.store.Append(history.Record{
+		Time:      uploadTime,
+		FileName:  result.FileName,
+		Size:      result.Size,
+		Provider:  result.Provider,
+		URL:       result.URL,
+		DeleteURL: result.DeleteURL,
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil && !h.warned {
+		h.warned = true
+		fmt.Fprintf(h.warnings, "Warning: could not record the upload history in %s: %v\n", h.store.Path(), err)
+	}
+}
+
+// HandleSummary forwards the summary
+func (h *HistoryHandler) HandleSummary(summary uploader.Summary) error {
+	if sh, ok := h.Handler.(SummaryHandler); ok {
+		return sh.HandleSummary(summary)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/history"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestHistoryHandler_RecordsSuccessfulUploads(t *testing.T) {
+	store := history.NewStore(filepath.Join(t.TempDir(), history.FileName))
+	out := &bytes.Buffer{}
+	warnings := &bytes.Buffer{}
+	handler := NewHistoryHandler(NewTextHandler(out), store, warnings)
+
+	for _, result := range manifestResults() {
+		handler.HandleResult(result)
+	}
+	if err := handler.HandleSummary(uploader.Summary{Succeeded: 3, Failed: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := store.Query(history.Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected the 3 successful uploads, got %d", len(records))
+	}
+	for _, record := range records {
+		if record.FileName == "c.txt" {
+			t.Errorf("failed upload was recorded: %+v", record)
+		}
+		if record.Time.IsZero() {
+			t.Errorf("record has no time: %+v", record)
+		}
+	}
+	if !strings.Contains(out.String(), "https://catbox.example/a") {
+		t.Errorf("results were not forwarded: %q", out.String())
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("unexpected warnings: %q", warnings.String())
+	}
+}
+
+func TestHistoryHandler_WarnsOnceWhenUnwritable(t *testing.T) {
+	// A file where the history directory should be makes every append fail
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "woof")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings := &bytes.Buffer{}
+	handler := NewHistoryHandler(NewTextHandler(&bytes.Buffer{}), history.NewStore(filepath.Join(blocker, history.FileName)), warnings)
+
+	for _, result := range manifestResults() {
+		if err := handler.HandleResult(result); err != nil {
+			t.Fatalf("a history failure should not fail the result: %v", err)
+		}
+	}
+	if count := strings.Count(warnings.String(), "Warning:"); count != 1 {
+		t.Errorf("expected one warning, got %q", warnings.String())
+	}
+}
@@ -1,17 +1,21 @@
 package output
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/parnexcodes/woof/internal/timefmt"
 	"github.com/parnexcodes/woof/internal/uploader"
 )
 
-// formatBytes formats bytes into human readable format
-func formatBytes(bytes int64) string {
+// FormatBytes formats a byte count with binary units, e.g. "1.5 MiB"
+func FormatBytes(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
@@ -24,53 +28,88 @@ func formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// JSONHandler implements Handler for JSON output
+// JSONHandler implements Handler for JSON output. The whole run is written as
+// one object, {"results":[...],"progress":[...],"summary":{...}}: results (or
+// file groups) are streamed into the results array as they arrive, while
+// progress events and the summary are held until Close, which always
+// completes the object so the output parses even for an empty run.
 type JSONHandler struct {
-	encoder   *json.Encoder
-	first     bool
-	progress  bool
-	output    io.Writer
+	mu       sync.Mutex
+	encoder  *json.Encoder
+	output   io.Writer
+	started  bool         // The opening of the object has been written
+	results  int          // Elements written to the results array
+	progress bytes.Buffer // Encoded progress events, comma separated
+	summary  *uploader.Summary
+	closed   bool
 }
 
 // NewJSONHandler creates a new JSON handler
 func NewJSONHandler(w io.Writer) *JSONHandler {
 	return &JSONHandler{
-		encoder:  json.NewEncoder(w),
-		first:    true,
-		progress: false,
-		output:   w,
+		encoder: json.NewEncoder(w),
+		output:  w,
 	}
 }
 
 // HandleResult handles an upload result in JSON format
 func (j *JSONHandler) HandleResult(result uploader.UploadResult) error {
-	if j.first {
-		fmt.Fprintf(j.output, "[")
-		j.first = false
-	} else {
+	result.ProgressInfo = nil // Remove progress info from result output
+	return j.writeResult(result)
+}
+
+// HandleGroup writes a file's grouped provider results as one element of the results array
+func (j *JSONHandler) HandleGroup(group FileGroup) error {
+	return j.writeResult(group)
+}
+
+// writeResult appends one element to the results array
+func (j *JSONHandler) writeResult(v interface{}) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.start()
+	if j.results > 0 {
 		fmt.Fprintf(j.output, ",")
 	}
+	j.results++
+	return j.encoder.Encode(v)
+}
 
-	result.ProgressInfo = nil // Remove progress info from result output
-	return j.encoder.Encode(result)
+// start writes the opening of the object once
+func (j *JSONHandler) start() {
+	if !j.started {
+		fmt.Fprintf(j.output, "{\"results\":[")
+		j.started = true
+	}
 }
 
-// HandleProgress handles progress information in JSON format
+// HandleProgress records progress information for the progress array
 func (j *JSONHandler) HandleProgress(progress uploader.ProgressInfo) error {
-	// Progress updates are streamed as separate JSON objects
-	if !j.progress {
-		// Start progress stream marker
-		fmt.Fprintf(j.output, "\n{\"type\":\"progress_stream\",\"items\":[")
-		j.progress = true
-		j.first = true
+	data, err := json.Marshal(progressItem(progress))
+	if err != nil {
+		return err
 	}
 
-	if j.first {
-		j.first = false
-	} else {
-		fmt.Fprintf(j.output, ",")
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.progress.Len() > 0 {
+		j.progress.WriteByte(',')
 	}
+	j.progress.Write(data)
+	return nil
+}
 
+// progressItem converts a progress event for the JSON formats
+func progressItem(progress uploader.ProgressInfo) map[string]interface{} {
+	if progress.Plan != nil {
+		return map[string]interface{}{
+			"type":  "plan",
+			"files": progress.Plan.Files,
+			"bytes": progress.Plan.Bytes,
+		}
+	}
 	item := map[string]interface{}{
 		"type":     "progress",
 		"filename": progress.FileName,
@@ -79,52 +118,212 @@ func (j *JSONHandler) HandleProgress(progress uploader.ProgressInfo) error {
 		"percent":  progress.Percentage,
 		"speed":    progress.Speed,
 	}
+	if progress.ETAKnown() {
+		item["eta"] = timefmt.Duration(progress.ETA)
+	}
+	if progress.Retry > 0 {
+		item["phase"] = progress.Phase
+		item["retry"] = progress.Retry
+		item["max_retries"] = progress.MaxRetries
+	}
+	return item
+}
+
+// HandleSummary records the end-of-run summary, written by Close
+func (j *JSONHandler) HandleSummary(summary uploader.Summary) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 
-	return j.encoder.Encode(item)
+	j.summary = &summary
+	return nil
+}
+
+// summaryItem converts the end-of-run summary for the JSON formats
+func summaryItem(summary uploader.Summary) map[string]interface{} {
+	return map[string]interface{}{
+		"type":           "summary",
+		"succeeded":      summary.Succeeded,
+		"failed":         summary.Failed,
+		"cancelled":      summary.Cancelled,
+		"skipped":        summary.Skipped,
+		"files":          summary.Files,
+		"bytes_uploaded": summary.BytesUploaded,
+		"duration":       timefmt.Duration(summary.Duration),
+		"first_try":      summary.FirstTry,
+		"retried":        summary.Retried,
+		"retries":        summary.Retries,
+		"expiring_soon":  expiringLinks(summary.ExpiringSoon),
+		"providers":      providerCounts(summary.Providers),
+	}
 }
 
-// Close closes the JSON handler
+// providerCounts converts the per-provider tallies for the JSON summary
+func providerCounts(counts map[string]uploader.ProviderCount) map[string]interface{} {
+	items := make(map[string]interface{}, len(counts))
+	for name, count := range counts {
+		items[name] = map[string]int{
+			"succeeded": count.Succeeded,
+			"failed":    count.Failed,
+		}
+	}
+	return items
+}
+
+// expiringLinks converts soon-expiring links for the JSON summary
+func expiringLinks(links []uploader.ExpiringLink) []map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(links))
+	for _, link := range links {
+		items = append(items, map[string]interface{}{
+			"filename": link.FileName,
+			"provider": link.Provider,
+			"url":      link.URL,
+			"expires":  timefmt.Timestamp(link.Expires),
+		})
+	}
+	return items
+}
+
+// Close ends the results array and writes the progress and summary, closing
+// the object. Calls after the first do nothing.
 func (j *JSONHandler) Close() error {
-	if !j.first {
-		fmt.Fprintf(j.output, "]")
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return nil
 	}
-	return nil
+	j.closed = true
+
+	j.start()
+	fmt.Fprintf(j.output, "],\"progress\":[%s]", j.progress.Bytes())
+	if j.summary != nil {
+		fmt.Fprintf(j.output, ",\"summary\":")
+		if err := j.encoder.Encode(summaryItem(*j.summary)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(j.output, "}\n")
+	return err
 }
 
-// TextHandler implements Handler for human-readable text output
+// TextHandler implements Handler for human-readable text output. Output is
+// buffered and flushed after every result, progress line and summary, so a
+// reader such as tail -f on a redirected file sees each result immediately.
+// It is safe for concurrent use; each call writes and flushes its lines as a
+// unit, so progress from concurrent uploads never tears a line.
 type TextHandler struct {
-	output io.Writer
+	mu         sync.Mutex
+	output     *bufio.Writer
+	total      *TotalProgress // Aggregate mode when set, see NewTotalTextHandler
+	totalShown bool           // The aggregate bar occupies the current line
 }
 
 // NewTextHandler creates a new text handler
 func NewTextHandler(w io.Writer) *TextHandler {
 	return &TextHandler{
-		output: w,
+		output: bufio.NewWriter(w),
 	}
 }
 
+// NewTotalTextHandler creates a text handler that replaces the per-file bars
+// with a single bar for the whole run, kept below the result lines. It needs
+// the uploader's RunPlan event (UploadConfig.PlanTotals) to show a total.
+func NewTotalTextHandler(w io.Writer) *TextHandler {
+	handler := NewTextHandler(w)
+	handler.total = NewTotalProgress()
+	return handler
+}
+
 // HandleResult handles an upload result in text format
 func (t *TextHandler) HandleResult(result uploader.UploadResult) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.clearTotal()
+	t.writeResult(result)
+	if t.total != nil {
+		t.total.AddResult(result)
+		t.drawTotal()
+	}
+	return t.output.Flush()
+}
+
+// writeResult formats a result line into the buffer
+func (t *TextHandler) writeResult(result uploader.UploadResult) {
+	if result.Cancelled {
+		fmt.Fprintf(t.output, "CANCELLED %s: %v\n", result.FileName, result.Error)
+		return
+	}
+
+	if result.Skipped {
+		fmt.Fprintf(t.output, "SKIPPED %s: %v\n", result.FileName, result.Error)
+		return
+	}
+
 	if result.Error != nil {
 		fmt.Fprintf(t.output, "ERROR %s: %v\n", result.FileName, result.Error)
-		return nil
+		return
+	}
+
+	speed := ""
+	if result.SpeedBps > 0 {
+		speed = fmt.Sprintf(" @ %s/s", FormatBytes(int64(result.SpeedBps)))
 	}
 
 	fmt.Fprintf(t.output,
-		"SUCCESS %s (%s) -> %s [%s via %s]\n",
+		"SUCCESS %s (%s) -> %s [%s via %s%s]\n",
 		result.FileName,
-		formatBytes(result.Size),
+		FormatBytes(result.Size),
 		result.URL,
-		result.Duration.Round(time.Millisecond),
+		timefmt.Duration(result.Duration),
 		result.Provider,
+		speed,
 	)
-	return nil
+	if result.DeleteURL != "" {
+		fmt.Fprintf(t.output, "  delete: %s\n", result.DeleteURL)
+	}
+	if result.Expires != nil {
+		fmt.Fprintf(t.output, "  expires: %s\n", timefmt.Timestamp(*result.Expires))
+	}
+	if result.Checksum != "" {
+		fmt.Fprintf(t.output, "  sha256: %s\n", result.Checksum)
+	}
+}
+
+// HandleGroup prints a file followed by one line per provider
+func (t *TextHandler) HandleGroup(group FileGroup) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.clearTotal()
+	fmt.Fprintf(t.output, "%s (%s)\n", group.Name, FormatBytes(group.Size))
+	for _, upload := range group.Uploads {
+		if upload.Error != "" {
+			fmt.Fprintf(t.output, "  ERROR %s: %s\n", upload.Provider, upload.Error)
+			continue
+		}
+		fmt.Fprintf(t.output, "  %s -> %s\n", upload.Provider, upload.URL)
+	}
+	if t.total != nil {
+		t.total.AddGroup(group)
+		t.drawTotal()
+	}
+	return t.output.Flush()
 }
 
 // HandleProgress handles progress information in text format
 func (t *TextHandler) HandleProgress(progress uploader.ProgressInfo) error {
-	// Simple progress bar for text output
-	barWidth := 40
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.total != nil {
+		t.total.OnProgress(progress)
+		t.drawTotal()
+		return t.output.Flush()
+	}
+	if progress.Plan != nil {
+		return nil // Only the aggregate bar uses the run's totals
+	}
 
 	// Handle edge cases for percentage
 	percentage := progress.Percentage
@@ -133,31 +332,136 @@ func (t *TextHandler) HandleProgress(progress uploader.ProgressInfo) error {
 	} else if percentage > 100 {
 		percentage = 100
 	}
+	bar := progressBar(percentage)
 
-	filled := int(percentage / 100.0 * float64(barWidth))
-	if filled < 0 {
-		filled = 0
-	} else if filled > barWidth {
-		filled = barWidth
+	total := FormatBytes(progress.TotalBytes)
+	if progress.TotalBytes < 0 {
+		total = "?" // Streamed input of unknown length
 	}
 
-	bar := strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
-
 	fmt.Fprintf(t.output, "\r[%s] %s %.1f%% (%s/%s)",
 		bar,
 		progress.FileName,
 		percentage,
-		formatBytes(progress.BytesUploaded),
-		formatBytes(progress.TotalBytes),
+		FormatBytes(progress.BytesUploaded),
+		total,
 	)
+	if progress.Speed > 0 {
+		fmt.Fprintf(t.output, " %s/s", FormatBytes(int64(progress.Speed)))
+	}
+	fmt.Fprintf(t.output, " ETA %s", formatETA(progress))
+	if progress.Retry > 0 {
+		fmt.Fprintf(t.output, " [retry %d/%d]", progress.Retry, progress.MaxRetries)
+	}
 
 	if progress.BytesUploaded >= progress.TotalBytes {
 		fmt.Fprintf(t.output, "\n")
 	}
-	return nil
+	return t.output.Flush()
+}
+
+// progressBarWidth is the number of cells in a text progress bar
+const progressBarWidth = 40
+
+// progressBar renders the cells of a bar filled to percentage, 0 to 100
+func progressBar(percentage float64) string {
+	filled := int(percentage / 100.0 * float64(progressBarWidth))
+	if filled < 0 {
+		filled = 0
+	} else if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
 }
 
-// Close closes the text handler
+// drawTotal redraws the aggregate bar on the current line
+func (t *TextHandler) drawTotal() {
+	fmt.Fprint(t.output, "\r"+formatTotalBar(t.total.Snapshot())+ansiClearLine)
+	t.totalShown = true
+}
+
+// clearTotal erases the aggregate bar so a result can take its line
+func (t *TextHandler) clearTotal() {
+	if t.totalShown {
+		fmt.Fprint(t.output, "\r"+ansiClearLine)
+		t.totalShown = false
+	}
+}
+
+// formatETA renders the time left as mm:ss, or hh:mm:ss past an hour, and
+// --:-- when it cannot be estimated
+func formatETA(progress uploader.ProgressInfo) string {
+	if !progress.ETAKnown() {
+		return "--:--"
+	}
+	seconds := int64(progress.ETA.Round(time.Second) / time.Second)
+	if seconds >= 3600 {
+		return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// HandleSummary prints the end-of-run totals and retry statistics
+func (t *TextHandler) HandleSummary(summary uploader.Summary) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.totalShown {
+		fmt.Fprintln(t.output) // Keep the final bar
+		t.totalShown = false
+	}
+	fmt.Fprintf(t.output, "Summary: %d succeeded, %d failed, %d cancelled, %d skipped of %d files (%s in %s)\n",
+		summary.Succeeded,
+		summary.Failed,
+		summary.Cancelled,
+		summary.Skipped,
+		summary.Files,
+		FormatBytes(summary.BytesUploaded),
+		timefmt.Duration(summary.Duration),
+	)
+	if len(summary.Providers) > 0 {
+		fmt.Fprintf(t.output, "Providers: %s\n", formatProviderCounts(summary))
+	}
+	if summary.Succeeded > 0 {
+		fmt.Fprintf(t.output, "Retries: %s\n", formatRetryStats(summary.RetryStats))
+	}
+	if n := len(summary.ExpiringSoon); n > 0 {
+		window := uploader.ExpiryWarningWindow.Hours()
+		if n == 1 {
+			fmt.Fprintf(t.output, "1 link expires within %.0fh:\n", window)
+		} else {
+			fmt.Fprintf(t.output, "%d links expire within %.0fh:\n", n, window)
+		}
+		for _, link := range summary.ExpiringSoon {
+			fmt.Fprintf(t.output, "  %s %s (%s via %s)\n", timefmt.Timestamp(link.Expires), link.URL, link.FileName, link.Provider)
+		}
+	}
+	return t.output.Flush()
+}
+
+// formatProviderCounts renders the per-provider tallies, e.g.
+// "BuzzHeavier 2 succeeded; GoFile 1 succeeded, 1 failed"
+func formatProviderCounts(summary uploader.Summary) string {
+	parts := make([]string, 0, len(summary.Providers))
+	for _, name := range summary.ProviderNames() {
+		count := summary.Providers[name]
+		part := fmt.Sprintf("%s %d succeeded", name, count.Succeeded)
+		if count.Failed > 0 {
+			part += fmt.Sprintf(", %d failed", count.Failed)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Close flushes anything still buffered
 func (t *TextHandler) Close() error {
-	return nil
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.totalShown {
+		fmt.Fprintln(t.output)
+		t.totalShown = false
+	}
+	return t.output.Flush()
 }
\ No newline at end of file
@@ -0,0 +1,274 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestTextHandler_ProgressRetryAnnotation(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewTextHandler(buf)
+
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "a.bin", BytesUploaded: 512, TotalBytes: 1024, Percentage: 50})
+	if strings.Contains(buf.String(), "retry") {
+		t.Errorf("first attempt should not be annotated, got %q", buf.String())
+	}
+
+	buf.Reset()
+	handler.HandleProgress(uploader.ProgressInfo{
+		FileName:   "a.bin",
+		TotalBytes: 1024,
+		Phase:      "retrying (attempt 3)",
+		Retry:      2,
+		MaxRetries: 3,
+	})
+	if !strings.Contains(buf.String(), "0.0%") || !strings.HasSuffix(buf.String(), " [retry 2/3]") {
+		t.Errorf("expected reset progress with retry annotation, got %q", buf.String())
+	}
+}
+
+func TestTextHandler_ResultSpeed(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewTextHandler(buf)
+
+	handler.HandleResult(uploader.UploadResult{
+		FileName: "a.bin",
+		Size:     2048,
+		URL:      "https://example.com/a",
+		Provider: "test",
+		SpeedBps: 12.3 * 1024 * 1024,
+	})
+	if !strings.Contains(buf.String(), "via test @ 12.3 MiB/s]") {
+		t.Errorf("expected the speed in the result line, got %q", buf.String())
+	}
+
+	buf.Reset()
+	handler.HandleResult(uploader.UploadResult{FileName: "b.bin", URL: "https://example.com/b", Provider: "test"})
+	if strings.Contains(buf.String(), "@") {
+		t.Errorf("expected no speed without a measured duration, got %q", buf.String())
+	}
+}
+
+// lineWriter hands every write to the test as it happens
+type lineWriter struct {
+	writes chan string
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.writes <- string(p)
+	return len(p), nil
+}
+
+func TestTextHandler_FlushesEachResult(t *testing.T) {
+	writer := &lineWriter{writes: make(chan string, 10)}
+	handler := NewTextHandler(writer)
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		handler.HandleResult(uploader.UploadResult{FileName: name, URL: "https://example.com/" + name, Provider: "test"})
+		select {
+		case written := <-writer.writes:
+			if !strings.HasPrefix(written, "SUCCESS "+name) {
+				t.Errorf("expected %s to be written, got %q", name, written)
+			}
+		default:
+			t.Fatalf("result for %s was not flushed before the next one", name)
+		}
+	}
+}
+
+func TestTextHandler_ProgressSpeed(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewTextHandler(buf)
+
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "a.bin", BytesUploaded: 512, TotalBytes: 1024, Percentage: 50, Speed: 3.2 * 1024 * 1024, ETA: 42 * time.Second})
+	if !strings.HasSuffix(buf.String(), "(512 B/1.0 KiB) 3.2 MiB/s ETA 00:42") {
+		t.Errorf("expected the transfer rate and ETA after the byte counts, got %q", buf.String())
+	}
+}
+
+func TestTextHandler_ConcurrentWritesKeepLinesIntact(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewTextHandler(buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("file-%d.bin", i)
+			for n := 0; n < 50; n++ {
+				// Complete progress ends its line, so every write is a whole line
+				handler.HandleProgress(uploader.ProgressInfo{FileName: name, BytesUploaded: 1024, TotalBytes: 1024, Percentage: 100})
+				handler.HandleResult(uploader.UploadResult{FileName: name, URL: "https://example.com/" + name, Provider: "test"})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	progressLine := regexp.MustCompile(`^\r\[=+\] file-\d\.bin 100\.0% \(1\.0 KiB/1\.0 KiB\) ETA --:--$`)
+	resultLine := regexp.MustCompile(`^SUCCESS file-(\d)\.bin \(0 B\) -> https://example\.com/file-(\d)\.bin \[\S+ via test\]$`)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 8*50*2 {
+		t.Fatalf("expected %d lines, got %d", 8*50*2, len(lines))
+	}
+	for _, line := range lines {
+		if progressLine.MatchString(line) {
+			continue
+		}
+		if m := resultLine.FindStringSubmatch(line); m != nil && m[1] == m[2] {
+			continue
+		}
+		t.Fatalf("torn line: %q", line)
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := []struct {
+		progress uploader.ProgressInfo
+		expected string
+	}{
+		{uploader.ProgressInfo{TotalBytes: 100, Speed: 10, ETA: 42 * time.Second}, "00:42"},
+		{uploader.ProgressInfo{TotalBytes: 100, Speed: 10, ETA: 61*time.Minute + 5*time.Second}, "01:01:05"},
+		{uploader.ProgressInfo{TotalBytes: 100, Speed: 0, ETA: 42 * time.Second}, "--:--"},
+		{uploader.ProgressInfo{TotalBytes: 0, Speed: 10}, "--:--"},
+	}
+	for _, tt := range tests {
+		if got := formatETA(tt.progress); got != tt.expected {
+			t.Errorf("formatETA(%+v) = %q, want %q", tt.progress, got, tt.expected)
+		}
+	}
+}
+
+func TestTextHandler_SummaryExpiringLinks(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewTextHandler(buf)
+
+	expires := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	handler.HandleSummary(uploader.Summary{
+		Succeeded: 2,
+		ExpiringSoon: []uploader.ExpiringLink{
+			{FileName: "a.txt", Provider: "Litterbox", URL: "https://litter.catbox.moe/a.txt", Expires: expires},
+			{FileName: "b.txt", Provider: "Uguu", URL: "https://uguu.se/b.txt", Expires: expires.Add(time.Hour)},
+		},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "2 links expire within 24h:\n") {
+		t.Errorf("expected the expiry note, got %q", out)
+	}
+	if !strings.Contains(out, "https://litter.catbox.moe/a.txt (a.txt via Litterbox)") {
+		t.Errorf("expected the expiring link to be listed, got %q", out)
+	}
+
+	buf.Reset()
+	handler.HandleSummary(uploader.Summary{Succeeded: 1})
+	if strings.Contains(buf.String(), "expire") {
+		t.Errorf("expected no expiry note without expiring links, got %q", buf.String())
+	}
+}
+
+func TestHandleSummary_FilesAndProviders(t *testing.T) {
+	summary := uploader.Summary{
+		Succeeded: 3,
+		Failed:    1,
+		Files:     3,
+		Providers: map[string]uploader.ProviderCount{
+			"GoFile":      {Succeeded: 1, Failed: 1},
+			"BuzzHeavier": {Succeeded: 2},
+		},
+	}
+
+	text := &bytes.Buffer{}
+	NewTextHandler(text).HandleSummary(summary)
+	if !strings.Contains(text.String(), "0 skipped of 3 files") {
+		t.Errorf("expected the file count, got %q", text.String())
+	}
+	if !strings.Contains(text.String(), "Providers: BuzzHeavier 2 succeeded; GoFile 1 succeeded, 1 failed\n") {
+		t.Errorf("expected per-provider counts, got %q", text.String())
+	}
+
+	jsonOut := &bytes.Buffer{}
+	handler := NewJSONHandler(jsonOut)
+	handler.HandleSummary(summary)
+	handler.Close()
+	var output struct {
+		Summary struct {
+			Type      string                    `json:"type"`
+			Files     int                       `json:"files"`
+			Providers map[string]map[string]int `json:"providers"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &output); err != nil {
+		t.Fatalf("failed to decode summary %q: %v", jsonOut.String(), err)
+	}
+	decoded := output.Summary
+	if decoded.Type != "summary" || decoded.Files != 3 || decoded.Providers["GoFile"]["failed"] != 1 || decoded.Providers["BuzzHeavier"]["succeeded"] != 2 {
+		t.Errorf("unexpected JSON summary %+v", decoded)
+	}
+}
+
+func TestJSONHandler_InterleavedProgressIsValidJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewJSONHandler(buf)
+
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "a.txt", BytesUploaded: 1, TotalBytes: 2, Percentage: 50})
+	handler.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a", Provider: "test"})
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "b.txt", BytesUploaded: 2, TotalBytes: 2, Percentage: 100})
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "c.txt", BytesUploaded: 1, TotalBytes: 4, Percentage: 25})
+	handler.HandleResult(uploader.UploadResult{FileName: "b.txt", Provider: "test", Error: errors.New("denied")})
+	handler.HandleSummary(uploader.Summary{Succeeded: 1, Failed: 1, Files: 2})
+	if err := handler.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	var decoded struct {
+		Results []struct {
+			FileName string `json:"filename"`
+			Error    string `json:"error"`
+		} `json:"results"`
+		Progress []struct {
+			FileName string  `json:"filename"`
+			Percent  float64 `json:"percent"`
+		} `json:"progress"`
+		Summary struct {
+			Succeeded int `json:"succeeded"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(decoded.Results) != 2 || decoded.Results[1].Error != "denied" {
+		t.Errorf("unexpected results %+v", decoded.Results)
+	}
+	if len(decoded.Progress) != 3 || decoded.Progress[2].FileName != "c.txt" || decoded.Progress[2].Percent != 25 {
+		t.Errorf("unexpected progress %+v", decoded.Progress)
+	}
+	if decoded.Summary.Succeeded != 1 {
+		t.Errorf("unexpected summary %+v", decoded.Summary)
+	}
+}
+
+func TestJSONHandler_EmptyRunIsValidJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewJSONHandler(buf)
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "a.txt", TotalBytes: 2})
+	handler.Close()
+	handler.Close()
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if results, ok := decoded["results"].([]interface{}); !ok || len(results) != 0 {
+		t.Errorf("expected an empty results array, got %v", decoded["results"])
+	}
+}
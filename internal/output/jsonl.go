@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// JSONLHandler implements Handler for JSON Lines output: every result,
+// progress event and summary is one complete JSON object on its own line,
+// written as soon as it arrives, so consumers can parse the stream
+// incrementally. Each object carries a "type" of result, group, progress
+// or summary.
+type JSONLHandler struct {
+	mu     sync.Mutex
+	output io.Writer
+}
+
+// NewJSONLHandler creates a new JSON Lines handler
+func NewJSONLHandler(w io.Writer) *JSONLHandler {
+	return &JSONLHandler{output: w}
+}
+
+// HandleResult writes an upload result as one line
+func (j *JSONLHandler) HandleResult(result uploader.UploadResult) error {
+	result.ProgressInfo = nil // Progress is reported on its own lines
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return j.writeLine(withType("result", data))
+}
+
+// HandleGroup writes a file's grouped provider results as one line
+func (j *JSONLHandler) HandleGroup(group FileGroup) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return err
+	}
+	return j.writeLine(withType("group", data))
+}
+
+// HandleProgress writes a progress event as one line
+func (j *JSONLHandler) HandleProgress(progress uploader.ProgressInfo) error {
+	data, err := json.Marshal(progressItem(progress))
+	if err != nil {
+		return err
+	}
+	return j.writeLine(data)
+}
+
+// HandleSummary writes the end-of-run summary as the last line
+func (j *JSONLHandler) HandleSummary(summary uploader.Summary) error {
+	data, err := json.Marshal(summaryItem(summary))
+	if err != nil {
+		return err
+	}
+	return j.writeLine(data)
+}
+
+// Close has nothing to flush; every line is written whole when it is handled
+func (j *JSONLHandler) Close() error {
+	return nil
+}
+
+// writeLine writes data and its newline in a single call so concurrent lines
+// never interleave
+func (j *JSONLHandler) writeLine(data []byte) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err := j.output.Write(append(data, '\n'))
+	return err
+}
+
+// withType prepends a "type" field to an encoded JSON object, keeping the
+// object's own field order
+func withType(kind string, object []byte) []byte {
+	rest := bytes.TrimPrefix(object, []byte("{"))
+	line := []byte(`{"type":"` + kind + `"`)
+	if len(bytes.TrimSpace(rest)) > 1 { // More than the closing brace
+		line = append(line, ',')
+	}
+	return append(line, rest...)
+}
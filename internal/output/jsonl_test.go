@@ -0,0 +1,55 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestJSONLHandler_EachLineParses(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := NewJSONLHandler(out)
+
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "a.txt", BytesUploaded: 1, TotalBytes: 2, Percentage: 50})
+	handler.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a", Provider: "test"})
+	handler.HandleResult(uploader.UploadResult{FileName: "b.txt", Provider: "test", Error: errors.New("denied")})
+	handler.HandleGroup(FileGroup{Name: "c.txt", Uploads: []GroupedUpload{{Provider: "test", URL: "https://example.com/c"}}})
+	handler.HandleSummary(uploader.Summary{Succeeded: 1, Failed: 1, Files: 2})
+	if err := handler.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var types []string
+	lines := bufio.NewScanner(out)
+	for lines.Scan() {
+		var item map[string]interface{}
+		if err := json.Unmarshal(lines.Bytes(), &item); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", lines.Text(), err)
+		}
+		types = append(types, item["type"].(string))
+
+		if item["type"] == "result" && item["filename"] == "b.txt" && item["error"] != "denied" {
+			t.Errorf("expected the error text on the failed result, got %v", item)
+		}
+	}
+
+	expected := []string{"progress", "result", "result", "group", "summary"}
+	if len(types) != len(expected) {
+		t.Fatalf("expected %d lines, got %v", len(expected), types)
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("line %d: expected type %q, got %q", i, expected[i], types[i])
+		}
+	}
+}
+
+func TestWithType_EmptyObject(t *testing.T) {
+	if got := string(withType("result", []byte("{}"))); got != `{"type":"result"}` {
+		t.Errorf("unexpected object: %s", got)
+	}
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/timefmt"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// Manifest formats accepted by NewManifestHandler
+const (
+	ManifestJSON       = "json"
+	ManifestSHA256Sums = "sha256sums"
+)
+
+// ManifestEntry describes one successful upload in a manifest
+type ManifestEntry struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+	Expires  string `json:"expires,omitempty"`
+}
+
+// Manifest collects the successful uploads of a run so consumers can fetch
+// and verify the whole set from a single file
+type Manifest struct {
+	Files []ManifestEntry `json:"files"`
+}
+
+// Add records a successful result; failed, cancelled and skipped results are ignored
+func (m *Manifest) Add(result uploader.UploadResult) {
+	if result.Error != nil || result.URL == "" {
+		return
+	}
+	entry := ManifestEntry{
+		Name:     result.FileName,
+		Size:     result.Size,
+		SHA256:   result.Checksum,
+		Provider: result.Provider,
+		URL:      result.URL,
+	}
+	if result.Expires != nil {
+		entry.Expires = timefmt.Timestamp(*result.Expires)
+	}
+	m.Files = append(m.Files, entry)
+}
+
+// sort orders entries by file name, then provider, so output is independent of completion order
+func (m *Manifest) sort() {
+	sort.SliceStable(m.Files, func(i, j int) bool {
+		if m.Files[i].Name != m.Files[j].Name {
+			return m.Files[i].Name < m.Files[j].Name
+		}
+		return m.Files[i].Provider < m.Files[j].Provider
+	})
+}
+
+// WriteJSON writes the manifest as indented JSON
+func (m *Manifest) WriteJSON(w io.Writer) error {
+	m.sort()
+	files := m.Files
+	if files == nil {
+		files = []ManifestEntry{}
+	}
+	data, err := json.MarshalIndent(Manifest{Files: files}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteSHA256Sums writes one "<sha256>  <name>" line per file, the format read
+// by sha256sum -c. A file mirrored to several providers is listed once, and
+// entries without a digest are left out.
+func (m *Manifest) WriteSHA256Sums(w io.Writer) error {
+	m.sort()
+	seen := make(map[string]bool)
+	for _, entry := range m.Files {
+		if entry.SHA256 == "" || seen[entry.Name] {
+			continue
+		}
+		seen[entry.Name] = true
+		if _, err := fmt.Fprintf(w, "%s  %s\n", entry.SHA256, entry.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ManifestHandler decorates a handler and writes a manifest of the successful
+// uploads to a file once the run summary arrives
+type ManifestHandler struct {
+	Handler
+	mu       sync.Mutex
+	path     string
+	format   string
+	manifest Manifest
+}
+
+// NewManifestHandler wraps inner to write a manifest in format (json or
+// sha256sums) to path at the end of the run
+func NewManifestHandler(inner Handler, path, format string) (*ManifestHandler, error) {
+	format = strings.ToLower(format)
+	if format != ManifestJSON && format != ManifestSHA256Sums {
+		return nil, fmt.Errorf("unsupported manifest format %q (expected %s or %s)", format, ManifestJSON, ManifestSHA256Sums)
+	}
+	return &ManifestHandler{Handler: inner, path: path, format: format}, nil
+}
+
+// HandleResult records the result and forwards it
+func (m *ManifestHandler) HandleResult(result uploader.UploadResult) error {
+	m.mu.Lock()
+	m.manifest.Add(result)
+	m.mu.Unlock()
+	return m.Handler.HandleResult(result)
+}
+
+// HandleSummary forwards the summary, then writes the manifest
+func (m *ManifestHandler) HandleSummary(summary uploader.Summary) error {
+	if sh, ok := m.Handler.(SummaryHandler); ok {
+		if err := sh.HandleSummary(summary); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var buf bytes.Buffer
+	var err error
+	if m.format == ManifestSHA256Sums {
+		err = m.manifest.WriteSHA256Sums(&buf)
+	} else {
+		err = m.manifest.WriteJSON(&buf)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render manifest: %w", err)
+	}
+	if err := os.WriteFile(m.path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
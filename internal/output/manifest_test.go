@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// manifestResults returns a run where b.txt was mirrored and c.txt failed
+func manifestResults() []uploader.UploadResult {
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []uploader.UploadResult{
+		{FileName: "b.txt", Size: 20, Checksum: "bbbb", Provider: "Uguu", URL: "https://uguu.example/b", Expires: &expires},
+		{FileName: "a.txt", Size: 10, Checksum: "aaaa", Provider: "Catbox", URL: "https://catbox.example/a"},
+		{FileName: "c.txt", Size: 30, Checksum: "cccc", Provider: "Catbox", Error: errors.New("denied")},
+		{FileName: "b.txt", Size: 20, Checksum: "bbbb", Provider: "Catbox", URL: "https://catbox.example/b"},
+	}
+}
+
+func writeManifest(t *testing.T, format string) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest")
+	handler, err := NewManifestHandler(NewTextHandler(&bytes.Buffer{}), path, format)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range manifestResults() {
+		handler.HandleResult(result)
+	}
+	if err := handler.HandleSummary(uploader.Summary{Succeeded: 3, Failed: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("manifest was not written: %v", err)
+	}
+	return data
+}
+
+func TestManifestHandler_JSON(t *testing.T) {
+	var manifest Manifest
+	if err := json.Unmarshal(writeManifest(t, ManifestJSON), &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	expected := []ManifestEntry{
+		{Name: "a.txt", Size: 10, SHA256: "aaaa", Provider: "Catbox", URL: "https://catbox.example/a"},
+		{Name: "b.txt", Size: 20, SHA256: "bbbb", Provider: "Catbox", URL: "https://catbox.example/b"},
+		{Name: "b.txt", Size: 20, SHA256: "bbbb", Provider: "Uguu", URL: "https://uguu.example/b", Expires: "2030-01-02T03:04:05Z"},
+	}
+	if len(manifest.Files) != len(expected) {
+		t.Fatalf("expected %d entries, got %+v", len(expected), manifest.Files)
+	}
+	for i, entry := range expected {
+		got := manifest.Files[i]
+		if got.Name != entry.Name || got.Size != entry.Size || got.SHA256 != entry.SHA256 || got.Provider != entry.Provider || got.URL != entry.URL {
+			t.Errorf("entry %d: expected %+v, got %+v", i, entry, got)
+		}
+		if (got.Expires == "") != (entry.Expires == "") {
+			t.Errorf("entry %d: expected expiry %q, got %q", i, entry.Expires, got.Expires)
+		}
+	}
+}
+
+func TestManifestHandler_SHA256Sums(t *testing.T) {
+	if got, expected := string(writeManifest(t, ManifestSHA256Sums)), "aaaa  a.txt\nbbbb  b.txt\n"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestNewManifestHandler_RejectsUnknownFormat(t *testing.T) {
+	if _, err := NewManifestHandler(NewTextHandler(&bytes.Buffer{}), "manifest", "xml"); err == nil {
+		t.Error("expected an error for an unsupported manifest format")
+	}
+}
@@ -0,0 +1,115 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// markdownHeader names the table columns
+var markdownHeader = []string{"File", "Size", "Provider", "Link"}
+
+// MarkdownHandler implements Handler by rendering results as a GitHub-flavored
+// Markdown table. Rows are buffered and the table is written once, when the
+// summary arrives or on Close, so every column can be padded to its widest cell.
+type MarkdownHandler struct {
+	mu      sync.Mutex
+	output  io.Writer
+	rows    [][]string
+	written bool
+}
+
+// NewMarkdownHandler creates a new Markdown handler
+func NewMarkdownHandler(w io.Writer) *MarkdownHandler {
+	return &MarkdownHandler{output: w}
+}
+
+// HandleResult buffers a table row for the result
+func (m *MarkdownHandler) HandleResult(result uploader.UploadResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	link := fmt.Sprintf("[link](%s)", result.URL)
+	if result.Error != nil {
+		link = "_" + escapeMarkdownCell(result.Error.Error()) + "_"
+	}
+	m.rows = append(m.rows, []string{
+		escapeMarkdownCell(result.FileName),
+		FormatBytes(result.Size),
+		escapeMarkdownCell(result.Provider),
+		link,
+	})
+	return nil
+}
+
+// HandleProgress is a no-op; a table has no place for progress
+func (m *MarkdownHandler) HandleProgress(progress uploader.ProgressInfo) error {
+	return nil
+}
+
+// HandleSummary writes the table at the end of the run
+func (m *MarkdownHandler) HandleSummary(summary uploader.Summary) error {
+	return m.writeTable()
+}
+
+// Close writes the table unless the summary already did
+func (m *MarkdownHandler) Close() error {
+	return m.writeTable()
+}
+
+// writeTable renders the buffered rows with padded columns, once
+func (m *MarkdownHandler) writeTable() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.written {
+		return nil
+	}
+	m.written = true
+
+	widths := make([]int, len(markdownHeader))
+	for _, row := range append([][]string{markdownHeader}, m.rows...) {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	separator := make([]string, len(widths))
+	for i, width := range widths {
+		separator[i] = strings.Repeat("-", width)
+	}
+
+	var b strings.Builder
+	writeMarkdownRow(&b, markdownHeader, widths)
+	writeMarkdownRow(&b, separator, widths)
+	for _, row := range m.rows {
+		writeMarkdownRow(&b, row, widths)
+	}
+
+	_, err := io.WriteString(m.output, b.String())
+	return err
+}
+
+// writeMarkdownRow writes one table row with each cell padded to its column width
+func writeMarkdownRow(b *strings.Builder, cells []string, widths []int) {
+	b.WriteString("|")
+	for i, cell := range cells {
+		b.WriteString(" ")
+		b.WriteString(cell)
+		b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
+
+// escapeMarkdownCell keeps cell content from ending the cell or the row
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
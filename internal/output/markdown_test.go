@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+func TestMarkdownHandler_Golden(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewMarkdownHandler(buf)
+
+	handler.HandleResult(uploader.UploadResult{FileName: "report.pdf", Size: 2 * 1024 * 1024, Provider: "GoFile", URL: "https://gofile.io/d/abc"})
+	handler.HandleResult(uploader.UploadResult{FileName: "a|b.txt", Size: 12, Provider: "Catbox", URL: "https://files.catbox.moe/x.txt"})
+	handler.HandleResult(uploader.UploadResult{FileName: "huge.iso", Size: 3 * 1024 * 1024 * 1024, Provider: "Uguu", Error: errors.New("file too large:\nlimit is 128 MiB")})
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "report.pdf", BytesUploaded: 1, TotalBytes: 2})
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing before the table is complete, got %q", buf.String())
+	}
+	if err := handler.HandleSummary(uploader.Summary{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := handler.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "markdown.golden")
+	if *updateGolden {
+		if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if buf.String() != string(expected) {
+		t.Errorf("table does not match %s\ngot:\n%s\nwant:\n%s", golden, buf.String(), expected)
+	}
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/qrcode"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// qrQuietZone is the light border around the code that scanners need
+const qrQuietZone = 4
+
+// QRRenderer draws QR codes as text, packing two module rows into each line
+// with half-block characters. Light modules are drawn and dark ones left
+// blank, so the code reads dark on light in a terminal with a dark background.
+type QRRenderer struct {
+	output io.Writer
+}
+
+// NewQRRenderer creates a renderer writing to w
+func NewQRRenderer(w io.Writer) *QRRenderer {
+	return &QRRenderer{output: w}
+}
+
+// Render encodes text and writes it as a QR code
+func (r *QRRenderer) Render(text string) error {
+	code, err := qrcode.Encode(text)
+	if err != nil {
+		return err
+	}
+
+	light := func(x, y int) bool {
+		x -= qrQuietZone
+		y -= qrQuietZone
+		if x < 0 || x >= code.Size || y < 0 || y >= code.Size {
+			return true
+		}
+		return !code.Modules[y][x]
+	}
+
+	width := code.Size + 2*qrQuietZone
+	var sb strings.Builder
+	for y := 0; y < width; y += 2 {
+		for x := 0; x < width; x++ {
+			top := light(x, y)
+			bottom := y+1 < width && light(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	_, err = io.WriteString(r.output, sb.String())
+	return err
+}
+
+// qrLink is a successful upload waiting to be rendered
+type qrLink struct {
+	name string
+	url  string
+}
+
+// QRHandler decorates a handler and renders the URL of every successful
+// upload as a QR code once the run summary arrives. With several links each
+// code is preceded by its file name.
+type QRHandler struct {
+	Handler
+	mu       sync.Mutex
+	renderer *QRRenderer
+	links    []qrLink
+}
+
+// NewQRHandler wraps inner, rendering codes with renderer
+func NewQRHandler(inner Handler, renderer *QRRenderer) *QRHandler {
+	return &QRHandler{Handler: inner, renderer: renderer}
+}
+
+// HandleResult remembers the URL of a successful upload and forwards the result
+func (q *QRHandler) HandleResult(result uploader.UploadResult) error {
+	if result.Error == nil && result.URL != "" {
+		q.mu.Lock()
+		q.links = append(q.links, qrLink{name: result.FileName, url: result.URL})
+		q.mu.Unlock()
+	}
+	return q.Handler.HandleResult(result)
+}
+
+// HandleSummary forwards the summary, then renders the collected links
+func (q *QRHandler) HandleSummary(summary uploader.Summary) error {
+	if sh, ok := q.Handler.(SummaryHandler); ok {
+		if err := sh.HandleSummary(summary); err != nil {
+			return err
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, link := range q.links {
+		if len(q.links) > 1 {
+			if _, err := fmt.Fprintf(q.renderer.output, "%s\n", link.name); err != nil {
+				return err
+			}
+		}
+		if err := q.renderer.Render(link.url); err != nil {
+			return fmt.Errorf("failed to render QR code for %s: %w", link.name, err)
+		}
+	}
+	return nil
+}
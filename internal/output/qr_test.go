@@ -0,0 +1,113 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/qrcode"
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// parseQR turns rendered half-block lines back into a module matrix, dropping the quiet zone
+func parseQR(t *testing.T, rendered string) [][]bool {
+	t.Helper()
+	var rows [][]bool
+	for _, line := range strings.Split(strings.TrimSuffix(rendered, "\n"), "\n") {
+		var top, bottom []bool
+		for _, r := range line {
+			switch r {
+			case '█':
+				top, bottom = append(top, false), append(bottom, false)
+			case '▀':
+				top, bottom = append(top, false), append(bottom, true)
+			case '▄':
+				top, bottom = append(top, true), append(bottom, false)
+			case ' ':
+				top, bottom = append(top, true), append(bottom, true)
+			default:
+				t.Fatalf("unexpected character %q in QR output", r)
+			}
+		}
+		rows = append(rows, top, bottom)
+	}
+
+	width := len(rows[0])
+	rows = rows[:width] // The last line carries a padding row when the width is odd
+	var modules [][]bool
+	for _, row := range rows[qrQuietZone : width-qrQuietZone] {
+		modules = append(modules, row[qrQuietZone:width-qrQuietZone])
+	}
+	return modules
+}
+
+func TestQRRenderer_MatchesEncodedModules(t *testing.T) {
+	url := "https://example.com/f/abc"
+	buf := &bytes.Buffer{}
+	if err := NewQRRenderer(buf).Render(url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	code, err := qrcode.Encode(url)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	modules := parseQR(t, buf.String())
+	if len(modules) != code.Size {
+		t.Fatalf("expected %d module rows, got %d", code.Size, len(modules))
+	}
+	for y := range modules {
+		for x := range modules[y] {
+			if modules[y][x] != code.Modules[y][x] {
+				t.Fatalf("module (%d, %d) differs from the encoded code", x, y)
+			}
+		}
+	}
+}
+
+func TestQRHandler_RendersOneCodePerURL(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		results []uploader.UploadResult
+		headers []string
+	}{
+		{
+			name:    "single",
+			results: []uploader.UploadResult{{FileName: "a.txt", URL: "https://example.com/a"}},
+		},
+		{
+			name: "multiple",
+			results: []uploader.UploadResult{
+				{FileName: "a.txt", URL: "https://example.com/a"},
+				{FileName: "b.txt", Error: errors.New("denied")},
+				{FileName: "c.txt", URL: "https://example.com/c"},
+			},
+			headers: []string{"a.txt\n", "c.txt\n"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			handler := NewQRHandler(NewTextHandler(&bytes.Buffer{}), NewQRRenderer(buf))
+			for _, result := range tt.results {
+				handler.HandleResult(result)
+			}
+			if err := handler.HandleSummary(uploader.Summary{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			out := buf.String()
+			for _, header := range tt.headers {
+				if !strings.Contains(out, header) {
+					t.Errorf("expected file name %q before its code", header)
+				}
+			}
+			if strings.Contains(out, "b.txt") {
+				t.Error("failed uploads should not get a QR code")
+			}
+			if len(tt.headers) == 0 && strings.Contains(out, "a.txt") {
+				t.Error("a single code should not be labelled")
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// TemplateHandler implements Handler by executing a text/template once per
+// result. The template sees the uploader.UploadResult, e.g. {{.FileName}},
+// {{.URL}}, {{.Provider}} or {{.Size}}; a newline is added after each result
+// unless the template ends with one.
+type TemplateHandler struct {
+	mu       sync.Mutex
+	output   *bufio.Writer
+	template *template.Template
+}
+
+// NewTemplateHandler parses text and returns a handler writing to w
+func NewTemplateHandler(w io.Writer, text string) (*TemplateHandler, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("output format template requires a template, set --output-template")
+	}
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return &TemplateHandler{
+		output:   bufio.NewWriter(w),
+		template: tmpl,
+	}, nil
+}
+
+// HandleResult renders the template for the result
+func (t *TemplateHandler) HandleResult(result uploader.UploadResult) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b strings.Builder
+	if err := t.template.Execute(&b, result); err != nil {
+		return fmt.Errorf("failed to render output template: %w", err)
+	}
+	rendered := b.String()
+	if !strings.HasSuffix(rendered, "\n") {
+		rendered += "\n"
+	}
+	t.output.WriteString(rendered)
+	return t.output.Flush()
+}
+
+// HandleProgress is a no-op; templates render results only
+func (t *TemplateHandler) HandleProgress(progress uploader.ProgressInfo) error {
+	return nil
+}
+
+// Close flushes anything still buffered
+func (t *TemplateHandler) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.output.Flush()
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestTemplateHandler_RendersEachResult(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler, err := NewTemplateHandler(buf, "{{.FileName}} {{.URL}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a"})
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "b.txt", BytesUploaded: 1, TotalBytes: 2})
+	handler.HandleResult(uploader.UploadResult{FileName: "b.txt", URL: "https://example.com/b"})
+	handler.Close()
+
+	expected := "a.txt https://example.com/a\nb.txt https://example.com/b\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestTemplateHandler_KeepsTrailingNewline(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler, err := NewTemplateHandler(buf, "{{.Provider}}:{{.Size}}\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler.HandleResult(uploader.UploadResult{Provider: "GoFile", Size: 42})
+
+	if buf.String() != "GoFile:42\n" {
+		t.Errorf("expected a single newline, got %q", buf.String())
+	}
+}
+
+func TestNewHandlerWithConfig_TemplateErrors(t *testing.T) {
+	if _, err := NewHandlerWithConfig("template", HandlerConfig{}); err == nil || !strings.Contains(err.Error(), "--output-template") {
+		t.Errorf("expected a missing template error, got %v", err)
+	}
+	if _, err := NewHandlerWithConfig("template", HandlerConfig{Template: "{{.FileName"}); err == nil || !strings.Contains(err.Error(), "invalid output template") {
+		t.Errorf("expected an invalid template error, got %v", err)
+	}
+	if _, err := NewHandlerWithConfig("template", HandlerConfig{Template: "{{.URL}}"}); err != nil {
+		t.Errorf("unexpected error for a valid template: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// TotalSnapshot is the aggregate progress of a run across all files
+type TotalSnapshot struct {
+	Planned      bool // A RunPlan arrived, so PlannedFiles and PlannedBytes are known
+	PlannedFiles int
+	PlannedBytes int64
+	Files        int   // Files with a final result
+	Bytes        int64 // Bytes of finished files plus those sent so far by running ones
+}
+
+// Percent returns the share of the planned bytes transferred, from 0 to 100.
+// Once every planned file finished it is 100, even if some failed early.
+func (s TotalSnapshot) Percent() float64 {
+	if !s.Planned {
+		return 0
+	}
+	if s.PlannedFiles > 0 && s.Files >= s.PlannedFiles {
+		return 100
+	}
+	if s.PlannedBytes <= 0 {
+		if s.PlannedFiles == 0 {
+			return 0
+		}
+		return float64(s.Files) / float64(s.PlannedFiles) * 100
+	}
+	percent := float64(s.Bytes) / float64(s.PlannedBytes) * 100
+	if percent > 100 {
+		percent = 100
+	}
+	return percent
+}
+
+// TotalProgress adds up the progress of every file in a run against the
+// RunPlan announced by the uploader. It is safe for concurrent use.
+type TotalProgress struct {
+	mu        sync.Mutex
+	plan      *uploader.RunPlan
+	done      map[string]bool // File paths with a final result
+	doneBytes int64
+	inFlight  map[string]int64 // Bytes sent so far, by file name
+}
+
+// NewTotalProgress creates an empty TotalProgress
+func NewTotalProgress() *TotalProgress {
+	return &TotalProgress{
+		done:     make(map[string]bool),
+		inFlight: make(map[string]int64),
+	}
+}
+
+// OnProgress records the run's plan or the latest byte count of a file
+func (p *TotalProgress) OnProgress(info uploader.ProgressInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if info.Plan != nil {
+		plan := *info.Plan
+		p.plan = &plan
+		return
+	}
+	p.inFlight[info.FileName] = info.BytesUploaded
+}
+
+// AddResult records a final result; with several providers per file only
+// the first result of a file counts
+func (p *TotalProgress) AddResult(result uploader.UploadResult) {
+	p.addFile(result.FilePath, result.FileName, result.Size)
+}
+
+// AddGroup records a file whose grouped results were all collected
+func (p *TotalProgress) AddGroup(group FileGroup) {
+	p.addFile(group.File, group.Name, group.Size)
+}
+
+func (p *TotalProgress) addFile(path, name string, size int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.inFlight, name)
+	if path == "" || p.done[path] {
+		return // Scan errors name no file
+	}
+	p.done[path] = true
+	if size > 0 {
+		p.doneBytes += size
+	}
+}
+
+// Snapshot returns the current totals
+func (p *TotalProgress) Snapshot() TotalSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snapshot := TotalSnapshot{
+		Files: len(p.done),
+		Bytes: p.doneBytes,
+	}
+	for _, n := range p.inFlight {
+		snapshot.Bytes += n
+	}
+	if p.plan != nil {
+		snapshot.Planned = true
+		snapshot.PlannedFiles = p.plan.Files
+		snapshot.PlannedBytes = p.plan.Bytes
+	}
+	return snapshot
+}
+
+// formatTotalBar renders the aggregate bar, e.g.
+// "[=====     ] 3/10 files, 42.0% total (1.2 MiB/3.0 MiB)"
+func formatTotalBar(s TotalSnapshot) string {
+	if !s.Planned {
+		return fmt.Sprintf("[%s] %d files, %s", progressBar(0), s.Files, FormatBytes(s.Bytes))
+	}
+	percent := s.Percent()
+	return fmt.Sprintf("[%s] %d/%d files, %.1f%% total (%s/%s)",
+		progressBar(percent),
+		s.Files,
+		s.PlannedFiles,
+		percent,
+		FormatBytes(s.Bytes),
+		FormatBytes(s.PlannedBytes),
+	)
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestTotalProgress_AddsUpFiles(t *testing.T) {
+	total := NewTotalProgress()
+	total.OnProgress(uploader.ProgressInfo{Plan: &uploader.RunPlan{Files: 4, Bytes: 1000}})
+
+	// Two files finish, one of them reported by two mirrored providers
+	total.AddResult(uploader.UploadResult{FilePath: "/a", FileName: "a", Size: 100, Provider: "one"})
+	total.AddResult(uploader.UploadResult{FilePath: "/a", FileName: "a", Size: 100, Provider: "two"})
+	total.AddResult(uploader.UploadResult{FilePath: "/b", FileName: "b", Size: 300})
+	// Two are still running
+	total.OnProgress(uploader.ProgressInfo{FileName: "c", BytesUploaded: 150, TotalBytes: 200})
+	total.OnProgress(uploader.ProgressInfo{FileName: "d", BytesUploaded: 50, TotalBytes: 400})
+	total.OnProgress(uploader.ProgressInfo{FileName: "d", BytesUploaded: 200, TotalBytes: 400})
+
+	snapshot := total.Snapshot()
+	if snapshot.Files != 2 || snapshot.PlannedFiles != 4 {
+		t.Errorf("expected 2 of 4 files, got %d of %d", snapshot.Files, snapshot.PlannedFiles)
+	}
+	if snapshot.Bytes != 750 {
+		t.Errorf("expected 100+300 finished and 150+200 running bytes, got %d", snapshot.Bytes)
+	}
+	if percent := snapshot.Percent(); percent != 75 {
+		t.Errorf("expected 75%%, got %v", percent)
+	}
+
+	// A failed file counts as done, and finishing everything reads 100%
+	total.AddResult(uploader.UploadResult{FilePath: "/c", FileName: "c", Size: 200})
+	total.AddResult(uploader.UploadResult{FilePath: "/d", FileName: "d", Error: errors.New("denied")})
+	snapshot = total.Snapshot()
+	if snapshot.Files != 4 || snapshot.Bytes != 600 || snapshot.Percent() != 100 {
+		t.Errorf("expected a complete run, got %+v at %v%%", snapshot, snapshot.Percent())
+	}
+}
+
+func TestTotalSnapshot_Percent(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot TotalSnapshot
+		expected float64
+	}{
+		{"no plan yet", TotalSnapshot{Files: 1, Bytes: 10}, 0},
+		{"by bytes", TotalSnapshot{Planned: true, PlannedFiles: 3, PlannedBytes: 400, Files: 1, Bytes: 100}, 25},
+		{"empty files by count", TotalSnapshot{Planned: true, PlannedFiles: 4, Files: 1}, 25},
+		{"capped", TotalSnapshot{Planned: true, PlannedFiles: 2, PlannedBytes: 100, Files: 1, Bytes: 150}, 100},
+		{"empty run", TotalSnapshot{Planned: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.snapshot.Percent(); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTotalTextHandler_KeepsBarBelowResults(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewTotalTextHandler(buf)
+
+	handler.HandleProgress(uploader.ProgressInfo{Plan: &uploader.RunPlan{Files: 2, Bytes: 2048}})
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "a.bin", BytesUploaded: 512, TotalBytes: 1024})
+	if !strings.HasSuffix(buf.String(), "0/2 files, 25.0% total (512 B/2.0 KiB)"+ansiClearLine) {
+		t.Errorf("expected the aggregate bar, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "a.bin") {
+		t.Errorf("expected no per-file bar, got %q", buf.String())
+	}
+
+	buf.Reset()
+	handler.HandleResult(uploader.UploadResult{FilePath: "/a.bin", FileName: "a.bin", Size: 1024, URL: "https://example.com/a", Provider: "test"})
+	lines := strings.SplitN(buf.String(), "\n", 2)
+	if !strings.HasPrefix(lines[0], "\r"+ansiClearLine+"SUCCESS a.bin") {
+		t.Errorf("expected the bar cleared before the result, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "1/2 files, 50.0% total") {
+		t.Errorf("expected the bar redrawn below the result, got %q", lines[1])
+	}
+
+	buf.Reset()
+	handler.Close()
+	if buf.String() != "\n" {
+		t.Errorf("expected close to end the bar's line, got %q", buf.String())
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+// URLsHandler implements Handler for piping: the URL of each successful upload
+// goes to the output, one per line, and everything else goes to the error
+// writer so scripts reading the output only ever see links.
+type URLsHandler struct {
+	mu     sync.Mutex
+	output io.Writer
+	errors io.Writer
+}
+
+// NewURLsHandler creates a handler writing URLs to w and failures to errW
+func NewURLsHandler(w, errW io.Writer) *URLsHandler {
+	return &URLsHandler{output: w, errors: errW}
+}
+
+// HandleResult prints the URL of a successful upload or reports the failure
+func (u *URLsHandler) HandleResult(result uploader.UploadResult) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	switch {
+	case result.Cancelled:
+		_, err := fmt.Fprintf(u.errors, "CANCELLED %s: %v\n", result.FileName, result.Error)
+		return err
+	case result.Skipped:
+		_, err := fmt.Fprintf(u.errors, "SKIPPED %s: %v\n", result.FileName, result.Error)
+		return err
+	case result.Error != nil:
+		_, err := fmt.Fprintf(u.errors, "ERROR %s: %v\n", result.FileName, result.Error)
+		return err
+	}
+
+	_, err := fmt.Fprintln(u.output, result.URL)
+	return err
+}
+
+// HandleProgress is a no-op; progress would mix with the URLs
+func (u *URLsHandler) HandleProgress(progress uploader.ProgressInfo) error {
+	return nil
+}
+
+// Close has nothing to flush
+func (u *URLsHandler) Close() error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/uploader"
+)
+
+func TestURLsHandler_SeparatesURLsAndErrors(t *testing.T) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	handler := NewURLsHandler(out, errOut)
+
+	handler.HandleResult(uploader.UploadResult{FileName: "a.txt", URL: "https://example.com/a", Provider: "test"})
+	handler.HandleProgress(uploader.ProgressInfo{FileName: "b.txt", BytesUploaded: 1, TotalBytes: 2})
+	handler.HandleResult(uploader.UploadResult{FileName: "b.txt", Provider: "test", Error: errors.New("denied")})
+	handler.HandleResult(uploader.UploadResult{FileName: "c.txt", Skipped: true, Error: errors.New("budget")})
+	handler.HandleResult(uploader.UploadResult{FileName: "d.txt", URL: "https://example.com/d", Provider: "test"})
+
+	if expected := "https://example.com/a\nhttps://example.com/d\n"; out.String() != expected {
+		t.Errorf("expected only URLs on stdout, got %q", out.String())
+	}
+	if expected := "ERROR b.txt: denied\nSKIPPED c.txt: budget\n"; errOut.String() != expected {
+		t.Errorf("expected failures on the error writer, got %q", errOut.String())
+	}
+}
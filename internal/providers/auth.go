@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"encoding/base64"
+	"net/http"
+)
+
+// DefaultAPIKeyHeader carries the API key when api_key_header is not set
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// Auth holds the credentials a provider reads from its settings. Every field
+// is optional; providers apply whichever ones their service understands.
+type Auth struct {
+	APIKey       string
+	APIKeyHeader string
+	BearerToken  string
+	Username     string
+	Password     string
+}
+
+// ParseAuth reads the shared credential settings. Recognised settings:
+//   - api_key:        key sent in the api_key_header header
+//   - api_key_header: header carrying api_key (default X-API-Key)
+//   - bearer_token:   token sent as "Authorization: Bearer <token>"
+//   - username:       user name for HTTP basic authentication
+//   - password:       password for HTTP basic authentication
+func ParseAuth(settings map[string]interface{}) Auth {
+	auth := Auth{APIKeyHeader: DefaultAPIKeyHeader}
+	auth.APIKey, _ = settings["api_key"].(string)
+	if header, ok := settings["api_key_header"].(string); ok && header != "" {
+		auth.APIKeyHeader = header
+	}
+	auth.BearerToken, _ = settings["bearer_token"].(string)
+	auth.Username, _ = settings["username"].(string)
+	auth.Password, _ = settings["password"].(string)
+	return auth
+}
+
+// IsZero reports whether no credentials are configured
+func (a Auth) IsZero() bool {
+	return a.APIKey == "" && a.BearerToken == "" && a.Username == "" && a.Password == ""
+}
+
+// Apply sets the configured credentials on req. A bearer token takes the
+// Authorization header over basic authentication; the API key always goes
+// in its own header.
+func (a Auth) Apply(req *http.Request) {
+	for key, value := range a.Headers() {
+		req.Header.Set(key, value)
+	}
+}
+
+// Headers returns the configured credentials as request headers, as Apply
+// sets them, for requests made through BaseProvider.MakeRequest
+func (a Auth) Headers() map[string]string {
+	headers := make(map[string]string)
+	if a.APIKey != "" {
+		header := a.APIKeyHeader
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+		headers[header] = a.APIKey
+	}
+	switch {
+	case a.BearerToken != "":
+		headers["Authorization"] = "Bearer " + a.BearerToken
+	case a.Username != "" || a.Password != "":
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+	}
+	return headers
+}
@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseAuth(t *testing.T) {
+	auth := ParseAuth(map[string]interface{}{
+		"api_key":        "key",
+		"api_key_header": "X-Upload-Key",
+		"bearer_token":   "token",
+		"username":       "alice",
+		"password":       "secret",
+		"timeout":        "5s",
+	})
+	expected := Auth{APIKey: "key", APIKeyHeader: "X-Upload-Key", BearerToken: "token", Username: "alice", Password: "secret"}
+	if auth != expected {
+		t.Errorf("expected %+v, got %+v", expected, auth)
+	}
+
+	empty := ParseAuth(map[string]interface{}{"api_key": 42})
+	if !empty.IsZero() || empty.APIKeyHeader != DefaultAPIKeyHeader {
+		t.Errorf("expected no credentials and the default header, got %+v", empty)
+	}
+}
+
+func TestAuth_Apply(t *testing.T) {
+	tests := []struct {
+		name          string
+		auth          Auth
+		authorization string
+		apiKey        string
+	}{
+		{"none", Auth{}, "", ""},
+		{"api key default header", Auth{APIKey: "key"}, "", "key"},
+		{"bearer", Auth{BearerToken: "token"}, "Bearer token", ""},
+		{"basic", Auth{Username: "alice", Password: "secret"}, "Basic YWxpY2U6c2VjcmV0", ""},
+		{"bearer wins over basic", Auth{BearerToken: "token", Username: "alice"}, "Bearer token", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPut, "https://example.com/file", nil)
+			tt.auth.Apply(req)
+			if got := req.Header.Get("Authorization"); got != tt.authorization {
+				t.Errorf("Authorization = %q, want %q", got, tt.authorization)
+			}
+			if got := req.Header.Get(DefaultAPIKeyHeader); got != tt.apiKey {
+				t.Errorf("%s = %q, want %q", DefaultAPIKeyHeader, got, tt.apiKey)
+			}
+		})
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, "https://example.com/file", nil)
+	Auth{APIKey: "key", APIKeyHeader: "X-Upload-Key"}.Apply(req)
+	if req.Header.Get("X-Upload-Key") != "key" || req.Header.Get(DefaultAPIKeyHeader) != "" {
+		t.Errorf("expected the key in the configured header only, got %v", req.Header)
+	}
+}
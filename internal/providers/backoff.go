@@ -0,0 +1,66 @@
+package providers
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// BackoffStrategy selects how the delay between retries grows
+type BackoffStrategy string
+
+const (
+	BackoffConstant    BackoffStrategy = "constant"    // Same delay before every retry
+	BackoffLinear      BackoffStrategy = "linear"      // Delay grows by a fixed step each retry
+	BackoffExponential BackoffStrategy = "exponential" // Delay is multiplied each retry
+)
+
+// ParseBackoffStrategy converts a user supplied name into a BackoffStrategy
+func ParseBackoffStrategy(name string) (BackoffStrategy, error) {
+	switch strategy := BackoffStrategy(strings.ToLower(strings.TrimSpace(name))); strategy {
+	case BackoffConstant, BackoffLinear, BackoffExponential:
+		return strategy, nil
+	case "":
+		return BackoffExponential, nil
+	default:
+		return "", fmt.Errorf("unknown backoff strategy %q (expected constant, linear or exponential)", name)
+	}
+}
+
+// BackoffDelay returns the un-jittered delay before the given retry (1 for the first retry).
+//   - constant:    base
+//   - linear:      base + (retry-1) * base * factor   (factor defaults to 1)
+//   - exponential: base * factor^(retry-1)            (factor defaults to 2)
+//
+// A factor of 0 or less uses the strategy's default. A positive maxDelay caps
+// the result.
+func BackoffDelay(strategy BackoffStrategy, base time.Duration, factor float64, maxDelay time.Duration, retry int) time.Duration {
+	if retry < 1 || base <= 0 {
+		return 0
+	}
+
+	var delay float64
+	switch strategy {
+	case BackoffConstant:
+		delay = float64(base)
+	case BackoffLinear:
+		if factor <= 0 {
+			factor = 1
+		}
+		delay = float64(base) + float64(retry-1)*float64(base)*factor
+	default:
+		if factor <= 0 {
+			factor = 2
+		}
+		delay = float64(base) * math.Pow(factor, float64(retry-1))
+	}
+
+	if maxDelay > 0 && delay > float64(maxDelay) {
+		return maxDelay
+	}
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
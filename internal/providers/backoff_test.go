@@ -0,0 +1,154 @@
+package providers
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay_Sequences(t *testing.T) {
+	base := time.Second
+	tests := []struct {
+		name     string
+		strategy BackoffStrategy
+		factor   float64
+		maxDelay time.Duration
+		expected []time.Duration
+	}{
+		{
+			name:     "constant",
+			strategy: BackoffConstant,
+			expected: []time.Duration{time.Second, time.Second, time.Second, time.Second},
+		},
+		{
+			name:     "linear default step",
+			strategy: BackoffLinear,
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second},
+		},
+		{
+			name:     "linear half step",
+			strategy: BackoffLinear,
+			factor:   0.5,
+			expected: []time.Duration{1000 * time.Millisecond, 1500 * time.Millisecond, 2000 * time.Millisecond, 2500 * time.Millisecond},
+		},
+		{
+			name:     "exponential default factor",
+			strategy: BackoffExponential,
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			name:     "exponential factor 3 capped",
+			strategy: BackoffExponential,
+			factor:   3,
+			maxDelay: 5 * time.Second,
+			expected: []time.Duration{1 * time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delays []time.Duration
+			for retry := 1; retry <= len(tt.expected); retry++ {
+				delays = append(delays, BackoffDelay(tt.strategy, base, tt.factor, tt.maxDelay, retry))
+			}
+			if !reflect.DeepEqual(delays, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, delays)
+			}
+		})
+	}
+}
+
+func TestParseBackoffStrategy(t *testing.T) {
+	for input, expected := range map[string]BackoffStrategy{
+		"":            BackoffExponential,
+		"constant":    BackoffConstant,
+		"Linear":      BackoffLinear,
+		"exponential": BackoffExponential,
+	} {
+		strategy, err := ParseBackoffStrategy(input)
+		if err != nil {
+			t.Errorf("ParseBackoffStrategy(%q) unexpected error: %v", input, err)
+		}
+		if strategy != expected {
+			t.Errorf("ParseBackoffStrategy(%q) = %q, want %q", input, strategy, expected)
+		}
+	}
+
+	if _, err := ParseBackoffStrategy("fibonacci"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestConsistencyWrapper_RetryDelayJitterBounds(t *testing.T) {
+	config := DefaultWrapperConfig()
+	config.RetryDelay = 100 * time.Millisecond
+	cw := NewConsistencyWrapper(nil, config)
+
+	for retry := 1; retry <= 4; retry++ {
+		upper := BackoffDelay(config.Backoff, config.RetryDelay, config.BackoffFactor, config.MaxRetryDelay, retry)
+		for i := 0; i < 50; i++ {
+			delay := cw.retryDelay(retry)
+			if delay < 0 || delay >= upper {
+				t.Fatalf("retry %d: jittered delay %v outside [0, %v)", retry, delay, upper)
+			}
+		}
+	}
+}
+
+func TestConsistencyWrapper_SeededJitterIsReproducible(t *testing.T) {
+	config := DefaultWrapperConfig()
+	config.RetryDelay = 100 * time.Millisecond
+	cw := NewConsistencyWrapper(nil, config)
+	cw.SetJitterSource(rand.NewSource(42))
+
+	expected := []time.Duration{
+		31278675 * time.Nanosecond,
+		143856411 * time.Nanosecond,
+		101878760 * time.Nanosecond,
+		126624009 * time.Nanosecond,
+	}
+	for i, want := range expected {
+		if got := cw.retryDelay(i + 1); got != want {
+			t.Errorf("retry %d: expected delay %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestConsistencyWrapper_RetryDelayJitterRespectsCap(t *testing.T) {
+	config := DefaultWrapperConfig()
+	config.RetryDelay = time.Second
+	config.MaxRetryDelay = 3 * time.Second
+	cw := NewConsistencyWrapper(nil, config)
+
+	for i := 0; i < 50; i++ {
+		if delay := cw.retryDelay(10); delay >= config.MaxRetryDelay {
+			t.Fatalf("jittered delay %v not below the %v cap", delay, config.MaxRetryDelay)
+		}
+	}
+}
+
+func TestConsistencyWrapper_DefaultFactorSuitsEachStrategy(t *testing.T) {
+	// The default factor must not carry the exponential growth of 2 over
+	// as the linear step, which would make linear delays 1x, 3x, 5x
+	config := DefaultWrapperConfig()
+	config.Jitter = false
+	config.RetryDelay = time.Second
+	config.MaxRetryDelay = 0
+
+	expected := map[BackoffStrategy][]time.Duration{
+		BackoffLinear:      {1 * time.Second, 2 * time.Second, 3 * time.Second},
+		BackoffExponential: {1 * time.Second, 2 * time.Second, 4 * time.Second},
+	}
+	for strategy, want := range expected {
+		config.Backoff = strategy
+		cw := NewConsistencyWrapper(nil, config)
+		var delays []time.Duration
+		for retry := 1; retry <= len(want); retry++ {
+			delays = append(delays, cw.retryDelay(retry))
+		}
+		if !reflect.DeepEqual(delays, want) {
+			t.Errorf("%s: expected %v, got %v", strategy, want, delays)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package providers
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,23 +15,22 @@ import (
 	"github.com/parnexcodes/woof/internal/logging"
 )
 
-// EnhancedProvider interface extends the base Provider with additional capabilities
-type EnhancedProvider interface {
-	Name() string
-	Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error)
-	ValidateFile(ctx context.Context, filePath string, size int64) error
-	GetMaxFileSize() int64
-	GetSupportedExtensions() []string
-	GetTimeout() time.Duration
-}
+var (
+	_ TimeoutProvider    = (*BaseProvider)(nil)
+	_ ConcurrencyLimiter = (*BaseProvider)(nil)
+	_ WeightedProvider   = (*BaseProvider)(nil)
+)
 
 // BaseProvider provides common functionality for all providers
 type BaseProvider struct {
-	name     string
-	client   *http.Client
-	timeout  time.Duration
-	maxSize  int64
+	name                string
+	client              *http.Client
+	timeout             time.Duration
+	maxSize             int64
 	supportedExtensions map[string]bool
+	signer              RequestSigner
+	maxConcurrency      int // 0 means no limit
+	weight              int // Share of the upload slots when racing, 0 means 1
 }
 
 // NewBaseProvider creates a new base provider with common configuration
@@ -39,19 +40,46 @@ func NewBaseProvider(name string, timeout time.Duration, maxSize int64, extensio
 		supportedExts[strings.ToLower(ext)] = true
 	}
 
-	client := &http.Client{
-		Timeout: timeout,
-	}
+	client := NewHTTPClient(timeout)
 
 	return &BaseProvider{
-		name:   name,
-		client: client,
-		timeout: timeout,
-		maxSize: maxSize,
+		name:                name,
+		client:              client,
+		timeout:             timeout,
+		maxSize:             maxSize,
 		supportedExtensions: supportedExts,
 	}
 }
 
+// NewBaseProviderFromSettings creates a base provider configured from a
+// provider's settings: the HTTP client options, the signing_* request signer,
+// allowed_extensions, max_concurrency and weight
+func NewBaseProviderFromSettings(name string, settings map[string]interface{}, timeout time.Duration, maxSize int64) (*BaseProvider, error) {
+	maxConcurrency, err := MaxConcurrencyFromSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := WeightFromSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	return &BaseProvider{
+		name:                name,
+		client:              NewHTTPClientFromSettings(settings, timeout),
+		timeout:             timeout,
+		maxSize:             maxSize,
+		supportedExtensions: ExtensionsFromSettings(settings),
+		signer:              NewSignerFromSettings(settings),
+		maxConcurrency:      maxConcurrency,
+		weight:              weight,
+	}, nil
+}
+
+// SetSigner configures a signer applied to every request made through MakeRequest
+func (bp *BaseProvider) SetSigner(signer RequestSigner) {
+	bp.signer = signer
+}
+
 // Name returns the provider name
 func (bp *BaseProvider) Name() string {
 	return bp.name
@@ -76,21 +104,37 @@ func (bp *BaseProvider) GetTimeout() time.Duration {
 	return bp.timeout
 }
 
+// MaxConcurrency returns the max_concurrency setting, 0 when unlimited
+func (bp *BaseProvider) MaxConcurrency() int {
+	return bp.maxConcurrency
+}
+
+// Weight returns the weight setting, the provider's share of the upload slots when racing
+func (bp *BaseProvider) Weight() int {
+	if bp.weight <= 0 {
+		return 1
+	}
+	return bp.weight
+}
+
+// SignsRequests reports whether a request signer is configured. Signed
+// requests need a body that can be hashed before it is sent.
+func (bp *BaseProvider) SignsRequests() bool {
+	return bp.signer != nil
+}
+
 // ValidateFile validates a file before upload
 func (bp *BaseProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
 	// Check file size
 	if bp.maxSize > 0 && size > bp.maxSize {
 		logFields := map[string]interface{}{
-			"provider":     bp.name,
-			"file_size":    size,
-			"max_size":     bp.maxSize,
-			"file_path":    filePath,
+			"provider":  bp.name,
+			"file_size": size,
+			"max_size":  bp.maxSize,
+			"file_path": filePath,
 		}
 		logging.ErrorContext("file_too_large", fmt.Errorf("file too large"), logFields)
-		return NewFileTooLargeError(
-			fmt.Sprintf("file size %d bytes exceeds maximum %d bytes", size, bp.maxSize),
-			nil,
-		)
+		return ErrFileTooLarge(size, bp.maxSize)
 	}
 
 	// Check file extension if restrictions exist
@@ -103,10 +147,10 @@ func (bp *BaseProvider) ValidateFile(ctx context.Context, filePath string, size
 			}
 
 			logFields := map[string]interface{}{
-				"provider":    bp.name,
-				"extension":   ext,
-				"supported":   supported,
-				"file_path":   filePath,
+				"provider":  bp.name,
+				"extension": ext,
+				"supported": supported,
+				"file_path": filePath,
 			}
 			logging.ErrorContext("unsupported_extension", fmt.Errorf("unsupported file extension"), logFields)
 			return NewUnsupportedError(
@@ -121,6 +165,19 @@ func (bp *BaseProvider) ValidateFile(ctx context.Context, filePath string, size
 
 // MakeRequest creates and executes an HTTP request with common headers and logging
 func (bp *BaseProvider) MakeRequest(ctx context.Context, method, url string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	// Signing needs the body's hash before the body is sent
+	var bodyHash []byte
+	if bp.signer != nil {
+		var err error
+		bodyHash, err = hashBody(body)
+		if errors.Is(err, errUnsignableBody) {
+			return nil, NewUnsupportedError("cannot sign a request whose body can only be read once", err)
+		}
+		if err != nil {
+			return nil, ErrFileRead(err)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		logging.ErrorContext("http_request_create", err, map[string]interface{}{
@@ -128,18 +185,18 @@ func (bp *BaseProvider) MakeRequest(ctx context.Context, method, url string, bod
 			"method":   method,
 			"url":      url,
 		})
-		return nil, NewNetworkError(
-			fmt.Sprintf("failed to create request: %s", method),
-			err,
-		)
+		return nil, ErrRequestCreate(err)
 	}
 
-	// Set headers
-	req.Header.Set("User-Agent", "woof/1.0")
+	// Set headers; the client adds the configured User-Agent
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
+	if err := SignRequest(bp.signer, req, bodyHash); err != nil {
+		return nil, err
+	}
+
 	// Log the request
 	logging.HTTPRequest(method, url, headers)
 
@@ -150,21 +207,21 @@ func (bp *BaseProvider) MakeRequest(ctx context.Context, method, url string, bod
 			"provider": bp.name,
 			"url":      url,
 		})
-		return nil, NewNetworkError(
-			fmt.Sprintf("request failed: %s", url),
-			err,
-		)
+		return nil, ErrRequestFailed(err)
 	}
 
 	return resp, nil
 }
 
-// ParseResponse parses a JSON response body into the target structure
-func (bp *BaseProvider) ParseResponse(resp *http.Response, target interface{}) ([]byte, error) {
+// ParseResponse reads and logs a response, then parses its JSON body into
+// target unless target is nil. duration is how long the request took, timed
+// by the caller around MakeRequest. The body is returned along with a status
+// error so callers can map it to a provider-specific error.
+func (bp *BaseProvider) ParseResponse(resp *http.Response, duration time.Duration, target interface{}) ([]byte, error) {
 	defer resp.Body.Close()
 
 	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := ReadResponseBody(resp)
 	if err != nil {
 		logging.ErrorContext("http_response_read", err, map[string]interface{}{
 			"provider":     bp.name,
@@ -175,31 +232,26 @@ func (bp *BaseProvider) ParseResponse(resp *http.Response, target interface{}) (
 	}
 
 	// Log the response
-	duration := time.Since(time.Now()) // This should be passed in from caller
 	logging.HTTPResponse(resp.StatusCode, string(body), duration)
 
 	// Check for non-success status codes
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		logging.ErrorContext("api_error", fmt.Errorf("API returned status %d", resp.StatusCode), map[string]interface{}{
-			"provider":     bp.name,
-			"status_code":  resp.StatusCode,
-			"response":     string(body),
+			"provider":    bp.name,
+			"status_code": resp.StatusCode,
+			"response":    string(body),
 		})
-		return body, NewAPIError(
-			fmt.Sprintf("%d", resp.StatusCode),
-			fmt.Sprintf("API returned status %d: %s", resp.StatusCode, string(body)),
-			nil,
-		)
+		return body, ErrAPIStatus(resp.StatusCode, string(body))
 	}
 
-	// Parse JSON body if target is provided
-	if target != nil && len(body) > 0 {
+	// Parse JSON body if target is provided; an empty body is a parse error
+	if target != nil {
 		if err := json.Unmarshal(body, target); err != nil {
 			logging.ErrorContext("json_parse", err, map[string]interface{}{
 				"provider": bp.name,
 				"response": string(body),
 			})
-			return body, NewAPIError("JSON_PARSE_ERROR", "failed to parse API response", err)
+			return body, ErrJSONParse(err)
 		}
 	}
 
@@ -209,7 +261,7 @@ func (bp *BaseProvider) ParseResponse(resp *http.Response, target interface{}) (
 // ValidateURL validates that a URL is not empty
 func (bp *BaseProvider) ValidateURL(url string) error {
 	if url == "" {
-		return NewAPIError("MISSING_URL", "provider response missing download URL", nil)
+		return ErrMissingURL()
 	}
 	return nil
 }
@@ -250,4 +302,44 @@ func (bp *BaseProvider) LogProviderError(operation string, err error, fields map
 	fields["provider"] = bp.name
 
 	logging.ErrorContext(operation, err, fields)
-}
\ No newline at end of file
+}
+
+// VerifyEchoedSize checks the size a server reports for a stored upload against
+// the bytes sent. A nil echoed size means the provider did not report one.
+func VerifyEchoedSize(echoed *int64, sent int64) error {
+	if echoed == nil || *echoed == sent {
+		return nil
+	}
+	return ErrShortBody(*echoed, sent)
+}
+
+// ResponseEndpoint returns the scheme and host that actually served a response,
+// following any redirects taken by the client
+func ResponseEndpoint(resp *http.Response) string {
+	if resp == nil || resp.Request == nil || resp.Request.URL == nil {
+		return ""
+	}
+	return resp.Request.URL.Scheme + "://" + resp.Request.URL.Host
+}
+
+// ReadResponseBody reads a whole response body, decompressing it when the
+// server sent Content-Encoding: gzip. Go's transport only does this itself when
+// it added Accept-Encoding, so an explicit header would otherwise leave the
+// compressed bytes for the JSON parser.
+func ReadResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Uncompressed || !strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		return io.ReadAll(resp.Body)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip response: %w", err)
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip response: %w", err)
+	}
+	return body, nil
+}
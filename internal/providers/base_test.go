@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+)
+
+func TestParseResponse_GzipEncodedJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"url":"https://example.com/f/abc"}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	bp := NewBaseProvider("test", 5*time.Second, 0, []string{"*"})
+	// An explicit Accept-Encoding stops the transport from decompressing by itself
+	resp, err := bp.MakeRequest(context.Background(), http.MethodGet, server.URL, nil, map[string]string{"Accept-Encoding": "gzip"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var target struct {
+		URL string `json:"url"`
+	}
+	if _, err := bp.ParseResponse(resp, time.Second, &target); err != nil {
+		t.Fatalf("failed to parse gzip response: %v", err)
+	}
+	if target.URL != "https://example.com/f/abc" {
+		t.Errorf("unexpected URL %q", target.URL)
+	}
+}
+
+func TestReadResponseBody_Plain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("plain"))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ReadResponseBody(resp)
+	if err != nil || string(body) != "plain" {
+		t.Errorf("expected the plain body, got %q, %v", body, err)
+	}
+}
+
+func TestParseResponse_LogsRequestDuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	logs := &bytes.Buffer{}
+	logging.Init(true, logs)
+	defer logging.Init(false, io.Discard)
+
+	bp := NewBaseProvider("test", 5*time.Second, 0, nil)
+	resp, err := bp.MakeRequest(context.Background(), http.MethodGet, server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := bp.ParseResponse(resp, 1500*time.Millisecond, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(logs.String(), `"duration_ms":1500`) {
+		t.Errorf("expected the response log to carry the caller's duration, got %s", logs.String())
+	}
+}
+
+func TestParseResponse_EmptyBodyIsParseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	bp := NewBaseProvider("test", 5*time.Second, 0, nil)
+	resp, err := bp.MakeRequest(context.Background(), http.MethodGet, server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var target struct{}
+	_, err = bp.ParseResponse(resp, 0, &target)
+	var provErr *ProviderError
+	if !errors.As(err, &provErr) || provErr.Code != CodeJSONParse {
+		t.Errorf("expected a JSON parse error for an empty body, got %v", err)
+	}
+}
+
+func TestNewBaseProviderFromSettings(t *testing.T) {
+	bp, err := NewBaseProviderFromSettings("test", map[string]interface{}{
+		"allowed_extensions": "txt",
+		"max_concurrency":    "2",
+		"weight":             3,
+	}, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bp.Name() != "test" || bp.GetTimeout() != time.Minute || bp.GetMaxFileSize() != 10 || bp.MaxConcurrency() != 2 || bp.Weight() != 3 {
+		t.Errorf("unexpected base provider %#v", bp)
+	}
+	if err := bp.ValidateFile(context.Background(), "notes.txt", 5); err != nil {
+		t.Errorf("expected an allowed file to validate, got %v", err)
+	}
+	if err := bp.ValidateFile(context.Background(), "image.png", 5); err == nil {
+		t.Error("expected an extension outside allowed_extensions to be rejected")
+	}
+	if err := bp.ValidateFile(context.Background(), "notes.txt", 11); err == nil {
+		t.Error("expected a file over the size limit to be rejected")
+	}
+}
+
+func TestNewBaseProviderFromSettings_InvalidConcurrency(t *testing.T) {
+	if _, err := NewBaseProviderFromSettings("test", map[string]interface{}{"max_concurrency": "many"}, time.Minute, 0); err == nil {
+		t.Error("expected an error for a non-numeric max_concurrency")
+	}
+}
@@ -0,0 +1,69 @@
+package providers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxConcurrencyFromSettings reads the max_concurrency setting, the most
+// uploads a provider runs at once regardless of the global concurrency. It
+// accepts a number or a numeric string; 0 or a missing setting means no limit.
+func MaxConcurrencyFromSettings(settings map[string]interface{}) (int, error) {
+	limit, _, err := intSetting(settings, "max_concurrency")
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("invalid max_concurrency %d: must not be negative", limit)
+	}
+	return limit, nil
+}
+
+// WeightFromSettings reads the weight setting, the provider's share of the
+// upload slots when racing. 0 or a missing setting means the default of 1.
+func WeightFromSettings(settings map[string]interface{}) (int, error) {
+	weight, _, err := intSetting(settings, "weight")
+	if err != nil {
+		return 0, err
+	}
+	if weight < 0 {
+		return 0, fmt.Errorf("invalid weight %d: must not be negative", weight)
+	}
+	if weight == 0 {
+		weight = 1
+	}
+	return weight, nil
+}
+
+// PriorityFromSettings reads the priority setting, which orders providers:
+// lower numbers are tried first. set is false when the setting is missing.
+func PriorityFromSettings(settings map[string]interface{}) (priority int, set bool, err error) {
+	return intSetting(settings, "priority")
+}
+
+// intSetting reads a whole number given as a number or a numeric string; set
+// is false when the setting is missing
+func intSetting(settings map[string]interface{}, key string) (int, bool, error) {
+	switch value := settings[key].(type) {
+	case nil:
+		return 0, false, nil
+	case int:
+		return value, true, nil
+	case int64:
+		return int(value), true, nil
+	case float64:
+		if value != float64(int(value)) {
+			return 0, false, fmt.Errorf("invalid %s %v: must be a whole number", key, value)
+		}
+		return int(value), true, nil
+	case string:
+		parsed, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		return parsed, true, nil
+	default:
+		return 0, false, fmt.Errorf("invalid %s %v: must be a number", key, value)
+	}
+}
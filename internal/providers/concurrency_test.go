@@ -0,0 +1,73 @@
+package providers
+
+import (
+	"testing"
+)
+
+func TestMaxConcurrencyFromSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int
+		wantErr  bool
+	}{
+		{"missing", nil, 0, false},
+		{"int", 2, 2, false},
+		{"int64", int64(3), 3, false},
+		{"yaml float", float64(4), 4, false},
+		{"string", " 5 ", 5, false},
+		{"zero means unlimited", 0, 0, false},
+		{"negative", -1, 0, true},
+		{"fraction", 1.5, 0, true},
+		{"not a number", "many", 0, true},
+		{"wrong type", true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := map[string]interface{}{}
+			if tt.value != nil {
+				settings["max_concurrency"] = tt.value
+			}
+			limit, err := MaxConcurrencyFromSettings(settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error result: %v", err)
+			}
+			if limit != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, limit)
+			}
+		})
+	}
+}
+
+func TestWeightFromSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int
+		wantErr  bool
+	}{
+		{"missing defaults to 1", nil, 1, false},
+		{"zero defaults to 1", 0, 1, false},
+		{"int", 3, 3, false},
+		{"string", "2", 2, false},
+		{"negative", -1, 0, true},
+		{"not a number", "heavy", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := map[string]interface{}{}
+			if tt.value != nil {
+				settings["weight"] = tt.value
+			}
+			weight, err := WeightFromSettings(settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error result: %v", err)
+			}
+			if weight != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, weight)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// sniffLen is how much content http.DetectContentType looks at
+const sniffLen = 512
+
+// DetectContentType returns the MIME type of a file from its extension, or
+// sniffed from the first 512 bytes of content when the extension is missing
+// or unknown. Unrecognised content is application/octet-stream.
+func DetectContentType(filename string, content []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		return contentType
+	}
+	if len(content) > sniffLen {
+		content = content[:sniffLen]
+	}
+	return http.DetectContentType(content)
+}
@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	binary := []byte{0x00, 0x01, 0x02, 0xfe, 0xff, 0x10, 0x7f}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		want     string
+	}{
+		{"png extension", "photo.png", png, "image/png"},
+		{"json extension", "data.json", []byte(`{"a":1}`), "application/json"},
+		{"extension wins over content", "photo.PNG", []byte("plain text"), "image/png"},
+		{"extensionless binary", "blob", binary, "application/octet-stream"},
+		{"extensionless png is sniffed", "image", png, "image/png"},
+		{"unknown extension is sniffed", "notes.woofunknown", []byte("hello there"), "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectContentType(tt.filename, tt.content))
+		})
+	}
+}
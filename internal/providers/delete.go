@@ -0,0 +1,16 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeleteFile removes an uploaded file through provider, returning an
+// unsupported error when the provider cannot delete files
+func DeleteFile(ctx context.Context, provider Provider, deleteToken string) error {
+	deleter, ok := provider.(Deleter)
+	if !ok {
+		return NewUnsupportedError(fmt.Sprintf("provider %s does not support deleting files", provider.Name()), nil)
+	}
+	return deleter.Delete(ctx, deleteToken)
+}
@@ -0,0 +1,52 @@
+package providers
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+// FileDigests holds hex encoded checksums of an upload's content, computed
+// before the upload starts
+type FileDigests struct {
+	SHA256 string
+	MD5    string
+}
+
+type fileDigestsKey struct{}
+
+// WithFileDigests returns a context carrying the digests of the file being uploaded
+func WithFileDigests(ctx context.Context, digests FileDigests) context.Context {
+	return context.WithValue(ctx, fileDigestsKey{}, digests)
+}
+
+// FileDigestsFromContext returns the digests attached by WithFileDigests, if any.
+// Providers that need a checksum up front (Content-MD5, idempotency keys) can
+// use them instead of hashing the body a second time.
+func FileDigestsFromContext(ctx context.Context) (FileDigests, bool) {
+	digests, ok := ctx.Value(fileDigestsKey{}).(FileDigests)
+	return digests, ok
+}
+
+// ComputeFileDigests reads r to the end and returns its SHA-256 and MD5 digests
+func ComputeFileDigests(r io.Reader) (FileDigests, error) {
+	return ComputeFileDigestsBuffer(r, nil)
+}
+
+// ComputeFileDigestsBuffer is ComputeFileDigests reading through buf; a nil buf
+// falls back to io.Copy's default size
+func ComputeFileDigestsBuffer(r io.Reader, buf []byte) (FileDigests, error) {
+	sha := sha256.New()
+	sum := md5.New()
+	// Hide WriterTo so *os.File cannot bypass buf with its own copy loop
+	src := struct{ io.Reader }{r}
+	if _, err := io.CopyBuffer(io.MultiWriter(sha, sum), src, buf); err != nil {
+		return FileDigests{}, err
+	}
+	return FileDigests{
+		SHA256: hex.EncodeToString(sha.Sum(nil)),
+		MD5:    hex.EncodeToString(sum.Sum(nil)),
+	}, nil
+}
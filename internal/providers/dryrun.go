@@ -0,0 +1,21 @@
+package providers
+
+import (
+	"context"
+)
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context marking a dry run. Nothing is uploaded in a
+// dry run, and providers must not make network calls: Initialize skips
+// network-dependent setup such as server selection or token checks, and
+// ValidateFile only runs local checks (size, extension).
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx was marked by WithDryRun
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
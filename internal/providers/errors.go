@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Error codes shared by all providers
+const (
+	CodeJSONParse          = "JSON_PARSE_ERROR"
+	CodeUploadError        = "UPLOAD_ERROR"
+	CodeMissingID          = "MISSING_ID"
+	CodeMissingURL         = "MISSING_URL"
+	CodeMissingDownloadURL = "MISSING_DOWNLOAD_URL"
+	CodeNullResponse       = "NULL_RESPONSE"
+	CodeInvalidURL         = "INVALID_URL"
+	CodeShortBody          = "SHORT_BODY"
+	CodeAlreadyExpired     = "ALREADY_EXPIRED"
+)
+
+// ErrUploadStatus reports an unexpected HTTP status returned by an upload endpoint
+func ErrUploadStatus(status int, body string) *ProviderError {
+	return NewAPIError(
+		strconv.Itoa(status),
+		fmt.Sprintf("upload failed with status %d: %s", status, body),
+		nil,
+	)
+}
+
+// ErrAPIStatus reports an unexpected HTTP status returned by a generic API call
+func ErrAPIStatus(status int, body string) *ProviderError {
+	return NewAPIError(
+		strconv.Itoa(status),
+		fmt.Sprintf("API returned status %d: %s", status, body),
+		nil,
+	)
+}
+
+// ErrResponseCode reports a failure code embedded in an otherwise successful response body
+func ErrResponseCode(code int) *ProviderError {
+	return NewAPIError(
+		strconv.Itoa(code),
+		fmt.Sprintf("upload failed with code %d", code),
+		nil,
+	)
+}
+
+// ErrUploadRejected reports a provider-level status string other than success
+func ErrUploadRejected(status string) *ProviderError {
+	return NewAPIError(CodeUploadError, fmt.Sprintf("upload failed with status: %s", status), nil)
+}
+
+// ErrJSONParse reports a response body that could not be decoded
+func ErrJSONParse(cause error) *ProviderError {
+	return NewAPIError(CodeJSONParse, "failed to parse response", cause)
+}
+
+// ErrMissingID reports a response without a file identifier
+func ErrMissingID() *ProviderError {
+	return NewAPIError(CodeMissingID, "upload response missing file ID", nil)
+}
+
+// ErrMissingDownloadURL reports an upload response without a download URL
+func ErrMissingDownloadURL() *ProviderError {
+	return NewAPIError(CodeMissingDownloadURL, "upload response missing download URL", nil)
+}
+
+// ErrMissingURL reports a normalized provider response without a URL
+func ErrMissingURL() *ProviderError {
+	return NewAPIError(CodeMissingURL, "provider response missing download URL", nil)
+}
+
+// ErrNullResponse reports a provider that returned neither a response nor an error
+func ErrNullResponse() *ProviderError {
+	return NewAPIError(CodeNullResponse, "provider returned null response", nil)
+}
+
+// ErrInvalidURLBody reports a plain-text response body that is not a download URL
+func ErrInvalidURLBody(body string) *ProviderError {
+	return NewAPIError(CodeInvalidURL, fmt.Sprintf("response is not a valid URL: %q", body), nil)
+}
+
+// ErrInvalidResponseURL reports a normalized provider response whose URL is not
+// an absolute http(s) URL
+func ErrInvalidResponseURL(url string) *ProviderError {
+	return NewAPIError(CodeInvalidURL, fmt.Sprintf("provider response URL is not an absolute http(s) URL: %q", url), nil)
+}
+
+// ErrAlreadyExpired reports a provider response whose expiry has already passed
+func ErrAlreadyExpired(expires time.Time) *ProviderError {
+	return NewAPIError(CodeAlreadyExpired, fmt.Sprintf("provider response expired at %s", expires.Format(time.RFC3339)), nil)
+}
+
+// ErrShortBody reports a server that acknowledged fewer bytes than were sent; the
+// upload is retryable so the full body is sent again
+func ErrShortBody(accepted, sent int64) *ProviderError {
+	return NewProviderError(
+		ErrorTypeTemporary,
+		CodeShortBody,
+		fmt.Sprintf("server accepted %d of %d bytes", accepted, sent),
+		true,
+		nil,
+	)
+}
+
+// ErrFileRead reports a failure reading the source file
+func ErrFileRead(cause error) *ProviderError {
+	return NewNetworkError("failed to read file", cause)
+}
+
+// ErrRequestCreate reports a failure building the HTTP request
+func ErrRequestCreate(cause error) *ProviderError {
+	return NewNetworkError("failed to create request", cause)
+}
+
+// ErrRequestFailed reports a failure sending the upload request
+func ErrRequestFailed(cause error) *ProviderError {
+	return NewNetworkError("failed to upload file", cause)
+}
+
+// ErrFileTooLarge reports a file exceeding the provider's size limit
+func ErrFileTooLarge(size, maxSize int64) *ProviderError {
+	return NewFileTooLargeError(
+		fmt.Sprintf("file size %d bytes exceeds maximum %d bytes", size, maxSize),
+		nil,
+	)
+}
@@ -0,0 +1,41 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExtensionsFromSettings builds a provider's supported extension set from the
+// allowed_extensions setting, given as a list or a comma-separated string.
+// Entries are lowercased and prefixed with a dot; without the setting every
+// extension ("*") is allowed.
+func ExtensionsFromSettings(settings map[string]interface{}) map[string]bool {
+	var entries []string
+	switch value := settings["allowed_extensions"].(type) {
+	case string:
+		entries = strings.Split(value, ",")
+	case []string:
+		entries = value
+	case []interface{}:
+		for _, entry := range value {
+			entries = append(entries, fmt.Sprint(entry))
+		}
+	}
+
+	extensions := make(map[string]bool)
+	for _, entry := range entries {
+		ext := strings.ToLower(strings.TrimSpace(entry))
+		if ext == "" {
+			continue
+		}
+		if ext != "*" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions[ext] = true
+	}
+
+	if len(extensions) == 0 {
+		extensions["*"] = true
+	}
+	return extensions
+}
@@ -0,0 +1,20 @@
+package providers
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoHealthCheck is returned by CheckHealth for providers that do not
+// implement HealthChecker, so their state is unknown rather than failed
+var ErrNoHealthCheck = errors.New("provider has no health check")
+
+// CheckHealth runs provider's health check, returning ErrNoHealthCheck when
+// it has none
+func CheckHealth(ctx context.Context, provider Provider) error {
+	checker, ok := provider.(HealthChecker)
+	if !ok {
+		return ErrNoHealthCheck
+	}
+	return checker.HealthCheck(ctx)
+}
@@ -0,0 +1,29 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// InitializeAll runs Initialize once for every provider that implements
+// Initializer. Providers are initialized concurrently; the first failure is
+// returned so the run can stop before any upload starts.
+func InitializeAll(ctx context.Context, providers []Provider) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, provider := range providers {
+		init, ok := provider.(Initializer)
+		if !ok {
+			continue
+		}
+		name := provider.Name()
+		g.Go(func() error {
+			if err := init.Initialize(ctx); err != nil {
+				return fmt.Errorf("failed to initialize provider %s: %w", name, err)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
@@ -0,0 +1,52 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// initProvider counts Initialize calls and returns err from each of them
+type initProvider struct {
+	flakyProvider
+	calls atomic.Int32
+	err   error
+}
+
+func (p *initProvider) Name() string { return "init" }
+
+func (p *initProvider) Initialize(ctx context.Context) error {
+	p.calls.Add(1)
+	return p.err
+}
+
+func TestInitializeAll_CallsEachInitializerOnce(t *testing.T) {
+	provider := &initProvider{}
+	plain := &flakyProvider{}
+	list := []Provider{NewConsistencyWrapper(provider, retryTestConfig()), plain}
+
+	if err := InitializeAll(context.Background(), list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("expected Initialize to be called once, got %d", got)
+	}
+}
+
+func TestInitializeAll_ReturnsFailure(t *testing.T) {
+	cause := NewAuthenticationError("token rejected", nil)
+	provider := &initProvider{err: cause}
+
+	err := InitializeAll(context.Background(), []Provider{NewConsistencyWrapper(provider, retryTestConfig())})
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected the initialization error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "failed to initialize provider init") {
+		t.Errorf("expected the provider name in the error, got %q", err.Error())
+	}
+	if GetErrorType(err) != ErrorTypeAuthentication {
+		t.Errorf("expected the error type to be preserved, got %v", GetErrorType(err))
+	}
+}
@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+)
+
+// UploadOptions describes one upload apart from its body. It lets providers
+// and the uploader gain per-upload settings without widening every signature.
+type UploadOptions struct {
+	FilePath    string            // Path the upload is named after; providers use its base name
+	Size        int64             // Body length in bytes, -1 when not known in advance
+	ContentType string            // MIME type of the body, empty lets the provider choose
+	Metadata    map[string]string // Added to the response metadata, replacing provider values
+	Tags        []string          // Free-form labels for providers that support them
+	Digests     *FileDigests      // Checksums computed before the upload, nil when not prehashed
+	Transforms  []string          // Content transforms applied to the body, e.g. "exif_stripped"
+}
+
+// FileName returns the name the upload is stored under
+func (o UploadOptions) FileName() string {
+	return filepath.Base(o.FilePath)
+}
+
+// OptionsUploader is implemented by providers that take UploadOptions in
+// place of Upload's positional arguments. Their Upload method is a thin
+// wrapper building the options from the path and size.
+type OptionsUploader interface {
+	UploadWithOptions(ctx context.Context, file io.Reader, opts UploadOptions) (*ProviderResponse, error)
+}
+
+// UploadWithOptions uploads file through the provider's UploadWithOptions,
+// falling back to Upload for providers without it. Digests are also attached
+// to the context for providers that read them from there, and opts.Metadata
+// is merged into a successful response.
+func UploadWithOptions(ctx context.Context, provider Provider, file io.Reader, opts UploadOptions) (*ProviderResponse, error) {
+	if opts.Digests != nil {
+		ctx = WithFileDigests(ctx, *opts.Digests)
+	}
+
+	var response *ProviderResponse
+	var err error
+	if uploader, ok := provider.(OptionsUploader); ok {
+		response, err = uploader.UploadWithOptions(ctx, file, opts)
+	} else {
+		response, err = provider.Upload(ctx, opts.FilePath, file, opts.Size)
+	}
+	if err != nil || response == nil || len(opts.Metadata) == 0 {
+		return response, err
+	}
+
+	if response.Metadata == nil {
+		response.Metadata = make(map[string]string, len(opts.Metadata))
+	}
+	for key, value := range opts.Metadata {
+		response.Metadata[key] = value
+	}
+	return response, nil
+}
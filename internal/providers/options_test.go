@@ -0,0 +1,106 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+// positionalProvider only implements Upload and records what it was called with
+type positionalProvider struct {
+	filePath string
+	size     int64
+	digests  FileDigests
+}
+
+func (p *positionalProvider) Name() string { return "positional" }
+
+func (p *positionalProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	p.filePath, p.size = filePath, size
+	p.digests, _ = FileDigestsFromContext(ctx)
+	return &ProviderResponse{URL: "https://example.com/file", Metadata: map[string]string{"original_name": "file"}}, nil
+}
+
+func (p *positionalProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *positionalProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *positionalProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+// optionsProvider takes UploadOptions; Upload must not be reached
+type optionsProvider struct {
+	positionalProvider
+	opts UploadOptions
+}
+
+func (p *optionsProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	panic("Upload called on a provider that accepts UploadOptions")
+}
+
+func (p *optionsProvider) UploadWithOptions(ctx context.Context, file io.Reader, opts UploadOptions) (*ProviderResponse, error) {
+	p.opts = opts
+	return &ProviderResponse{URL: "https://example.com/" + opts.FileName()}, nil
+}
+
+var _ OptionsUploader = (*optionsProvider)(nil)
+
+func TestUploadWithOptions_FallsBackToUpload(t *testing.T) {
+	provider := &positionalProvider{}
+	response, err := UploadWithOptions(context.Background(), provider, strings.NewReader("data"), UploadOptions{
+		FilePath: "/tmp/report.txt",
+		Size:     4,
+		Digests:  &FileDigests{SHA256: "abc"},
+		Metadata: map[string]string{"original_name": "Report.txt"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.filePath != "/tmp/report.txt" || provider.size != 4 {
+		t.Errorf("expected path and size to be passed on, got %q and %d", provider.filePath, provider.size)
+	}
+	if provider.digests.SHA256 != "abc" {
+		t.Errorf("expected digests in the context, got %+v", provider.digests)
+	}
+	if got := response.Metadata["original_name"]; got != "Report.txt" {
+		t.Errorf("expected option metadata to replace the provider's, got %q", got)
+	}
+}
+
+func TestUploadWithOptions_PrefersOptionsUploader(t *testing.T) {
+	provider := &optionsProvider{}
+	opts := UploadOptions{FilePath: "/tmp/photo.jpg", Size: 10, ContentType: "image/jpeg", Tags: []string{"holiday"}}
+	response, err := UploadWithOptions(context.Background(), provider, strings.NewReader("data"), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.opts.ContentType != "image/jpeg" || len(provider.opts.Tags) != 1 {
+		t.Errorf("expected the options to reach the provider, got %+v", provider.opts)
+	}
+	if response.URL != "https://example.com/photo.jpg" {
+		t.Errorf("unexpected URL %q", response.URL)
+	}
+}
+
+func TestConsistencyWrapper_ForwardsUploadOptions(t *testing.T) {
+	provider := &optionsProvider{}
+	wrapper := NewConsistencyWrapper(provider, DefaultWrapperConfig())
+
+	// The positional entry point reaches the provider as options too
+	if _, err := wrapper.Upload(context.Background(), "/tmp/a.txt", strings.NewReader("data"), 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.opts.FilePath != "/tmp/a.txt" || provider.opts.Size != 4 {
+		t.Errorf("expected Upload to build options, got %+v", provider.opts)
+	}
+
+	opts := UploadOptions{FilePath: "/tmp/b.txt", Size: 4, ContentType: "text/plain"}
+	if _, err := wrapper.UploadWithOptions(context.Background(), strings.NewReader("data"), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.opts.ContentType != "text/plain" {
+		t.Errorf("expected the wrapper to forward the options, got %+v", provider.opts)
+	}
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxURLBodyExcerpt bounds how much of a rejected body is quoted in the error
+const maxURLBodyExcerpt = 100
+
+// utf8BOM is stripped from plain-text bodies before parsing
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParsePlainTextURL extracts the download URL from providers that answer an upload
+// with the URL as a plain-text body. Surrounding whitespace and a UTF-8 byte order
+// mark are removed; anything that is not a single absolute http(s) URL, such as an
+// HTML page or a "Too many requests" message, is rejected.
+func ParsePlainTextURL(body []byte) (string, error) {
+	body = bytes.TrimPrefix(body, utf8BOM)
+	text := strings.TrimSpace(string(body))
+
+	if !utf8.ValidString(text) || text == "" || strings.ContainsAny(text, " \t\r\n<>\"") {
+		return "", ErrInvalidURLBody(excerpt(text))
+	}
+
+	parsed, err := url.Parse(text)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", ErrInvalidURLBody(excerpt(text))
+	}
+
+	return text, nil
+}
+
+// excerpt shortens a body for inclusion in an error message
+func excerpt(text string) string {
+	text = strings.ToValidUTF8(text, "�")
+	if len(text) <= maxURLBodyExcerpt {
+		return text
+	}
+	cut := maxURLBodyExcerpt
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePlainTextURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "plain url", body: "https://files.example.com/abc123", expected: "https://files.example.com/abc123"},
+		{name: "trailing newline", body: "https://files.example.com/abc123\n", expected: "https://files.example.com/abc123"},
+		{name: "crlf and spaces", body: "  http://example.com/x.txt \r\n", expected: "http://example.com/x.txt"},
+		{name: "byte order mark", body: "\xEF\xBB\xBFhttps://example.com/f", expected: "https://example.com/f"},
+		{name: "rate limit text", body: "Too many requests\n", wantErr: true},
+		{name: "html page", body: "<html><body>502 Bad Gateway</body></html>", wantErr: true},
+		{name: "relative path", body: "/files/abc123", wantErr: true},
+		{name: "unsupported scheme", body: "ftp://example.com/file", wantErr: true},
+		{name: "missing host", body: "https:///file", wantErr: true},
+		{name: "multiple lines", body: "https://example.com/a\nhttps://example.com/b", wantErr: true},
+		{name: "empty", body: "  \n", wantErr: true},
+		{name: "invalid utf-8", body: "https://example.com/\xff", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePlainTextURL([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got URL %q", tt.body, got)
+				}
+				var provErr *ProviderError
+				if !errors.As(err, &provErr) || provErr.Code != CodeInvalidURL {
+					t.Errorf("expected %s provider error, got %v", CodeInvalidURL, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParsePlainTextURL_TruncatesLongBodies(t *testing.T) {
+	_, err := ParsePlainTextURL([]byte(strings.Repeat("error ", 100)))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(err.Error()) > 200 {
+		t.Errorf("error message should quote only an excerpt, got %d bytes", len(err.Error()))
+	}
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Provider is the single interface implemented by every file hosting provider.
+// The factory, the consistency wrapper and the uploader all depend on it.
+type Provider interface {
+	Name() string
+	Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error)
+	ValidateFile(ctx context.Context, filePath string, size int64) error
+	GetMaxFileSize() int64
+	GetSupportedExtensions() []string
+}
+
+// TimeoutProvider is implemented by providers that expose their HTTP timeout
+type TimeoutProvider interface {
+	GetTimeout() time.Duration
+}
+
+// ConcurrencyLimiter is implemented by providers that cannot handle unlimited
+// parallel uploads, e.g. because of account-scoped rate limits. The uploader
+// runs at most MaxConcurrency uploads to the provider at once; 0 means no limit.
+type ConcurrencyLimiter interface {
+	MaxConcurrency() int
+}
+
+// WeightedProvider is implemented by providers that declare their share of
+// the upload slots when a file is raced across providers: a provider of
+// weight 2 may hold twice as many slots as one of weight 1.
+type WeightedProvider interface {
+	Weight() int
+}
+
+// ProviderWeight returns the scheduling weight of provider, 1 when it declares none
+func ProviderWeight(provider Provider) int {
+	if weighted, ok := provider.(WeightedProvider); ok && weighted.Weight() > 0 {
+		return weighted.Weight()
+	}
+	return 1
+}
+
+// StreamingProvider is implemented by providers that declare whether they can
+// upload a body whose length is not known in advance (size passed as -1), such
+// as standard input. Providers that do not implement it are assumed unable to.
+type StreamingProvider interface {
+	AcceptsUnknownLength() bool
+}
+
+// AcceptsUnknownLength reports whether provider declares support for bodies of unknown length
+func AcceptsUnknownLength(provider Provider) bool {
+	streaming, ok := provider.(StreamingProvider)
+	return ok && streaming.AcceptsUnknownLength()
+}
+
+// Chunked is implemented by providers that can receive a large file as a
+// sequence of byte ranges, such as WebDAV or S3-style servers accepting PUT
+// with Content-Range. SupportsChunks lets a provider opt in from its settings.
+// UploadChunk sends the length bytes at offset of a file of size bytes; chunks
+// arrive in order and the response to the last one describes the upload.
+type Chunked interface {
+	SupportsChunks() bool
+	UploadChunk(ctx context.Context, filePath string, chunk io.Reader, offset, length, size int64) (*ProviderResponse, error)
+}
+
+// SupportsChunks reports whether provider declares support for chunked uploads
+func SupportsChunks(provider Provider) bool {
+	chunked, ok := provider.(Chunked)
+	return ok && chunked.SupportsChunks()
+}
+
+// Initializer is implemented by providers that need one-time setup, such as
+// picking an upload server or validating a token, before their first upload.
+// Initialize is called once per provider before a batch starts.
+type Initializer interface {
+	Initialize(ctx context.Context) error
+}
+
+// Deleter is implemented by providers that can remove an uploaded file. The
+// token is the DeleteURL from the upload response, or whatever identifier the
+// provider documents in its place (usually the file ID).
+type Deleter interface {
+	Delete(ctx context.Context, deleteToken string) error
+}
+
+// HealthChecker is implemented by providers that can confirm their service is
+// reachable and the configured credentials are accepted without uploading a
+// real file, usually with a lightweight request to the API. See CheckHealth.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,96 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CodeQuotaExceeded marks an upload refused because of a provider-imposed limit
+const CodeQuotaExceeded = "QUOTA_EXCEEDED"
+
+// quotaPhrases are lowercase fragments providers use when refusing an upload
+// over a daily, storage or rate limit
+var quotaPhrases = []string{
+	"daily limit",
+	"upload limit",
+	"limit reached",
+	"limit exceeded",
+	"quota",
+	"rate limit",
+	"ratelimit",
+	"too many uploads",
+	"too many requests",
+}
+
+// QuotaError recognises a provider-imposed limit in a refused upload and
+// returns an actionable quota error naming the provider, or nil when status
+// and body look like any other failure. 429 Too Many Requests and 507
+// Insufficient Storage (the WebDAV quota status) always count; other statuses
+// (0 when only a body or status string is available) count when the body uses
+// a common quota phrasing. Call it only on responses already known to be
+// failures.
+//
+// The error is not retryable: a limit does not clear within the retry delays,
+// so the uploader moves on to the next provider instead. ErrUploadStatusFor
+// makes it retryable when the server says when to come back.
+func QuotaError(provider string, status int, body string) *ProviderError {
+	lower := strings.ToLower(body)
+	if status != http.StatusTooManyRequests && status != http.StatusInsufficientStorage && !mentionsQuota(lower) {
+		return nil
+	}
+
+	limit := "upload limit"
+	switch {
+	case strings.Contains(lower, "daily"):
+		limit = "daily limit"
+	case status == http.StatusInsufficientStorage:
+		limit = "storage quota"
+	}
+	var cause error
+	if status != 0 {
+		cause = ErrUploadStatus(status, excerpt(body))
+	} else if body != "" {
+		cause = errors.New(excerpt(body))
+	}
+	return NewProviderError(
+		ErrorTypeQuota,
+		CodeQuotaExceeded,
+		fmt.Sprintf("provider %s %s reached, try again later or use another provider", provider, limit),
+		false,
+		cause,
+	)
+}
+
+// ErrUploadStatusFor is ErrUploadStatus for a named provider's response: a
+// refusal that QuotaError recognises is reported as a quota error instead.
+// When that response carries a Retry-After header, the quota error becomes
+// retryable after the requested wait.
+func ErrUploadStatusFor(provider string, resp *http.Response, body string) *ProviderError {
+	quotaErr := QuotaError(provider, resp.StatusCode, body)
+	if quotaErr == nil {
+		return ErrUploadStatus(resp.StatusCode, body)
+	}
+	if delay, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		quotaErr.RetryAfter = delay
+		quotaErr.Retryable = true
+	}
+	return quotaErr
+}
+
+// mentionsQuota reports whether a lowercased body contains a quota phrasing
+func mentionsQuota(lower string) bool {
+	for _, phrase := range quotaPhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsQuotaError reports whether err, or an error it wraps, is a quota error
+func IsQuotaError(err error) bool {
+	return RootErrorType(err) == ErrorTypeQuota
+}
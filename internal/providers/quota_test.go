@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuotaError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		quota   bool
+		message string
+	}{
+		{"too many requests status", http.StatusTooManyRequests, "", true, "provider Catbox upload limit reached"},
+		{"insufficient storage", http.StatusInsufficientStorage, "", true, "provider Catbox storage quota reached"},
+		{"daily limit phrase", http.StatusForbidden, `{"error":"Daily limit reached"}`, true, "provider Catbox daily limit reached"},
+		{"quota phrase without status", 0, "Upload quota exceeded for this IP", true, "provider Catbox upload limit reached"},
+		{"status string", 0, `{"status":"error-rateLimit"}`, true, "provider Catbox upload limit reached"},
+		{"other failure", http.StatusInternalServerError, "internal error", false, ""},
+		{"not found", http.StatusNotFound, "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := QuotaError("Catbox", tt.status, tt.body)
+			if !tt.quota {
+				if err != nil {
+					t.Fatalf("expected no quota error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected a quota error")
+			}
+			if err.Type != ErrorTypeQuota || err.Code != CodeQuotaExceeded || err.Retryable {
+				t.Errorf("unexpected error fields: %+v", err)
+			}
+			if !strings.HasPrefix(err.Message, tt.message) || !strings.HasSuffix(err.Message, "try again later or use another provider") {
+				t.Errorf("unexpected message %q", err.Message)
+			}
+		})
+	}
+}
+
+func TestErrUploadStatusFor(t *testing.T) {
+	response := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: make(http.Header)}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+
+	if err := ErrUploadStatusFor("Uguu", response(http.StatusTooManyRequests, ""), "slow down"); !IsQuotaError(err) || err.Retryable {
+		t.Errorf("expected a non-retryable quota error, got %+v", err)
+	}
+	if err := ErrUploadStatusFor("Uguu", response(http.StatusTooManyRequests, "7"), "slow down"); !err.Retryable || err.RetryAfter != 7*time.Second {
+		t.Errorf("expected a quota error retryable after 7s, got %+v", err)
+	}
+	if err := ErrUploadStatusFor("Uguu", response(http.StatusBadGateway, "7"), "bad gateway"); IsQuotaError(err) || err.Code != "502" || err.RetryAfter != 0 {
+		t.Errorf("expected a plain status error, got %+v", err)
+	}
+}
+
+// quotaProvider always reports a recognised limit
+type quotaProvider struct {
+	positionalProvider
+	attempts int
+}
+
+func (p *quotaProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	p.attempts++
+	return nil, QuotaError("limited", http.StatusTooManyRequests, "")
+}
+
+func TestConsistencyWrapper_DoesNotRetryQuotaErrors(t *testing.T) {
+	provider := &quotaProvider{}
+	config := DefaultWrapperConfig()
+	config.MaxRetries = 3
+	wrapper := NewConsistencyWrapper(provider, config)
+
+	_, err := wrapper.Upload(context.Background(), "file.txt", strings.NewReader("data"), 4)
+	if !IsQuotaError(err) {
+		t.Fatalf("expected the quota error, got %v", err)
+	}
+	if provider.attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", provider.attempts)
+	}
+}
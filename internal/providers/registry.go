@@ -0,0 +1,125 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Constructor builds a provider from its settings
+type Constructor func(settings map[string]interface{}) (Provider, error)
+
+// Registration describes how the factory creates a registered provider
+type Registration struct {
+	New Constructor
+	// Other names the provider is created under; they are not listed
+	Aliases []string
+	// Priority places the provider among the others for --all and the provider
+	// list, lowest first, so first-success uploads try durable hosts before
+	// expiring ones. Providers without a priority (0) follow, by name.
+	Priority int
+	// SetupSettings marks a provider that cannot upload until it is configured
+	// with a server or credentials. CreateAllProviders skips it, and the
+	// provider list builds it from these placeholder settings only to read
+	// its limits.
+	SetupSettings map[string]interface{}
+}
+
+// RequiresSetup reports whether the provider must be configured before use
+func (r Registration) RequiresSetup() bool {
+	return r.SetupSettings != nil
+}
+
+var (
+	registryMu    sync.RWMutex
+	registrations = make(map[string]Registration) // By lowercased name
+	aliases       = make(map[string]string)       // Lowercased alias to name
+)
+
+// Register makes a provider available to the factory under name. Provider
+// packages call it from init.
+func Register(name string, constructor Constructor) {
+	RegisterProvider(name, Registration{New: constructor})
+}
+
+// RegisterProvider is Register with aliases or setup requirements. It panics
+// when a name or alias is already taken, like registering the same provider
+// twice would.
+func RegisterProvider(name string, registration Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	key := strings.ToLower(name)
+	if registration.New == nil {
+		panic(fmt.Sprintf("providers: Register of %q has no constructor", name))
+	}
+	if registeredLocked(key) {
+		panic(fmt.Sprintf("providers: Register called twice for %q", name))
+	}
+	for _, alias := range registration.Aliases {
+		if registeredLocked(strings.ToLower(alias)) {
+			panic(fmt.Sprintf("providers: alias %q of %q is already registered", alias, name))
+		}
+	}
+
+	registrations[key] = registration
+	for _, alias := range registration.Aliases {
+		aliases[strings.ToLower(alias)] = key
+	}
+}
+
+func registeredLocked(key string) bool {
+	_, registered := registrations[key]
+	_, aliased := aliases[key]
+	return registered || aliased
+}
+
+// LookupProvider returns the registration for a provider name or alias,
+// ignoring case
+func LookupProvider(name string) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	key := strings.ToLower(name)
+	if target, ok := aliases[key]; ok {
+		key = target
+	}
+	registration, ok := registrations[key]
+	return registration, ok
+}
+
+// CanonicalProviderName returns the registered name a provider name or alias
+// refers to, ignoring case
+func CanonicalProviderName(name string) (string, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	key := strings.ToLower(name)
+	if target, ok := aliases[key]; ok {
+		key = target
+	}
+	_, ok := registrations[key]
+	return key, ok
+}
+
+// RegisteredProviders returns the registered provider names, without aliases,
+// ordered by priority and then by name
+func RegisteredProviders() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registrations))
+	for name := range registrations {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := registrations[names[i]].Priority, registrations[names[j]].Priority
+		if pi != pj {
+			// Unprioritized providers go last
+			return pj == 0 || (pi != 0 && pi < pj)
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
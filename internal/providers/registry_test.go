@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+// unregister removes a test registration and its aliases
+func unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	key := strings.ToLower(name)
+	delete(registrations, key)
+	for alias, target := range aliases {
+		if target == key {
+			delete(aliases, alias)
+		}
+	}
+}
+
+func newFixedProvider(settings map[string]interface{}) (Provider, error) {
+	return &fixedProvider{response: ProviderResponse{URL: "https://example.com/fixed"}}, nil
+}
+
+func TestRegisterProvider_LookupByNameAndAlias(t *testing.T) {
+	RegisterProvider("Registry-Test", Registration{New: newFixedProvider, Aliases: []string{"registry.test"}})
+	defer unregister("registry-test")
+
+	for _, name := range []string{"registry-test", "REGISTRY-TEST", "registry.test"} {
+		registration, ok := LookupProvider(name)
+		if !ok {
+			t.Fatalf("expected %q to be registered", name)
+		}
+		if provider, err := registration.New(nil); err != nil || provider.Name() != "fixed" {
+			t.Errorf("unexpected provider %v, %v", provider, err)
+		}
+	}
+
+	var listed []string
+	for _, name := range RegisteredProviders() {
+		if strings.HasPrefix(name, "registry") {
+			listed = append(listed, name)
+		}
+	}
+	if len(listed) != 1 || listed[0] != "registry-test" {
+		t.Errorf("expected only the lowercased name to be listed, got %v", listed)
+	}
+}
+
+func TestRegister_DuplicatePanics(t *testing.T) {
+	Register("registry-dup", newFixedProvider)
+	defer unregister("registry-dup")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a name twice to panic")
+		}
+	}()
+	Register("Registry-Dup", newFixedProvider)
+}
+
+func TestLookupProvider_Unknown(t *testing.T) {
+	if _, ok := LookupProvider("registry-missing"); ok {
+		t.Error("expected an unregistered name to be unknown")
+	}
+}
+
+func TestRegisteredProviders_OrdersByPriority(t *testing.T) {
+	RegisterProvider("registry-b", Registration{New: newFixedProvider})
+	RegisterProvider("registry-a", Registration{New: newFixedProvider})
+	RegisterProvider("registry-last", Registration{New: newFixedProvider, Priority: 2})
+	RegisterProvider("registry-first", Registration{New: newFixedProvider, Priority: 1})
+	for _, name := range []string{"registry-a", "registry-b", "registry-first", "registry-last"} {
+		defer unregister(name)
+	}
+
+	var listed []string
+	for _, name := range RegisteredProviders() {
+		if strings.HasPrefix(name, "registry") {
+			listed = append(listed, name)
+		}
+	}
+	expected := []string{"registry-first", "registry-last", "registry-a", "registry-b"}
+	if strings.Join(listed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, listed)
+	}
+}
@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"context"
+)
+
+// RetryEvent describes a retry the consistency wrapper is about to make
+type RetryEvent struct {
+	Provider   string
+	FilePath   string
+	Retry      int   // 1 for the first retry
+	MaxRetries int   // Configured retry limit
+	Err        error // Error of the attempt being retried
+}
+
+// RetryNotifier is called before each retry attempt, after the body was rewound
+type RetryNotifier func(event RetryEvent)
+
+type retryNotifierKey struct{}
+
+// WithRetryNotifier returns a context whose uploads report retries to notify
+func WithRetryNotifier(ctx context.Context, notify RetryNotifier) context.Context {
+	return context.WithValue(ctx, retryNotifierKey{}, notify)
+}
+
+// notifyRetry reports a retry to the notifier carried by ctx, if any
+func notifyRetry(ctx context.Context, event RetryEvent) {
+	if notify, ok := ctx.Value(retryNotifierKey{}).(RetryNotifier); ok && notify != nil {
+		notify(event)
+	}
+}
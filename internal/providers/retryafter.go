@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRetryAfter reads a Retry-After header value, either delay seconds or
+// an HTTP-date, and returns how long to wait from now. A date in the past
+// means no wait. ok is false when the value is empty or malformed.
+func ParseRetryAfter(value string, now time.Time) (delay time.Duration, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay = date.Sub(now); delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+// RetryAfterOf returns the server-requested wait carried by err, or 0
+func RetryAfterOf(err error) time.Duration {
+	var provErr *ProviderError
+	if errors.As(err, &provErr) {
+		return provErr.RetryAfter
+	}
+	return 0
+}
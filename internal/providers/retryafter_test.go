@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"2", 2 * time.Second, true},
+		{" 120 ", 2 * time.Minute, true},
+		{"0", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		delay, ok := ParseRetryAfter(tt.value, now)
+		if delay != tt.expected || ok != tt.ok {
+			t.Errorf("ParseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, delay, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+// httpProvider PUTs the body to a server and reports refusals like the real providers do
+type httpProvider struct {
+	positionalProvider
+	url string
+}
+
+func (p *httpProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.url, file)
+	if err != nil {
+		return nil, ErrRequestCreate(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, ErrRequestFailed(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrUploadStatusFor("limited", resp, string(body))
+	}
+	return &ProviderResponse{URL: strings.TrimSpace(string(body))}, nil
+}
+
+// rateLimitedServer answers the first request with 429 and retryAfter, later ones with a URL
+func rateLimitedServer(retryAfter string) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, "https://example.com/file\n")
+	}))
+	return server, &requests
+}
+
+func TestConsistencyWrapper_HonorsRetryAfter(t *testing.T) {
+	server, requests := rateLimitedServer("2")
+	defer server.Close()
+
+	config := DefaultWrapperConfig()
+	config.RetryDelay = 10 * time.Millisecond
+	wrapper := NewConsistencyWrapper(&httpProvider{url: server.URL}, config)
+
+	start := time.Now()
+	response, err := wrapper.Upload(context.Background(), "file.txt", strings.NewReader("data"), 4)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if response.URL != "https://example.com/file" || requests.Load() != 2 {
+		t.Errorf("expected success on the second request, got %q after %d requests", response.URL, requests.Load())
+	}
+	if elapsed < 2*time.Second || elapsed > 3*time.Second {
+		t.Errorf("expected the wrapper to wait about 2s as asked, took %v", elapsed)
+	}
+}
+
+func TestConsistencyWrapper_RetryAfterBeyondMaxDelayFailsOver(t *testing.T) {
+	server, requests := rateLimitedServer("3600")
+	defer server.Close()
+
+	config := DefaultWrapperConfig()
+	config.MaxRetryDelay = time.Minute
+	wrapper := NewConsistencyWrapper(&httpProvider{url: server.URL}, config)
+
+	_, err := wrapper.Upload(context.Background(), "file.txt", strings.NewReader("data"), 4)
+	if !IsQuotaError(err) || RetryAfterOf(err) != time.Hour {
+		t.Fatalf("expected the quota error with its hour-long Retry-After, got %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected no retry when the server asks for more than the maximum delay, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+)
+
+func TestMain(m *testing.M) {
+	logging.Init(false, io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestHMACSigner_KnownVector(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "https://example.com/upload/test.txt?folder=1", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	signer := NewSignerFromSettings(map[string]interface{}{
+		"signing_key":    "secret",
+		"signing_key_id": "key-1",
+	})
+	bodyHash := sha256.Sum256([]byte("hello"))
+	if err := SignRequest(signer, req, bodyHash[:]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedHash := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	expectedAuth := "HMAC-SHA256 KeyId=key-1, Signature=e077f48d2ea7b6fe2b10fc3cb2575df3dc006d895b3f98950e810ebb7949117a"
+	if got := req.Header.Get("X-Content-SHA256"); got != expectedHash {
+		t.Errorf("X-Content-SHA256 = %s, want %s", got, expectedHash)
+	}
+	if got := req.Header.Get("X-Signature"); got != expectedAuth {
+		t.Errorf("X-Signature = %s, want %s", got, expectedAuth)
+	}
+}
+
+func TestHMACSigner_KeepsExistingHeader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "https://example.com/upload/test.txt", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+
+	signer := NewSignerFromSettings(map[string]interface{}{
+		"signing_key":    "secret",
+		"signing_header": "Authorization",
+	})
+	bodyHash := sha256.Sum256(nil)
+	if err := SignRequest(signer, req, bodyHash[:]); err == nil {
+		t.Fatal("expected an error when the signing header is already set")
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization = %s, want the original credentials", got)
+	}
+}
+
+func TestNewSignerFromSettings_Disabled(t *testing.T) {
+	if signer := NewSignerFromSettings(map[string]interface{}{}); signer != nil {
+		t.Errorf("expected no signer without signing_key, got %#v", signer)
+	}
+}
+
+func TestBaseProvider_MakeRequestSignsBody(t *testing.T) {
+	var gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("X-Signature")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+	}))
+	defer server.Close()
+
+	bp := NewBaseProvider("signed", 5*time.Second, 0, nil)
+	bp.SetSigner(&HMACSigner{KeyID: "k", Secret: []byte("secret"), Header: "X-Signature"})
+
+	resp, err := bp.MakeRequest(context.Background(), http.MethodPut, server.URL+"/test.txt", strings.NewReader("hello"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotBody != "hello" {
+		t.Errorf("body should reach the server intact, got %q", gotBody)
+	}
+	expected := "HMAC-SHA256 KeyId=k, Signature=2fcf21a3c22cf45ff52d7c684bd33c67438a9ad46d8752bf53b55a0e75a6b46a"
+	if gotAuth != expected {
+		t.Errorf("X-Signature = %s, want %s", gotAuth, expected)
+	}
+}
+
+func TestBaseProvider_MakeRequestRejectsUnsignableBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("an unsigned request should not be sent")
+	}))
+	defer server.Close()
+
+	bp := NewBaseProvider("signed", 5*time.Second, 0, nil)
+	bp.SetSigner(&HMACSigner{KeyID: "k", Secret: []byte("secret")})
+
+	body := io.MultiReader(strings.NewReader("hello"))
+	if _, err := bp.MakeRequest(context.Background(), http.MethodPut, server.URL+"/test.txt", body, nil); err == nil {
+		t.Fatal("expected an error for a body that can only be read once")
+	}
+}
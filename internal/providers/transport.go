@@ -0,0 +1,226 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DNSPort is used when a DNS server is given without a port
+const DNSPort = "53"
+
+// DialConfig tunes how provider connections are established
+type DialConfig struct {
+	// FallbackDelay is how long a dual-stack dial waits on the first address
+	// family before racing the other one (happy eyeballs). Zero keeps Go's
+	// default of 300ms, a negative value disables the fallback.
+	FallbackDelay time.Duration
+	// DNSServer is a host:port resolver used instead of the system one
+	DNSServer string
+	// Resolver overrides DNSServer when set
+	Resolver *net.Resolver
+}
+
+// DefaultUserAgent is sent until SetUserAgent configures another one
+const DefaultUserAgent = "woof"
+
+var (
+	transportMu     sync.RWMutex
+	sharedTransport = newTransport(DialConfig{})
+	userAgent       = DefaultUserAgent
+	sharedClient    = &userAgentTransport{base: sharedTransport, userAgent: userAgent}
+)
+
+// ConfigureTransport replaces the transport shared by provider HTTP clients.
+// Clients created before the call keep the previous transport, so configure
+// it before creating providers.
+func ConfigureTransport(cfg DialConfig) error {
+	if cfg.Resolver == nil && cfg.DNSServer != "" {
+		server, err := normalizeDNSServer(cfg.DNSServer)
+		if err != nil {
+			return err
+		}
+		cfg.Resolver = newResolver(server)
+	}
+
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	sharedTransport = newTransport(cfg)
+	sharedClient = &userAgentTransport{base: sharedTransport, userAgent: userAgent}
+	return nil
+}
+
+// SetUserAgent sets the User-Agent provider requests carry unless the
+// provider's user_agent setting overrides it. Like ConfigureTransport it
+// applies to clients created after the call; empty restores DefaultUserAgent.
+func SetUserAgent(agent string) {
+	if agent == "" {
+		agent = DefaultUserAgent
+	}
+
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	userAgent = agent
+	sharedClient = &userAgentTransport{base: sharedTransport, userAgent: userAgent}
+}
+
+// NewHTTPClient returns a client with the given timeout that uses the shared
+// transport, so all providers pool connections and honour the dial settings
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: sharedClient,
+	}
+}
+
+// NewHTTPClientFromSettings is NewHTTPClient honouring per-provider connection
+// overrides, for reaching a specific backend behind a shared address.
+// Recognised settings:
+//   - host_header:     Host header sent instead of the one in the request URL
+//   - tls_server_name: TLS SNI and certificate name (defaults to host_header's host)
+//   - connect_timeout: limit on establishing each connection, e.g. "5s"
+//   - user_agent:      User-Agent sent instead of the one set by SetUserAgent
+//
+// Without any of these settings the client shares the common transport; with
+// a connection setting it gets its own copy, since they are transport settings.
+// A user_agent alone keeps the shared connection pool.
+func NewHTTPClientFromSettings(settings map[string]interface{}, timeout time.Duration) *http.Client {
+	client := NewHTTPClient(timeout)
+
+	hostHeader, _ := settings["host_header"].(string)
+	serverName, _ := settings["tls_server_name"].(string)
+	agent, _ := settings["user_agent"].(string)
+	connectTimeout := connectTimeoutFromSettings(settings)
+	if hostHeader == "" && serverName == "" && agent == "" && connectTimeout <= 0 {
+		return client
+	}
+
+	shared := client.Transport.(*userAgentTransport)
+	if agent == "" {
+		agent = shared.userAgent
+	}
+	if hostHeader == "" && serverName == "" && connectTimeout <= 0 {
+		client.Transport = &userAgentTransport{base: shared.base, userAgent: agent}
+		return client
+	}
+
+	transport := shared.base.(*http.Transport).Clone()
+	if connectTimeout > 0 {
+		dial := transport.DialContext
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+			defer cancel()
+			return dial(ctx, network, addr)
+		}
+	}
+	client.Transport = &userAgentTransport{base: transport, userAgent: agent}
+	if hostHeader == "" && serverName == "" {
+		return client
+	}
+
+	if serverName == "" {
+		serverName = hostHeader
+		if host, _, err := net.SplitHostPort(hostHeader); err == nil {
+			serverName = host
+		}
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.ServerName = serverName
+	if hostHeader != "" {
+		client.Transport = &userAgentTransport{
+			base:      &hostOverrideTransport{base: transport, host: hostHeader},
+			userAgent: agent,
+		}
+	}
+	return client
+}
+
+// connectTimeoutFromSettings parses the connect_timeout setting, returning 0
+// when it is unset or not a valid duration
+func connectTimeoutFromSettings(settings map[string]interface{}) time.Duration {
+	switch v := settings["connect_timeout"].(type) {
+	case time.Duration:
+		return v
+	case string:
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return 0
+		}
+		return timeout
+	default:
+		return 0
+	}
+}
+
+// hostOverrideTransport sends every request with a fixed Host header
+type hostOverrideTransport struct {
+	base http.RoundTripper
+	host string
+}
+
+// RoundTrip sets the Host header on a copy of req, leaving the caller's request untouched
+func (t *hostOverrideTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	override := req.Clone(req.Context())
+	override.Host = t.host
+	return t.base.RoundTrip(override)
+}
+
+// userAgentTransport sets the User-Agent of requests that do not carry one
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip adds the User-Agent to a copy of req, leaving the caller's request untouched
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.base.RoundTrip(req)
+	}
+	withAgent := req.Clone(req.Context())
+	withAgent.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(withAgent)
+}
+
+// newTransport mirrors http.DefaultTransport with a tunable dialer
+func newTransport(cfg DialConfig) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:       30 * time.Second,
+		KeepAlive:     30 * time.Second,
+		FallbackDelay: cfg.FallbackDelay,
+		Resolver:      cfg.Resolver,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// newResolver returns a resolver that sends every query to server
+func newResolver(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: 5 * time.Second}
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// normalizeDNSServer validates a DNS server address and adds the default port
+func normalizeDNSServer(server string) (string, error) {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server, nil
+	}
+	if net.ParseIP(server) == nil {
+		return "", fmt.Errorf("invalid DNS server %q: expected an IP address or host:port", server)
+	}
+	return net.JoinHostPort(server, DNSPort), nil
+}
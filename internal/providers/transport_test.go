@@ -0,0 +1,197 @@
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConfigureTransport_UsesResolver(t *testing.T) {
+	var queries atomic.Int32
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			queries.Add(1)
+			return nil, errors.New("resolver unavailable")
+		},
+	}
+
+	if err := ConfigureTransport(DialConfig{Resolver: resolver, FallbackDelay: 50 * time.Millisecond}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer ConfigureTransport(DialConfig{})
+
+	client := NewHTTPClient(5 * time.Second)
+	if _, err := client.Get("http://uploads.woof-resolver-test.example/"); err == nil {
+		t.Fatal("expected the request to fail when the resolver is unavailable")
+	}
+	if queries.Load() == 0 {
+		t.Error("expected the custom resolver to be consulted")
+	}
+}
+
+func TestNormalizeDNSServer(t *testing.T) {
+	tests := map[string]string{
+		"1.1.1.1":         "1.1.1.1:53",
+		"1.1.1.1:5353":    "1.1.1.1:5353",
+		"2606:4700::1111": "[2606:4700::1111]:53",
+		"dns.local:53":    "dns.local:53",
+	}
+	for input, expected := range tests {
+		got, err := normalizeDNSServer(input)
+		if err != nil || got != expected {
+			t.Errorf("normalizeDNSServer(%q) = %q, %v; want %q", input, got, err, expected)
+		}
+	}
+
+	if _, err := normalizeDNSServer("not a server"); err == nil {
+		t.Error("expected an error for an invalid server")
+	}
+}
+
+func TestNewHTTPClientFromSettings_OverridesHost(t *testing.T) {
+	hosts := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts <- r.Host
+	}))
+	defer server.Close()
+
+	client := NewHTTPClientFromSettings(map[string]interface{}{"host_header": "staging.example.com"}, 5*time.Second)
+	resp, err := client.Get(server.URL + "/upload")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if host := <-hosts; host != "staging.example.com" {
+		t.Errorf("expected the overridden Host header, got %q", host)
+	}
+}
+
+func TestNewHTTPClientFromSettings_OverridesServerName(t *testing.T) {
+	serverNames := make(chan string, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverNames <- hello.ServerName
+			return nil, nil
+		},
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	// The test certificate is not trusted, only the handshake's SNI matters here
+	client := NewHTTPClientFromSettings(map[string]interface{}{"host_header": "staging.example.com:8443"}, 5*time.Second)
+	if resp, err := client.Get(server.URL); err == nil {
+		resp.Body.Close()
+	}
+
+	if name := <-serverNames; name != "staging.example.com" {
+		t.Errorf("expected SNI to follow host_header without its port, got %q", name)
+	}
+}
+
+func TestNewHTTPClientFromSettings_SharesTransportByDefault(t *testing.T) {
+	client := NewHTTPClientFromSettings(map[string]interface{}{}, 5*time.Second)
+	if client.Transport != NewHTTPClient(time.Second).Transport {
+		t.Error("expected providers without overrides to share the common transport")
+	}
+}
+
+func TestNewHTTPClientFromSettings_ConnectTimeoutGetsOwnTransport(t *testing.T) {
+	client := NewHTTPClientFromSettings(map[string]interface{}{"connect_timeout": "5s"}, 5*time.Second)
+	if client.Transport == NewHTTPClient(time.Second).Transport {
+		t.Error("expected connect_timeout to give the provider its own transport")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+}
+
+// userAgentServer reports the User-Agent of every request it receives
+func userAgentServer(t *testing.T) (*httptest.Server, chan string) {
+	agents := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents <- r.Header.Get("User-Agent")
+	}))
+	t.Cleanup(server.Close)
+	return server, agents
+}
+
+func TestNewHTTPClient_SendsUserAgent(t *testing.T) {
+	server, agents := userAgentServer(t)
+
+	SetUserAgent("woof/1.2.3")
+	defer SetUserAgent("")
+
+	resp, err := NewHTTPClient(5 * time.Second).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if agent := <-agents; agent != "woof/1.2.3" {
+		t.Errorf("expected the configured User-Agent, got %q", agent)
+	}
+
+	// A header set on the request itself is left alone
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("User-Agent", "custom/1.0")
+	if resp, err = NewHTTPClient(5 * time.Second).Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if agent := <-agents; agent != "custom/1.0" {
+		t.Errorf("expected the request's User-Agent, got %q", agent)
+	}
+}
+
+func TestNewHTTPClientFromSettings_UserAgentOverride(t *testing.T) {
+	server, agents := userAgentServer(t)
+
+	SetUserAgent("woof/1.2.3")
+	defer SetUserAgent("")
+
+	for _, settings := range []map[string]interface{}{
+		{"user_agent": "mirror-bot/2.0"},
+		{"user_agent": "mirror-bot/2.0", "connect_timeout": "5s"},
+		{"user_agent": "mirror-bot/2.0", "host_header": "staging.example.com"},
+	} {
+		resp, err := NewHTTPClientFromSettings(settings, 5*time.Second).Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if agent := <-agents; agent != "mirror-bot/2.0" {
+			t.Errorf("%v: expected the provider's User-Agent, got %q", settings, agent)
+		}
+	}
+
+	// Without user_agent a provider with its own transport keeps the default
+	resp, err := NewHTTPClientFromSettings(map[string]interface{}{"connect_timeout": "5s"}, 5*time.Second).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if agent := <-agents; agent != "woof/1.2.3" {
+		t.Errorf("expected the default User-Agent, got %q", agent)
+	}
+}
+
+func TestNewHTTPClientFromSettings_UserAgentSharesConnections(t *testing.T) {
+	client := NewHTTPClientFromSettings(map[string]interface{}{"user_agent": "mirror-bot/2.0"}, 5*time.Second)
+	shared := NewHTTPClient(time.Second).Transport.(*userAgentTransport)
+	if client.Transport.(*userAgentTransport).base != shared.base {
+		t.Error("expected a user_agent alone to keep the shared connection pool")
+	}
+}
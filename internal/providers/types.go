@@ -41,6 +41,7 @@ type ProviderError struct {
 	Code      string    `json:"code"`      // Provider-specific error code
 	Message   string    `json:"message"`   // Human-readable error message
 	Retryable bool      `json:"retryable"` // Whether this error is retryable
+	RetryAfter time.Duration `json:"retry_after,omitempty"` // Wait the server asked for before retrying, 0 when not given
 	Cause     error     `json:"-"`         // Original error for logging
 }
 
@@ -121,4 +122,21 @@ func GetErrorType(err error) ErrorType {
 		return provErr.Type
 	}
 	return ErrorTypeUnknown
-}
\ No newline at end of file
+}
+// RootErrorType returns the most specific ErrorType in the error chain. Wrappers
+// such as the temporary error reported after retry exhaustion are looked through
+// so the underlying cause, e.g. a quota or network error, is reported instead.
+func RootErrorType(err error) ErrorType {
+	result := ErrorTypeUnknown
+	for err != nil {
+		var provErr *ProviderError
+		if !errors.As(err, &provErr) {
+			break
+		}
+		if provErr.Type != ErrorTypeUnknown && (result == ErrorTypeUnknown || result == ErrorTypeTemporary) {
+			result = provErr.Type
+		}
+		err = provErr.Cause
+	}
+	return result
+}
@@ -4,26 +4,36 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/timefmt"
 	"github.com/sirupsen/logrus"
 )
 
-// Provider interface for consistency wrapper (minimal interface to avoid circular dependency)
-type Provider interface {
-	Name() string
-	Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error)
-	ValidateFile(ctx context.Context, filePath string, size int64) error
-	GetMaxFileSize() int64
-	GetSupportedExtensions() []string
-}
+var (
+	_ Provider        = (*ConsistencyWrapper)(nil)
+	_ TimeoutProvider = (*ConsistencyWrapper)(nil)
+	_ Initializer        = (*ConsistencyWrapper)(nil)
+	_ ConcurrencyLimiter = (*ConsistencyWrapper)(nil)
+	_ OptionsUploader    = (*ConsistencyWrapper)(nil)
+	_ Chunked            = (*ConsistencyWrapper)(nil)
+	_ HealthChecker      = (*ConsistencyWrapper)(nil)
+)
 
 // ConsistencyWrapper wraps providers to ensure standardized behavior
 type ConsistencyWrapper struct {
 	provider Provider
 	config   WrapperConfig
+
+	// Jitter draws from its own source so tests can make delays reproducible
+	jitterMu sync.Mutex
+	jitter   *rand.Rand
 }
 
 // WrapperConfig defines configuration for the consistency wrapper
@@ -34,6 +44,10 @@ type WrapperConfig struct {
 	// Enable response validation
 	ValidateResponses bool `json:"validate_responses"`
 
+	// Also require the response URL to be an absolute http(s) URL and any
+	// expiry to be in the future; only applies with ValidateResponses
+	StrictResponseValidation bool `json:"strict_response_validation"`
+
 	// Enable automatic retries for retryable errors
 	AutoRetry bool `json:"auto_retry"`
 
@@ -43,6 +57,23 @@ type WrapperConfig struct {
 	// Delay between retries
 	RetryDelay time.Duration `json:"retry_delay"`
 
+	// Shape of the delay growth between retries
+	Backoff BackoffStrategy `json:"backoff"`
+
+	// Step multiplier for linear backoff, growth factor for exponential backoff;
+	// 0 uses the strategy's default, 1 for linear and 2 for exponential
+	BackoffFactor float64 `json:"backoff_factor"`
+
+	// Upper bound for a single retry delay (0 for no cap)
+	MaxRetryDelay time.Duration `json:"max_retry_delay"`
+
+	// Randomize each delay to avoid synchronized retries
+	Jitter bool `json:"jitter"`
+
+	// Stop retrying once this much time has passed since the first attempt,
+	// whatever MaxRetries allows (0 for no limit)
+	MaxRetryElapsed time.Duration `json:"max_retry_elapsed"`
+
 	// Enable response enhancement (add standard metadata)
 	EnhanceResponses bool `json:"enhance_responses"`
 
@@ -58,6 +89,10 @@ func DefaultWrapperConfig() WrapperConfig {
 		AutoRetry:           true,
 		MaxRetries:          3,
 		RetryDelay:          2 * time.Second,
+		Backoff:             BackoffExponential,
+		BackoffFactor:       0,
+		MaxRetryDelay:       time.Minute,
+		Jitter:              true,
 		EnhanceResponses:    true,
 		CheckCapabilities:   true,
 	}
@@ -68,9 +103,18 @@ func NewConsistencyWrapper(provider Provider, config WrapperConfig) *Consistency
 	return &ConsistencyWrapper{
 		provider: provider,
 		config:   config,
+		jitter:   rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// SetJitterSource replaces the random source used to jitter retry delays,
+// e.g. with rand.NewSource(seed) for a reproducible delay sequence
+func (cw *ConsistencyWrapper) SetJitterSource(source rand.Source) {
+	cw.jitterMu.Lock()
+	defer cw.jitterMu.Unlock()
+	cw.jitter = rand.New(source)
+}
+
 // Name returns the wrapped provider's name
 func (cw *ConsistencyWrapper) Name() string {
 	return cw.provider.Name()
@@ -86,13 +130,84 @@ func (cw *ConsistencyWrapper) GetSupportedExtensions() []string {
 	return cw.provider.GetSupportedExtensions()
 }
 
-// ValidateFile validates a file using the wrapped provider's validation
+// GetTimeout returns the wrapped provider's timeout, or 0 if it does not expose one
+func (cw *ConsistencyWrapper) GetTimeout() time.Duration {
+	if tp, ok := cw.provider.(TimeoutProvider); ok {
+		return tp.GetTimeout()
+	}
+	return 0
+}
+
+// MaxConcurrency returns the wrapped provider's concurrency limit, or 0 if it declares none
+func (cw *ConsistencyWrapper) MaxConcurrency() int {
+	if cl, ok := cw.provider.(ConcurrencyLimiter); ok {
+		return cl.MaxConcurrency()
+	}
+	return 0
+}
+
+// Weight returns the wrapped provider's scheduling weight
+func (cw *ConsistencyWrapper) Weight() int {
+	return ProviderWeight(cw.provider)
+}
+
+// AcceptsUnknownLength reports the wrapped provider's support for bodies of unknown length
+func (cw *ConsistencyWrapper) AcceptsUnknownLength() bool {
+	return AcceptsUnknownLength(cw.provider)
+}
+
+// SupportsChunks reports the wrapped provider's support for chunked uploads
+func (cw *ConsistencyWrapper) SupportsChunks() bool {
+	return SupportsChunks(cw.provider)
+}
+
+// UploadChunk passes a chunk to the wrapped provider. Chunks are retried one
+// at a time by the caller, so the wrapper adds no retries of its own.
+func (cw *ConsistencyWrapper) UploadChunk(ctx context.Context, filePath string, chunk io.Reader, offset, length, size int64) (*ProviderResponse, error) {
+	chunked, ok := cw.provider.(Chunked)
+	if !ok {
+		return nil, NewUnsupportedError(fmt.Sprintf("provider %s does not support chunked uploads", cw.provider.Name()), nil)
+	}
+	return chunked.UploadChunk(ctx, filePath, chunk, offset, length, size)
+}
+
+// Initialize runs the wrapped provider's one-time setup, if it has any
+func (cw *ConsistencyWrapper) Initialize(ctx context.Context) error {
+	if init, ok := cw.provider.(Initializer); ok {
+		return init.Initialize(ctx)
+	}
+	return nil
+}
+
+// Delete removes a file through the wrapped provider, if it supports deletion
+func (cw *ConsistencyWrapper) Delete(ctx context.Context, deleteToken string) error {
+	return DeleteFile(ctx, cw.provider, deleteToken)
+}
+
+// HealthCheck runs the wrapped provider's health check, returning
+// ErrNoHealthCheck when it has none
+func (cw *ConsistencyWrapper) HealthCheck(ctx context.Context) error {
+	return CheckHealth(ctx, cw.provider)
+}
+
+// ValidateFile validates a file using the wrapped provider's validation. A
+// dry run never reaches Upload, so it also gets the pre-upload checks Upload
+// would apply
 func (cw *ConsistencyWrapper) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	if IsDryRun(ctx) && cw.config.PreUploadValidation {
+		return cw.validateUploadCapability(ctx, filePath, size)
+	}
 	return cw.provider.ValidateFile(ctx, filePath, size)
 }
 
 // Upload wraps the provider's Upload method with consistency features
 func (cw *ConsistencyWrapper) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	return cw.UploadWithOptions(ctx, file, UploadOptions{FilePath: filePath, Size: size})
+}
+
+// UploadWithOptions is Upload taking UploadOptions, passed on to providers that accept them
+func (cw *ConsistencyWrapper) UploadWithOptions(ctx context.Context, file io.Reader, opts UploadOptions) (*ProviderResponse, error) {
+	filePath, size := opts.FilePath, opts.Size
 	logging.Debug("Provider upload start", logrus.Fields{
 		"provider": cw.provider.Name(),
 		"filepath": filePath,
@@ -118,9 +233,12 @@ func (cw *ConsistencyWrapper) Upload(ctx context.Context, filePath string, file
 	var err error
 
 	if cw.config.AutoRetry {
-		response, err = cw.uploadWithRetry(ctx, filePath, file, size)
+		response, err = cw.uploadWithRetry(ctx, file, opts)
 	} else {
-		response, err = cw.provider.Upload(ctx, filePath, file, size)
+		response, err = UploadWithOptions(ctx, cw.provider, file, opts)
+		if err == nil {
+			response = withAttempts(response, 1)
+		}
 	}
 
 	// Add metadata if enabled
@@ -199,8 +317,10 @@ func (cw *ConsistencyWrapper) validateUploadCapability(ctx context.Context, file
 }
 
 // uploadWithRetry implements retry logic for uploads
-func (cw *ConsistencyWrapper) uploadWithRetry(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+func (cw *ConsistencyWrapper) uploadWithRetry(ctx context.Context, file io.Reader, opts UploadOptions) (*ProviderResponse, error) {
+	filePath := opts.FilePath
 	var lastError error
+	start := time.Now()
 
 	for attempt := 0; attempt <= cw.config.MaxRetries; attempt++ {
 		if attempt > 0 {
@@ -211,16 +331,53 @@ func (cw *ConsistencyWrapper) uploadWithRetry(ctx context.Context, filePath stri
 				"filepath": filePath,
 			})
 
-			// Wait before retry
+			// Wait before retry, as long as the server asked for when it said
+			delay := cw.retryDelay(attempt)
+			if retryAfter := RetryAfterOf(lastError); retryAfter > 0 {
+				delay = retryAfter
+			}
+			// Give up rather than wait past the retry budget
+			if elapsed := time.Since(start); cw.config.MaxRetryElapsed > 0 && elapsed+delay > cw.config.MaxRetryElapsed {
+				logging.Debug("Provider retry budget exhausted", logrus.Fields{
+					"provider": cw.provider.Name(),
+					"attempt": attempt,
+					"elapsed": elapsed.String(),
+					"filepath": filePath,
+				})
+				return nil, NewTemporaryError(
+					fmt.Sprintf("retry budget of %s exhausted after %d attempts", cw.config.MaxRetryElapsed, attempt),
+					lastError,
+				)
+			}
 			select {
 			case <-ctx.Done():
 				return nil, NewTemporaryError("context cancelled during retry", ctx.Err())
-			case <-time.After(cw.config.RetryDelay * time.Duration(attempt-1)):
-				// Exponential backoff
+			case <-time.After(delay):
+			}
+
+			// The previous attempt consumed the body; rewind it or give up
+			seeker, ok := file.(io.Seeker)
+			if !ok {
+				logging.Debug("Provider retry skipped, body not rewindable", logrus.Fields{
+					"provider": cw.provider.Name(),
+					"filepath": filePath,
+				})
+				return nil, lastError
+			}
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, NewNetworkError("failed to rewind file for retry", err)
 			}
+
+			notifyRetry(ctx, RetryEvent{
+				Provider:   cw.provider.Name(),
+				FilePath:   filePath,
+				Retry:      attempt,
+				MaxRetries: cw.config.MaxRetries,
+				Err:        lastError,
+			})
 		}
 
-		response, err := cw.provider.Upload(ctx, filePath, file, size)
+		response, err := UploadWithOptions(ctx, cw.provider, file, opts)
 
 		if err != nil {
 			lastError = err
@@ -246,6 +403,7 @@ func (cw *ConsistencyWrapper) uploadWithRetry(ctx context.Context, filePath stri
 		}
 
 		// Success
+		response = withAttempts(response, attempt+1)
 		if attempt > 0 {
 			logging.Debug("Provider retry success", logrus.Fields{
 				"provider": cw.provider.Name(),
@@ -271,19 +429,68 @@ func (cw *ConsistencyWrapper) uploadWithRetry(ctx context.Context, filePath stri
 	)
 }
 
-// addMetadata adds standard metadata and ensures response consistency
-func (cw *ConsistencyWrapper) addMetadata(response *ProviderResponse, filePath string, size int64) *ProviderResponse {
-	// Ensure metadata exists
-	if response.Metadata == nil {
-		response.Metadata = make(map[string]string)
+// retryDelay computes the wait before the given retry from the configured strategy.
+// With jitter enabled the delay is drawn uniformly from [0, delay) (full
+// jitter), so clients that failed together spread their retries out.
+func (cw *ConsistencyWrapper) retryDelay(retry int) time.Duration {
+	delay := BackoffDelay(cw.config.Backoff, cw.config.RetryDelay, cw.config.BackoffFactor, cw.config.MaxRetryDelay, retry)
+	if !cw.config.Jitter || delay <= 0 {
+		return delay
 	}
 
+	cw.jitterMu.Lock()
+	defer cw.jitterMu.Unlock()
+	return time.Duration(cw.jitter.Int63n(int64(delay)))
+}
+
+// wrapperMetadataPrefix starts every response metadata key the wrapper adds,
+// so wrapper keys never collide with keys set by the provider
+const wrapperMetadataPrefix = "wrapper_"
+
+// MetadataAttempts is the response metadata key holding how many attempts an
+// upload took through the wrapper; "1" means it succeeded on the first try
+const MetadataAttempts = wrapperMetadataPrefix + "attempts"
+
+// withAttempts returns response with the attempt count in its metadata
+func withAttempts(response *ProviderResponse, attempts int) *ProviderResponse {
+	return withWrapperMetadata(response, map[string]string{
+		MetadataAttempts: strconv.Itoa(attempts),
+	})
+}
+
+// withWrapperMetadata returns a copy of response whose metadata is a copy of
+// the provider's plus values. The provider's response and map are never
+// written, so one the provider shares between uploads cannot race, and a key
+// the provider already set keeps its value.
+func withWrapperMetadata(response *ProviderResponse, values map[string]string) *ProviderResponse {
+	if response == nil {
+		return nil
+	}
+
+	enhanced := *response
+	enhanced.Metadata = make(map[string]string, len(response.Metadata)+len(values))
+	for key, value := range response.Metadata {
+		enhanced.Metadata[key] = value
+	}
+	for key, value := range values {
+		if _, exists := enhanced.Metadata[key]; !exists {
+			enhanced.Metadata[key] = value
+		}
+	}
+	return &enhanced
+}
+
+// addMetadata adds standard metadata and ensures response consistency. It
+// returns a copy and leaves the provider's response untouched.
+func (cw *ConsistencyWrapper) addMetadata(response *ProviderResponse, filePath string, size int64) *ProviderResponse {
 	// Add standard metadata
-	response.Metadata["wrapper_provider"] = cw.provider.Name()
-	response.Metadata["wrapper_version"] = "1.0"
-	response.Metadata["upload_timestamp"] = time.Now().Format(time.RFC3339)
-	response.Metadata["original_filepath"] = filePath
-	response.Metadata["upload_size"] = fmt.Sprintf("%d", size)
+	response = withWrapperMetadata(response, map[string]string{
+		wrapperMetadataPrefix + "provider":          cw.provider.Name(),
+		wrapperMetadataPrefix + "version":           "1.0",
+		wrapperMetadataPrefix + "upload_timestamp":  timefmt.Timestamp(time.Now()),
+		wrapperMetadataPrefix + "original_filepath": filePath,
+		wrapperMetadataPrefix + "upload_size":       fmt.Sprintf("%d", size),
+	})
 
 	// Ensure URL is set
 	if response.URL == "" && response.DownloadURL != "" {
@@ -303,16 +510,35 @@ func (cw *ConsistencyWrapper) addMetadata(response *ProviderResponse, filePath s
 // validateResponse ensures the response meets minimum requirements
 func (cw *ConsistencyWrapper) validateResponse(response *ProviderResponse) error {
 	if response == nil {
-		return NewAPIError("NULL_RESPONSE", "provider returned null response", nil)
+		return ErrNullResponse()
 	}
 
 	if response.URL == "" {
-		return NewAPIError("MISSING_URL", "provider response missing download URL", nil)
+		return ErrMissingURL()
+	}
+
+	if cw.config.StrictResponseValidation {
+		if !isAbsoluteHTTPURL(response.URL) {
+			return ErrInvalidResponseURL(response.URL)
+		}
+		if response.Expires != nil && !response.Expires.After(time.Now()) {
+			return ErrAlreadyExpired(*response.Expires)
+		}
 	}
 
 	return nil
 }
 
+// isAbsoluteHTTPURL reports whether raw parses as an http or https URL with a host
+func isAbsoluteHTTPURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	return scheme == "http" || scheme == "https"
+}
+
 // isRetryableError checks if an error should be retried
 func (cw *ConsistencyWrapper) isRetryableError(err error) bool {
 	if err == nil {
@@ -321,8 +547,15 @@ func (cw *ConsistencyWrapper) isRetryableError(err error) bool {
 
 	errorType := GetErrorType(err)
 	switch errorType {
-	case ErrorTypeNetwork, ErrorTypeTemporary, ErrorTypeQuota:
+	case ErrorTypeNetwork, ErrorTypeTemporary:
 		return true
+	case ErrorTypeQuota:
+		// Recognised provider limits do not clear within the retry delays unless
+		// the server says when to come back, and that wait is one the run accepts
+		if !IsRetryable(err) {
+			return false
+		}
+		return cw.config.MaxRetryDelay <= 0 || RetryAfterOf(err) <= cw.config.MaxRetryDelay
 	case ErrorTypeAPI, ErrorTypeAuthentication, ErrorTypeFileTooLarge, ErrorTypeUnsupported:
 		return false
 	default:
@@ -0,0 +1,228 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyProvider drains the body and fails the first attempt with a retryable error
+type flakyProvider struct {
+	attempts []string
+}
+
+func (p *flakyProvider) Name() string { return "flaky" }
+
+func (p *flakyProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	p.attempts = append(p.attempts, string(data))
+	if len(p.attempts) == 1 {
+		return nil, NewNetworkError("connection reset", nil)
+	}
+	return &ProviderResponse{URL: "https://example.com/file"}, nil
+}
+
+func (p *flakyProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *flakyProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *flakyProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+func retryTestConfig() WrapperConfig {
+	config := DefaultWrapperConfig()
+	config.RetryDelay = time.Millisecond
+	config.Jitter = false
+	return config
+}
+
+func TestUploadWithRetry_RewindsSeekableBody(t *testing.T) {
+	provider := &flakyProvider{}
+	wrapper := NewConsistencyWrapper(provider, retryTestConfig())
+
+	_, err := wrapper.Upload(context.Background(), "a.txt", bytes.NewReader([]byte("payload")), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(provider.attempts) != 2 || provider.attempts[1] != "payload" {
+		t.Errorf("expected the retry to resend the full body, got %q", provider.attempts)
+	}
+}
+
+func TestUploadWithRetry_DoesNotResendDrainedBody(t *testing.T) {
+	provider := &flakyProvider{}
+	wrapper := NewConsistencyWrapper(provider, retryTestConfig())
+
+	// strings.NewReader is seekable, so hide it behind a plain io.Reader
+	body := io.MultiReader(strings.NewReader("payload"))
+	if _, err := wrapper.Upload(context.Background(), "a.txt", body, 7); err == nil {
+		t.Fatal("expected the original error when the body cannot be rewound")
+	}
+	if len(provider.attempts) != 1 {
+		t.Errorf("expected a single attempt, got %d", len(provider.attempts))
+	}
+}
+
+func TestUploadWithRetry_RecordsAttempts(t *testing.T) {
+	provider := &flakyProvider{}
+	wrapper := NewConsistencyWrapper(provider, retryTestConfig())
+
+	response, err := wrapper.Upload(context.Background(), "a.txt", bytes.NewReader([]byte("payload")), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := response.Metadata[MetadataAttempts]; got != "2" {
+		t.Errorf("expected 2 attempts recorded, got %q", got)
+	}
+}
+
+// failingProvider fails every attempt with a retryable error
+type failingProvider struct {
+	flakyProvider
+}
+
+func (p *failingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	p.attempts = append(p.attempts, filePath)
+	return nil, NewNetworkError("connection reset", nil)
+}
+
+func TestUploadWithRetry_StopsAtElapsedBudget(t *testing.T) {
+	provider := &failingProvider{}
+	config := retryTestConfig()
+	config.MaxRetries = 100
+	config.RetryDelay = 20 * time.Millisecond
+	config.Backoff = BackoffConstant
+	config.MaxRetryElapsed = 100 * time.Millisecond
+	wrapper := NewConsistencyWrapper(provider, config)
+
+	start := time.Now()
+	_, err := wrapper.Upload(context.Background(), "a.txt", bytes.NewReader([]byte("payload")), 7)
+	elapsed := time.Since(start)
+	if err == nil || !strings.Contains(err.Error(), "retry budget") {
+		t.Fatalf("expected a retry budget error, got %v", err)
+	}
+	// Allow for scheduling slack on the last wait
+	if elapsed > config.MaxRetryElapsed+50*time.Millisecond {
+		t.Errorf("expected retries to stop within %v, took %v", config.MaxRetryElapsed, elapsed)
+	}
+	if attempts := len(provider.attempts); attempts < 2 || attempts > 6 {
+		t.Errorf("expected the budget to allow a few attempts, got %d", attempts)
+	}
+}
+
+// fixedProvider returns the same response for every upload
+type fixedProvider struct {
+	response ProviderResponse
+}
+
+func (p *fixedProvider) Name() string { return "fixed" }
+
+func (p *fixedProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	response := p.response
+	return &response, nil
+}
+
+func (p *fixedProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *fixedProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *fixedProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+func strictTestConfig() WrapperConfig {
+	config := retryTestConfig()
+	config.StrictResponseValidation = true
+	return config
+}
+
+func TestValidateResponse_StrictRejectsRelativeURL(t *testing.T) {
+	provider := &fixedProvider{response: ProviderResponse{URL: "/d/abc123"}}
+
+	_, err := NewConsistencyWrapper(provider, strictTestConfig()).Upload(context.Background(), "a.txt", strings.NewReader("x"), 1)
+	var provErr *ProviderError
+	if !errors.As(err, &provErr) || provErr.Code != CodeInvalidURL {
+		t.Errorf("expected an %s error for a relative URL, got %v", CodeInvalidURL, err)
+	}
+
+	if _, err := NewConsistencyWrapper(provider, retryTestConfig()).Upload(context.Background(), "a.txt", strings.NewReader("x"), 1); err != nil {
+		t.Errorf("expected the default validation to accept the URL, got %v", err)
+	}
+}
+
+func TestValidateResponse_StrictRejectsPastExpiry(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	provider := &fixedProvider{response: ProviderResponse{URL: "https://example.com/abc", Expires: &expired}}
+
+	_, err := NewConsistencyWrapper(provider, strictTestConfig()).Upload(context.Background(), "a.txt", strings.NewReader("x"), 1)
+	var provErr *ProviderError
+	if !errors.As(err, &provErr) || provErr.Code != CodeAlreadyExpired {
+		t.Errorf("expected an %s error for a past expiry, got %v", CodeAlreadyExpired, err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	provider.response.Expires = &future
+	if _, err := NewConsistencyWrapper(provider, strictTestConfig()).Upload(context.Background(), "a.txt", strings.NewReader("x"), 1); err != nil {
+		t.Errorf("expected a future expiry to pass, got %v", err)
+	}
+}
+
+// sharedProvider returns the same response, and so the same metadata map, for every upload
+type sharedProvider struct {
+	fixedProvider
+	shared *ProviderResponse
+}
+
+func (p *sharedProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*ProviderResponse, error) {
+	return p.shared, nil
+}
+
+// Run with -race to catch writes to the shared map
+func TestAddMetadata_ConcurrentUploadsShareNoState(t *testing.T) {
+	provider := &sharedProvider{shared: &ProviderResponse{
+		URL:      "https://example.com/abc",
+		Metadata: map[string]string{"upload_size": "42", "wrapper_version": "provider"},
+	}}
+	wrapper := NewConsistencyWrapper(provider, retryTestConfig())
+
+	var wg sync.WaitGroup
+	responses := make([]*ProviderResponse, 32)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, err := wrapper.Upload(context.Background(), fmt.Sprintf("file-%d.txt", i), strings.NewReader("x"), 1)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			responses[i] = response
+		}(i)
+	}
+	wg.Wait()
+
+	if len(provider.shared.Metadata) != 2 {
+		t.Errorf("the provider's metadata map was modified: %v", provider.shared.Metadata)
+	}
+	for i, response := range responses {
+		if response == nil {
+			continue
+		}
+		if response.Metadata["upload_size"] != "42" || response.Metadata["wrapper_version"] != "provider" {
+			t.Errorf("provider keys were overwritten: %v", response.Metadata)
+		}
+		if response.Metadata["wrapper_original_filepath"] != fmt.Sprintf("file-%d.txt", i) || response.Metadata[MetadataAttempts] != "1" {
+			t.Errorf("missing wrapper keys for upload %d: %v", i, response.Metadata)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package qrcode
+
+// matrix holds the modules being drawn and which of them belong to function
+// patterns, which data placement and masking must leave alone
+type matrix struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newMatrix(size int) *matrix {
+	m := &matrix{size: size, modules: make([][]bool, size), isFunction: make([][]bool, size)}
+	for y := 0; y < size; y++ {
+		m.modules[y] = make([]bool, size)
+		m.isFunction[y] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns and
+// version information, and reserves the format information area
+func (m *matrix) drawFunctionPatterns(version int) {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinderPattern(3, 3)
+	m.drawFinderPattern(m.size-4, 3)
+	m.drawFinderPattern(3, m.size-4)
+
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// The corners with finder patterns have no alignment pattern
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			m.drawAlignmentPattern(x, y)
+		}
+	}
+
+	m.drawFormatBits(0) // Placeholder, redrawn once the mask is chosen
+	m.drawVersion(version)
+}
+
+// drawFinderPattern draws a finder pattern and its separator centred on (x, y)
+func (m *matrix) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= m.size || yy < 0 || yy >= m.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			m.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws a 5x5 alignment pattern centred on (x, y)
+func (m *matrix) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPositions returns the row and column centres of alignment patterns
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	size := version*4 + 17
+	result := make([]int, numAlign)
+	result[0] = 6
+	for i, pos := numAlign-1, size-7; i >= 1; i, pos = i-1, pos-step {
+		result[i] = pos
+	}
+	return result
+}
+
+// formatBits returns the 15 bit format information for level M and mask
+func formatBits(mask int) int {
+	data := formatBitsM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawFormatBits draws both copies of the format information
+func (m *matrix) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true) // Always dark
+}
+
+// drawVersion draws both copies of the version information, present from version 7
+func (m *matrix) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 != 0
+		a, b := m.size-11+i%3, i/3
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codeword bits in the zigzag order of the standard,
+// two columns at a time from the bottom right, skipping function modules
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vert // Upward column pair
+				}
+				if m.isFunction[y][x] || i >= len(data)*8 {
+					continue
+				}
+				m.modules[y][x] = (data[i>>3]>>uint(7-i&7))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// maskApplies reports whether mask inverts the module at (x, y)
+func maskApplies(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask XORs the mask over all data modules; applying it twice undoes it
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if !m.isFunction[y][x] && maskApplies(mask, x, y) {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// Penalty weights of the mask evaluation rules
+const (
+	penaltyRun     = 3
+	penaltyBlock   = 3
+	penaltyFinder  = 40
+	penaltyBalance = 10
+)
+
+// finderLike is the 1:1:3:1:1 ratio with four light modules on one side
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// penalty scores the current modules; lower values scan more reliably
+func (m *matrix) penalty() int {
+	result := 0
+	dark := 0
+	get := func(x, y int, vertical bool) bool {
+		if vertical {
+			x, y = y, x
+		}
+		if x < 0 || x >= m.size || y < 0 || y >= m.size {
+			return false // The quiet zone is light
+		}
+		return m.modules[y][x]
+	}
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < m.size; y++ {
+			// Runs of five or more modules of the same colour
+			run := 1
+			for x := 1; x <= m.size; x++ {
+				if x < m.size && get(x, y, vertical) == get(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += penaltyRun + run - 5
+				}
+				run = 1
+			}
+
+			// Patterns that look like a finder
+			for x := -len(finderLike[0]) + 1; x < m.size; x++ {
+				for _, pattern := range finderLike {
+					matches := true
+					for k, want := range pattern {
+						if get(x+k, y, vertical) != want {
+							matches = false
+							break
+						}
+					}
+					if matches {
+						result += penaltyFinder
+					}
+				}
+			}
+		}
+	}
+
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			color := m.modules[y][x]
+			if color {
+				dark++
+			}
+			if x+1 < m.size && y+1 < m.size && color == m.modules[y][x+1] && color == m.modules[y+1][x] && color == m.modules[y+1][x+1] {
+				result += penaltyBlock
+			}
+		}
+	}
+
+	// Deviation from an even share of dark modules, in steps of 5%
+	total := m.size * m.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	result += k * penaltyBalance
+	return result
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
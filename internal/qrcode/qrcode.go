@@ -0,0 +1,176 @@
+// Package qrcode is a minimal QR code encoder: byte mode, error correction
+// level M, versions 1 to 40. It follows ISO/IEC 18004 and exists so woof can
+// print scannable links without an external dependency.
+package qrcode
+
+import (
+	"errors"
+)
+
+// ErrTooLong is returned when the text does not fit the largest QR version
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// Code is an encoded QR symbol without quiet zone. Modules[y][x] is true for
+// dark modules.
+type Code struct {
+	Version int
+	Size    int
+	Modules [][]bool
+}
+
+// eccCodewordsPerBlock and numBlocks hold the level M block structure per version
+var (
+	eccCodewordsPerBlock = [41]int{-1,
+		10, 16, 26, 18, 24, 16, 18, 22, 22, 26,
+		30, 22, 22, 24, 24, 28, 28, 26, 26, 26,
+		26, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+		28, 28, 28, 28, 28, 28, 28, 28, 28, 28}
+	numBlocks = [41]int{-1,
+		1, 1, 1, 2, 2, 4, 4, 4, 5, 5,
+		5, 8, 9, 9, 10, 10, 11, 13, 14, 16,
+		17, 17, 18, 20, 21, 23, 25, 26, 28, 29,
+		31, 33, 35, 37, 38, 40, 43, 45, 47, 49}
+)
+
+// formatBitsM identifies error correction level M in the format information
+const formatBitsM = 0
+
+// Encode returns the smallest QR code holding text
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+	for version := 1; version <= 40; version++ {
+		if capacity := numDataCodewords(version); len(data) <= byteCapacity(version, capacity) {
+			return encode(data, version), nil
+		}
+	}
+	return nil, ErrTooLong
+}
+
+// byteCapacity is how many bytes fit after the mode indicator and count
+func byteCapacity(version, dataCodewords int) int {
+	return (dataCodewords*8 - 4 - charCountBits(version)) / 8
+}
+
+// charCountBits is the width of the byte mode length field
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// numRawDataModules counts the modules available for codewords, including remainder bits
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// numDataCodewords is the number of codewords left for data after error correction
+func numDataCodewords(version int) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[version]*numBlocks[version]
+}
+
+func encode(data []byte, version int) *Code {
+	// Mode indicator, length, payload, terminator, then pad bytes
+	var bits bitBuffer
+	bits.append(0x4, 4)
+	bits.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	capacityBits := numDataCodewords(version) * 8
+	terminator := capacityBits - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacityBits; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := addECCAndInterleave(bits.bytes(), version)
+
+	size := version*4 + 17
+	m := newMatrix(size)
+	m.drawFunctionPatterns(version)
+	m.drawCodewords(codewords)
+
+	// Keep the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(mask)
+		if penalty := m.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		m.applyMask(mask) // XOR again to undo
+	}
+	m.applyMask(best)
+	m.drawFormatBits(best)
+
+	return &Code{Version: version, Size: size, Modules: m.modules}
+}
+
+// addECCAndInterleave splits data into blocks, appends Reed-Solomon codewords
+// to each and interleaves the blocks as the symbol stores them
+func addECCAndInterleave(data []byte, version int) []byte {
+	blocks := numBlocks[version]
+	eccLen := eccCodewordsPerBlock[version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := blocks - rawCodewords%blocks
+	shortBlockLen := rawCodewords / blocks
+
+	divisor := reedSolomonDivisor(eccLen)
+	var all [][]byte
+	for i, k := 0, 0; i < blocks; i++ {
+		n := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			n++
+		}
+		block := append([]byte(nil), data[k:k+n]...)
+		k += n
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			block = append(block, 0) // Placeholder so all blocks have equal length
+		}
+		all = append(all, append(block, ecc...))
+	}
+
+	var result []byte
+	for i := range all[0] {
+		for j, block := range all {
+			// Skip the placeholders of the short blocks
+			if i != shortBlockLen-eccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// bitBuffer accumulates bits most significant first
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>uint(i))&1 != 0)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package qrcode
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// decode reads the text back out of a level M byte mode code by reversing
+// every encoding step: format information, mask, placement and interleaving
+func decode(t *testing.T, code *Code) string {
+	t.Helper()
+	version := (code.Size - 17) / 4
+	functions := newMatrix(code.Size)
+	functions.drawFunctionPatterns(version)
+
+	// First copy of the format information
+	var raw int
+	read := func(x, y, i int) {
+		if code.Modules[y][x] {
+			raw |= 1 << uint(i)
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		read(8, i, i)
+	}
+	read(8, 7, 6)
+	read(8, 8, 7)
+	read(7, 8, 8)
+	for i := 9; i < 15; i++ {
+		read(14-i, 8, i)
+	}
+	format := raw ^ 0x5412
+	if level := format >> 13; level != formatBitsM {
+		t.Fatalf("expected error correction level M, got %d", level)
+	}
+	mask := (format >> 10) & 7
+	if formatBits(mask) != raw {
+		t.Fatalf("format information %015b fails its BCH check", raw)
+	}
+
+	// Collect the unmasked data bits in placement order
+	var bits bitBuffer
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < code.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = code.Size - 1 - vert
+				}
+				if !functions.isFunction[y][x] {
+					bits = append(bits, code.Modules[y][x] != maskApplies(mask, x, y))
+				}
+			}
+		}
+	}
+	codewords := bits[:len(bits)/8*8].bytes()
+
+	// Undo the interleaving, checking each block's error correction
+	blocks := numBlocks[version]
+	eccLen := eccCodewordsPerBlock[version]
+	numShortBlocks := blocks - len(codewords)%blocks
+	shortDataLen := len(codewords)/blocks - eccLen
+	dataBlocks := make([][]byte, blocks)
+	k := 0
+	for i := 0; i <= shortDataLen; i++ {
+		for j := range dataBlocks {
+			if i < shortDataLen || j >= numShortBlocks {
+				dataBlocks[j] = append(dataBlocks[j], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	divisor := reedSolomonDivisor(eccLen)
+	for j, block := range dataBlocks {
+		ecc := make([]byte, eccLen)
+		for i := range ecc {
+			ecc[i] = codewords[k+i*blocks+j]
+		}
+		if !reflect.DeepEqual(reedSolomonRemainder(block, divisor), ecc) {
+			t.Fatalf("block %d has wrong error correction codewords", j)
+		}
+		data = append(data, block...)
+	}
+
+	// Byte mode segment
+	if mode := data[0] >> 4; mode != 0x4 {
+		t.Fatalf("expected byte mode, got mode %d", mode)
+	}
+	var payload bitBuffer
+	for _, b := range data {
+		payload.append(int(b), 8)
+	}
+	countBits := charCountBits(version)
+	length := 0
+	for _, bit := range payload[4 : 4+countBits] {
+		length <<= 1
+		if bit {
+			length++
+		}
+	}
+	return string(payload[4+countBits : 4+countBits+length*8].bytes())
+}
+
+func TestEncode_DecodesBackToText(t *testing.T) {
+	texts := []string{
+		"https://example.com/a",
+		"https://buzzheavier.com/f/abcdefghijkl",
+		"https://files.catbox.moe/" + strings.Repeat("x", 120) + ".png",
+		strings.Repeat("https://example.com/long/", 40),
+	}
+	for _, text := range texts {
+		code, err := Encode(text)
+		if err != nil {
+			t.Fatalf("unexpected error for %d bytes: %v", len(text), err)
+		}
+		if code.Size != code.Version*4+17 || len(code.Modules) != code.Size {
+			t.Fatalf("version %d has inconsistent size %d", code.Version, code.Size)
+		}
+		if got := decode(t, code); got != text {
+			t.Errorf("version %d decoded to %q, want %q", code.Version, got, text)
+		}
+	}
+}
+
+func TestEncode_PicksSmallestVersion(t *testing.T) {
+	// Level M byte capacities: version 1 holds 14 bytes, version 2 holds 26
+	tests := map[int]int{1: 1, 14: 1, 15: 2, 26: 2, 27: 3, 2331: 40}
+	for length, version := range tests {
+		code, err := Encode(strings.Repeat("a", length))
+		if err != nil {
+			t.Fatalf("unexpected error for %d bytes: %v", length, err)
+		}
+		if code.Version != version {
+			t.Errorf("%d bytes: expected version %d, got %d", length, version, code.Version)
+		}
+	}
+	if _, err := Encode(strings.Repeat("a", 2332)); err != ErrTooLong {
+		t.Errorf("expected ErrTooLong, got %v", err)
+	}
+}
+
+func TestReferenceValues(t *testing.T) {
+	// Format information for level M, mask 0, and version information for version 7
+	if got := formatBits(0); got != 0x5412 {
+		t.Errorf("format bits: got %015b", got)
+	}
+	rem := 7
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	if got := 7<<12 | rem; got != 0x07C94 {
+		t.Errorf("version 7 information: got %018b", got)
+	}
+	if got := alignmentPositions(36); !reflect.DeepEqual(got, []int{6, 24, 50, 76, 102, 128, 154}) {
+		t.Errorf("version 36 alignment positions: got %v", got)
+	}
+
+	// Generator polynomial for 10 codewords, as exponents of alpha
+	exponents := []int{251, 67, 46, 61, 118, 70, 64, 94, 32, 45}
+	for i, coef := range reedSolomonDivisor(10) {
+		want := byte(1)
+		for j := 0; j < exponents[i]; j++ {
+			want = gfMultiply(want, 2)
+		}
+		if coef != want {
+			t.Errorf("generator coefficient %d: got %d, want alpha^%d = %d", i, coef, exponents[i], want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package timefmt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format selects how timestamps are rendered in metadata and output
+type Format string
+
+const (
+	FormatRFC3339 Format = "rfc3339" // 2006-01-02T15:04:05Z07:00
+	FormatUnix    Format = "unix"    // Seconds since the epoch
+	FormatLocal   Format = "local"   // 2006-01-02 15:04:05 MST, human readable
+)
+
+// localLayout is the layout used by FormatLocal
+const localLayout = "2006-01-02 15:04:05 MST"
+
+// ParseFormat parses a format name; an empty name selects FormatRFC3339
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case "", FormatRFC3339:
+		return FormatRFC3339, nil
+	case FormatUnix:
+		return FormatUnix, nil
+	case FormatLocal:
+		return FormatLocal, nil
+	default:
+		return "", fmt.Errorf("unknown time format %q (expected rfc3339, unix or local)", name)
+	}
+}
+
+// Formatter renders timestamps and durations consistently
+type Formatter struct {
+	Format Format
+	UTC    bool
+}
+
+// Timestamp renders t according to the formatter's settings
+func (f Formatter) Timestamp(t time.Time) string {
+	if f.UTC {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+
+	switch f.Format {
+	case FormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case FormatLocal:
+		return t.Format(localLayout)
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
+
+// Duration renders d rounded to milliseconds
+func (f Formatter) Duration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+var (
+	mu      sync.RWMutex
+	current = Formatter{Format: FormatRFC3339}
+)
+
+// SetDefault sets the formatter used by Timestamp and Duration
+func SetDefault(f Formatter) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = f
+}
+
+// Default returns the formatter used by Timestamp and Duration
+func Default() Formatter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Timestamp renders t with the default formatter
+func Timestamp(t time.Time) string {
+	return Default().Timestamp(t)
+}
+
+// Duration renders d with the default formatter
+func Duration(d time.Duration) string {
+	return Default().Duration(d)
+}
@@ -0,0 +1,62 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatter_Timestamp(t *testing.T) {
+	zone := time.FixedZone("CEST", 2*60*60)
+	ts := time.Date(2024, 5, 17, 14, 30, 0, 0, zone)
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		expected  string
+	}{
+		{name: "rfc3339 utc", formatter: Formatter{Format: FormatRFC3339, UTC: true}, expected: "2024-05-17T12:30:00Z"},
+		{name: "unix", formatter: Formatter{Format: FormatUnix}, expected: "1715949000"},
+		{name: "unix utc", formatter: Formatter{Format: FormatUnix, UTC: true}, expected: "1715949000"},
+		{name: "local layout utc", formatter: Formatter{Format: FormatLocal, UTC: true}, expected: "2024-05-17 12:30:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.Timestamp(ts); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatter_TimestampLocalZone(t *testing.T) {
+	original := time.Local
+	time.Local = time.FixedZone("EST", -5*60*60)
+	defer func() { time.Local = original }()
+
+	ts := time.Date(2024, 5, 17, 12, 30, 0, 0, time.UTC)
+	if got := (Formatter{Format: FormatRFC3339}).Timestamp(ts); got != "2024-05-17T07:30:00-05:00" {
+		t.Errorf("expected local RFC3339 time, got %q", got)
+	}
+	if got := (Formatter{Format: FormatLocal}).Timestamp(ts); got != "2024-05-17 07:30:00 EST" {
+		t.Errorf("expected local human readable time, got %q", got)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, expected := range map[string]Format{"": FormatRFC3339, "RFC3339": FormatRFC3339, "unix": FormatUnix, "local": FormatLocal} {
+		got, err := ParseFormat(name)
+		if err != nil || got != expected {
+			t.Errorf("ParseFormat(%q) = %q, %v; expected %q", name, got, err, expected)
+		}
+	}
+	if _, err := ParseFormat("iso"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	if got := Duration(1234567 * time.Microsecond); got != "1.235s" {
+		t.Errorf("expected duration rounded to milliseconds, got %q", got)
+	}
+}
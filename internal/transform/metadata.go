@@ -0,0 +1,234 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	jpegSOI      = []byte{0xFF, 0xD8}
+	pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+	tiffLE       = []byte{'I', 'I', 42, 0}
+	tiffBE       = []byte{'M', 'M', 0, 42}
+)
+
+// ErrMalformedImage is returned when an image claims a supported format but its structure is broken
+var ErrMalformedImage = errors.New("malformed image")
+
+// IsStrippable reports whether the file extension names a format StripMetadata understands
+func IsStrippable(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".jpg", ".jpeg", ".png", ".tif", ".tiff":
+		return true
+	}
+	return false
+}
+
+// StripMetadata removes EXIF, XMP and text metadata from JPEG, PNG and TIFF images
+// while leaving pixel data untouched. The format is detected from the content, not
+// the extension. It returns the original data and false for any other content.
+func StripMetadata(data []byte) ([]byte, bool, error) {
+	switch {
+	case bytes.HasPrefix(data, jpegSOI):
+		out, err := stripJPEG(data)
+		return out, err == nil, err
+	case bytes.HasPrefix(data, pngSignature):
+		out, err := stripPNG(data)
+		return out, err == nil, err
+	case bytes.HasPrefix(data, tiffLE), bytes.HasPrefix(data, tiffBE):
+		out, err := stripTIFF(data)
+		return out, err == nil, err
+	default:
+		return data, false, nil
+	}
+}
+
+// stripJPEG drops APP1 (EXIF/XMP) segments. Everything from the start-of-scan
+// marker onwards is entropy-coded image data and is copied verbatim.
+func stripJPEG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, jpegSOI...)
+
+	pos := len(jpegSOI)
+	for pos < len(data) {
+		if data[pos] != 0xFF || pos+1 >= len(data) {
+			return nil, fmt.Errorf("%w: expected JPEG marker at offset %d", ErrMalformedImage, pos)
+		}
+		marker := data[pos+1]
+
+		switch {
+		case marker == 0xFF:
+			// Fill byte before a marker
+			pos++
+			continue
+		case marker == 0xD9 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01:
+			// Markers without a length field
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		case marker == 0xDA:
+			// Start of scan: the rest of the file is image data
+			return append(out, data[pos:]...), nil
+		}
+
+		if pos+4 > len(data) {
+			return nil, fmt.Errorf("%w: truncated JPEG segment at offset %d", ErrMalformedImage, pos)
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+		if end > len(data) {
+			return nil, fmt.Errorf("%w: JPEG segment overruns file at offset %d", ErrMalformedImage, pos)
+		}
+
+		if marker != 0xE1 {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+
+	return out, nil
+}
+
+// pngMetadataChunks lists ancillary chunks that carry metadata rather than pixels
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG drops metadata chunks; chunk CRCs cover only the chunk itself so the
+// remaining chunks stay valid
+func stripPNG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("%w: truncated PNG chunk header at offset %d", ErrMalformedImage, pos)
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("%w: PNG chunk %s overruns file", ErrMalformedImage, chunkType)
+		}
+
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+// TIFF tags whose values are metadata rather than image structure
+const (
+	tiffTagXMP       = 700
+	tiffTagIPTC      = 33723
+	tiffTagPhotoshop = 34377
+	tiffTagExifIFD   = 34665
+	tiffTagGPSIFD    = 34853
+)
+
+// tiffTypeSizes maps TIFF field types to their size in bytes
+var tiffTypeSizes = map[uint16]int{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// stripTIFF unlinks metadata tags from the first IFD and zeroes the data they
+// referenced, including the entries and values of the EXIF and GPS sub-IFDs.
+// Offsets of the remaining image data are unchanged.
+func stripTIFF(data []byte) ([]byte, error) {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	if len(out) < 8 {
+		return nil, fmt.Errorf("%w: truncated TIFF header", ErrMalformedImage)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if out[0] == 'M' {
+		order = binary.BigEndian
+	}
+	ifd := int(order.Uint32(out[4:8]))
+	if ifd+2 > len(out) {
+		return nil, fmt.Errorf("%w: TIFF IFD offset out of range", ErrMalformedImage)
+	}
+
+	count := int(order.Uint16(out[ifd : ifd+2]))
+	entriesEnd := ifd + 2 + count*12
+	if entriesEnd+4 > len(out) {
+		return nil, fmt.Errorf("%w: TIFF IFD overruns file", ErrMalformedImage)
+	}
+	nextIFD := order.Uint32(out[entriesEnd : entriesEnd+4])
+
+	var kept [][]byte
+	for i := 0; i < count; i++ {
+		entry := out[ifd+2+i*12 : ifd+2+(i+1)*12]
+		switch order.Uint16(entry[0:2]) {
+		case tiffTagExifIFD, tiffTagGPSIFD:
+			zeroTIFFIFD(out, order, int(order.Uint32(entry[8:12])))
+		case tiffTagXMP, tiffTagIPTC, tiffTagPhotoshop:
+			zeroTIFFValue(out, order, entry)
+		default:
+			kept = append(kept, append([]byte(nil), entry...))
+		}
+	}
+
+	// Rewrite the directory in place with the remaining entries
+	region := out[ifd : entriesEnd+4]
+	for i := range region {
+		region[i] = 0
+	}
+	order.PutUint16(out[ifd:ifd+2], uint16(len(kept)))
+	for i, entry := range kept {
+		copy(out[ifd+2+i*12:], entry)
+	}
+	order.PutUint32(out[ifd+2+len(kept)*12:], nextIFD)
+
+	return out, nil
+}
+
+// zeroTIFFValue clears an entry's out-of-line value
+func zeroTIFFValue(data []byte, order binary.ByteOrder, entry []byte) {
+	size := tiffTypeSizes[order.Uint16(entry[2:4])] * int(order.Uint32(entry[4:8]))
+	if size <= 4 {
+		return // Value is stored inline in the entry itself
+	}
+	offset := int(order.Uint32(entry[8:12]))
+	if offset < 0 || offset+size > len(data) {
+		return
+	}
+	for i := offset; i < offset+size; i++ {
+		data[i] = 0
+	}
+}
+
+// zeroTIFFIFD clears a sub-IFD's entries and their values
+func zeroTIFFIFD(data []byte, order binary.ByteOrder, ifd int) {
+	if ifd <= 0 || ifd+2 > len(data) {
+		return
+	}
+	count := int(order.Uint16(data[ifd : ifd+2]))
+	end := ifd + 2 + count*12 + 4
+	if end > len(data) {
+		return
+	}
+	for i := 0; i < count; i++ {
+		zeroTIFFValue(data, order, data[ifd+2+i*12:ifd+2+(i+1)*12])
+	}
+	for i := ifd; i < end; i++ {
+		data[i] = 0
+	}
+}
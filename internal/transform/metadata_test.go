@@ -0,0 +1,166 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 30), G: uint8(y * 30), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// jpegWithEXIF encodes a JPEG and inserts an APP1 EXIF segment after SOI
+func jpegWithEXIF(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(), nil); err != nil {
+		t.Fatalf("failed to encode jpeg: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS 51.5074N 0.1278W")...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, encoded[:2]...)
+	out = append(out, segment...)
+	return append(out, encoded[2:]...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk[0:4], uint32(len(data)))
+	copy(chunk[4:8], chunkType)
+	chunk = append(chunk, data...)
+	crc := crc32.ChecksumIEEE(chunk[4:])
+	return binary.BigEndian.AppendUint32(chunk, crc)
+}
+
+func TestStripMetadata_JPEG(t *testing.T) {
+	original := jpegWithEXIF(t)
+	if !bytes.Contains(original, []byte("Exif\x00\x00")) {
+		t.Fatal("test image should contain EXIF")
+	}
+
+	stripped, ok, err := StripMetadata(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected JPEG to be recognised")
+	}
+	if bytes.Contains(stripped, []byte("Exif\x00\x00")) || bytes.Contains(stripped, []byte("GPS")) {
+		t.Error("stripped JPEG still contains EXIF data")
+	}
+
+	before, err := jpeg.Decode(bytes.NewReader(original))
+	if err != nil {
+		t.Fatalf("failed to decode original: %v", err)
+	}
+	after, err := jpeg.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatalf("stripped JPEG no longer decodes: %v", err)
+	}
+	if !bytes.Equal(before.(*image.YCbCr).Y, after.(*image.YCbCr).Y) {
+		t.Error("pixel data changed while stripping metadata")
+	}
+}
+
+func TestStripMetadata_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage()); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	encoded := buf.Bytes()
+
+	// Insert a tEXt chunk right after IHDR (signature + 25 byte IHDR chunk)
+	ihdrEnd := len(pngSignature) + 25
+	original := append([]byte{}, encoded[:ihdrEnd]...)
+	original = append(original, pngChunk("tEXt", []byte("Comment\x00secret location"))...)
+	original = append(original, encoded[ihdrEnd:]...)
+
+	stripped, ok, err := StripMetadata(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected PNG to be recognised")
+	}
+	if !bytes.Equal(stripped, encoded) {
+		t.Error("stripped PNG should match the image without the text chunk")
+	}
+	if _, err := png.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("stripped PNG no longer decodes: %v", err)
+	}
+}
+
+func TestStripMetadata_TIFF(t *testing.T) {
+	// Minimal little-endian TIFF: header, IFD0 with ImageWidth and a GPS IFD pointer,
+	// followed by a GPS IFD holding one out-of-line ASCII value
+	data := make([]byte, 0, 128)
+	data = append(data, 'I', 'I', 42, 0, 8, 0, 0, 0)
+	le := binary.LittleEndian
+	entry := func(tag, typ uint16, count, value uint32) []byte {
+		e := make([]byte, 12)
+		le.PutUint16(e[0:], tag)
+		le.PutUint16(e[2:], typ)
+		le.PutUint32(e[4:], count)
+		le.PutUint32(e[8:], value)
+		return e
+	}
+	// IFD0 at 8: 2 entries -> ends at 8+2+24+4 = 38
+	data = append(data, 2, 0)
+	data = append(data, entry(256, 3, 1, 8)...)
+	data = append(data, entry(tiffTagGPSIFD, 4, 1, 38)...)
+	data = append(data, 0, 0, 0, 0)
+	// GPS IFD at 38: 1 entry -> ends at 38+2+12+4 = 56, value at 56
+	secret := []byte("51.5074N\x00")
+	data = append(data, 1, 0)
+	data = append(data, entry(2, 2, uint32(len(secret)), 56)...)
+	data = append(data, 0, 0, 0, 0)
+	data = append(data, secret...)
+
+	stripped, ok, err := StripMetadata(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected TIFF to be recognised")
+	}
+	if bytes.Contains(stripped, []byte("51.5074N")) {
+		t.Error("stripped TIFF still contains GPS data")
+	}
+	if count := le.Uint16(stripped[8:10]); count != 1 {
+		t.Errorf("expected 1 remaining IFD0 entry, got %d", count)
+	}
+	if tag := le.Uint16(stripped[10:12]); tag != 256 {
+		t.Errorf("expected ImageWidth entry to be kept, got tag %d", tag)
+	}
+}
+
+func TestStripMetadata_PassesThroughOtherContent(t *testing.T) {
+	data := []byte("plain text, not an image")
+	out, ok, err := StripMetadata(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("non-image content should not be reported as stripped")
+	}
+	if !bytes.Equal(out, data) {
+		t.Error("non-image content should pass through untouched")
+	}
+}
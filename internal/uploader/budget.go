@@ -0,0 +1,122 @@
+package uploader
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrByteBudgetExhausted marks files that were skipped or aborted because the run's byte cap was reached
+var ErrByteBudgetExhausted = errors.New("byte budget exhausted")
+
+// byteBudget tracks bytes committed against the run's MaxTotalBytes cap. Starting a
+// file reserves its size; bytes sent beyond the reservation (retries, fallback
+// providers) are added as they are transferred and unused reservations are released.
+type byteBudget struct {
+	mu        sync.Mutex
+	limit     int64
+	committed int64
+}
+
+func newByteBudget(limit int64) *byteBudget {
+	if limit <= 0 {
+		return nil
+	}
+	return &byteBudget{limit: limit}
+}
+
+// Reserve commits size bytes, reporting false if that would exceed the cap
+func (b *byteBudget) Reserve(size int64) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.committed+size > b.limit {
+		return false
+	}
+	b.committed += size
+	return true
+}
+
+// Add commits bytes transferred beyond a reservation and reports whether the cap is now exceeded
+func (b *byteBudget) Add(n int64) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.committed += n
+	return b.committed > b.limit
+}
+
+// Release returns an unused part of a reservation
+func (b *byteBudget) Release(n int64) {
+	if b == nil || n <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.committed -= n
+}
+
+// Committed returns the bytes currently counted against the cap
+func (b *byteBudget) Committed() int64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.committed
+}
+
+// budgetAccount tracks one file's transfers against its reservation
+type budgetAccount struct {
+	budget   *byteBudget
+	reserved int64
+	sent     int64
+	abort    bool
+	aborted  bool
+}
+
+// Consume records n more bytes sent and reports whether the upload must stop
+func (a *budgetAccount) Consume(n int64) bool {
+	before := a.sent
+	a.sent += n
+	if a.sent <= a.reserved {
+		return false
+	}
+	extra := a.sent - max(before, a.reserved)
+	if a.budget.Add(extra) && a.abort {
+		a.aborted = true
+	}
+	return a.aborted
+}
+
+// Settle releases whatever part of the reservation was never transferred
+func (a *budgetAccount) Settle() {
+	a.budget.Release(a.reserved - a.sent)
+}
+
+// budgetReader charges every read to a budget account and fails once the cap is crossed in abort mode
+type budgetReader struct {
+	reader  io.Reader
+	account *budgetAccount
+}
+
+func (br *budgetReader) Read(p []byte) (int, error) {
+	n, err := br.reader.Read(p)
+	if br.account.Consume(int64(n)) {
+		return n, ErrByteBudgetExhausted
+	}
+	return n, err
+}
+
+// Seek rewinds the underlying reader; bytes read again are charged as extra transfer
+func (br *budgetReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := br.reader.(io.Seeker)
+	if !ok {
+		return 0, errors.New("budget reader: underlying reader is not seekable")
+	}
+	return seeker.Seek(offset, whence)
+}
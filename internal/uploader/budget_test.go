@@ -0,0 +1,140 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// drainThenFailProvider reads the whole body before failing, as a provider
+// rejecting the upload after transfer would
+type drainThenFailProvider struct {
+	*recordingProvider
+}
+
+func (p *drainThenFailProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("server rejected upload")
+}
+
+func writeFiles(t *testing.T, names []string, size int) []string {
+	t.Helper()
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, bytes.Repeat([]byte("x"), size), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestUpload_ByteBudgetSkipsRemainingFiles(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin", "c.bin"}, 100)
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency:   1,
+		Providers:     []Provider{newRecordingProvider("recorder")},
+		MaxTotalBytes: 250,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var succeeded, skipped int
+	for _, result := range collectResults(t, resultCh, progressCh) {
+		switch {
+		case result.Skipped:
+			skipped++
+			if !errors.Is(result.Error, ErrByteBudgetExhausted) {
+				t.Errorf("expected byte budget error, got %v", result.Error)
+			}
+		case result.Error != nil:
+			t.Errorf("unexpected upload error: %v", result.Error)
+		default:
+			succeeded++
+		}
+	}
+
+	if succeeded != 2 || skipped != 1 {
+		t.Errorf("expected 2 uploads and 1 skipped, got %d and %d", succeeded, skipped)
+	}
+}
+
+func TestUpload_ByteBudgetCrossedMidUpload(t *testing.T) {
+	tests := []struct {
+		name        string
+		abort       bool
+		wantAborted bool
+	}{
+		{name: "allowed to finish", abort: false, wantAborted: false},
+		{name: "aborted", abort: true, wantAborted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := writeFiles(t, []string{"a.bin"}, 100)
+
+			// The first provider consumes the full reservation, so the fallback crosses the cap
+			recorder := newRecordingProvider("recorder")
+			resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+				Concurrency:     1,
+				Providers:       []Provider{&drainThenFailProvider{newRecordingProvider("rejecting")}, recorder},
+				MaxTotalBytes:   150,
+				AbortOverBudget: tt.abort,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			results := collectResults(t, resultCh, progressCh)
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			result := results[0]
+
+			if tt.wantAborted {
+				if !errors.Is(result.Error, ErrByteBudgetExhausted) {
+					t.Errorf("expected upload to be aborted by the byte budget, got %v", result.Error)
+				}
+				if result.Skipped {
+					t.Error("a partially transferred upload should not be reported as skipped")
+				}
+				return
+			}
+			if result.Error != nil {
+				t.Errorf("expected upload to finish, got %v", result.Error)
+			}
+			if len(recorder.bodies["a.bin"]) != 100 {
+				t.Errorf("expected the full file at the fallback provider, got %d bytes", len(recorder.bodies["a.bin"]))
+			}
+		})
+	}
+}
+
+func TestByteBudget_ReleasesUnusedReservation(t *testing.T) {
+	budget := newByteBudget(100)
+	account := &budgetAccount{budget: budget, reserved: 80}
+	if !budget.Reserve(80) {
+		t.Fatal("expected reservation within the cap to succeed")
+	}
+	account.Consume(30)
+	account.Settle()
+
+	if got := budget.Committed(); got != 30 {
+		t.Errorf("expected only transferred bytes to remain committed, got %d", got)
+	}
+	if !budget.Reserve(70) {
+		t.Error("released bytes should be available to later files")
+	}
+}
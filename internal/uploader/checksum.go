@@ -0,0 +1,60 @@
+package uploader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+)
+
+// MetadataChecksum is the response metadata key holding the SHA-256 of the
+// uploaded content, set when UploadConfig.PreHash or Checksum is enabled
+const MetadataChecksum = "sha256"
+
+// ErrChecksumMismatch reports that the bytes sent differ from the prehashed
+// content, because the file changed while it was being uploaded
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// hashingReader computes a SHA-256 of the bytes read through it. A rewind
+// to the start restarts the digest so a retried body is only hashed once;
+// any other seek leaves the digest unusable.
+type hashingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	valid  bool
+}
+
+func newHashingReader(reader io.Reader) *hashingReader {
+	return &hashingReader{reader: reader, hash: sha256.New(), valid: true}
+}
+
+func (hr *hashingReader) Read(p []byte) (int, error) {
+	n, err := hr.reader.Read(p)
+	hr.hash.Write(p[:n])
+	return n, err
+}
+
+// Seek repositions the underlying reader and restarts the digest
+func (hr *hashingReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := hr.reader.(io.Seeker)
+	if !ok {
+		return 0, errors.New("hashing reader: underlying reader is not seekable")
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	hr.hash.Reset()
+	hr.valid = pos == 0
+	return pos, nil
+}
+
+// Sum returns the hex digest of everything read since the last rewind, or ""
+// when a seek to the middle of the body made it meaningless
+func (hr *hashingReader) Sum() string {
+	if !hr.valid {
+		return ""
+	}
+	return hex.EncodeToString(hr.hash.Sum(nil))
+}
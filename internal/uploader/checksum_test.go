@@ -0,0 +1,126 @@
+package uploader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// rewindingProvider reads part of the body, rewinds as a retry would, then reads it all
+type rewindingProvider struct {
+	*recordingProvider
+}
+
+func (p *rewindingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if _, err := io.CopyN(io.Discard, file, size/2); err != nil {
+		return nil, err
+	}
+	if _, err := file.(io.Seeker).Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return p.recordingProvider.Upload(ctx, filePath, file, size)
+}
+
+func uploadWithChecksum(t *testing.T, provider Provider, content string, checksum bool) UploadResult {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{path}, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{provider},
+		Checksum:    checksum,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("expected one successful result, got %+v", results)
+	}
+	return results[0]
+}
+
+func TestUpload_Checksum(t *testing.T) {
+	content := strings.Repeat("integrity ", 50000)
+	sum := sha256.Sum256([]byte(content))
+	expected := hex.EncodeToString(sum[:])
+
+	result := uploadWithChecksum(t, newRecordingProvider("recorder"), content, true)
+	if result.Checksum != expected {
+		t.Errorf("expected checksum %s, got %s", expected, result.Checksum)
+	}
+	if got := result.Response.Metadata[MetadataChecksum]; got != expected {
+		t.Errorf("expected sha256 metadata %s, got %q", expected, got)
+	}
+}
+
+func TestUpload_ChecksumRestartsOnRewind(t *testing.T) {
+	content := strings.Repeat("retried ", 1000)
+	sum := sha256.Sum256([]byte(content))
+
+	result := uploadWithChecksum(t, &rewindingProvider{newRecordingProvider("retrying")}, content, true)
+	if result.Checksum != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected the checksum of one full pass, got %s", result.Checksum)
+	}
+}
+
+func TestUpload_ChecksumOffByDefault(t *testing.T) {
+	result := uploadWithChecksum(t, newRecordingProvider("recorder"), "content", false)
+	if result.Checksum != "" || result.Response.Metadata[MetadataChecksum] != "" {
+		t.Errorf("expected no checksum without the option, got %+v", result)
+	}
+}
+
+func TestUpload_PreHashFillsChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, []byte("prehashed"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	sum := sha256.Sum256([]byte("prehashed"))
+	expected := hex.EncodeToString(sum[:])
+
+	for _, checksum := range []bool{false, true} {
+		resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{path}, UploadConfig{
+			Concurrency: 1,
+			Providers:   []Provider{newRecordingProvider("recorder")},
+			PreHash:     true,
+			Checksum:    checksum,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results := collectResults(t, resultCh, progressCh)
+		if len(results) != 1 || results[0].Error != nil {
+			t.Fatalf("expected one successful result, got %+v", results)
+		}
+		if results[0].Checksum != expected {
+			t.Errorf("checksum %v: expected %s, got %s", checksum, expected, results[0].Checksum)
+		}
+	}
+}
+
+func TestSuccessResult_ChecksumMismatch(t *testing.T) {
+	hasher := newHashingReader(strings.NewReader("changed"))
+	if _, err := io.Copy(io.Discard, hasher); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	digests := &providers.FileDigests{SHA256: "0000"}
+
+	result := successResult(FileInfo{Name: "data.bin"}, newRecordingProvider("recorder"), 7, &providers.ProviderResponse{URL: "https://example.com/f"}, hasher, digests, 0)
+	if !errors.Is(result.Error, ErrChecksumMismatch) {
+		t.Errorf("expected a checksum mismatch, got %v", result.Error)
+	}
+	if result.Checksum != hasher.Sum() {
+		t.Errorf("expected the checksum of the bytes sent, got %s", result.Checksum)
+	}
+}
@@ -0,0 +1,87 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+	"github.com/sirupsen/logrus"
+)
+
+// MetadataChunks is the response metadata key holding how many chunks a
+// chunked upload was sent in
+const MetadataChunks = "chunks"
+
+// sendFile uploads body to provider, in UploadConfig.ChunkSize pieces when
+// the file is larger than one chunk and the provider accepts chunked uploads
+func sendFile(ctx context.Context, provider Provider, body io.Reader, opts providers.UploadOptions, config UploadConfig) (*providers.ProviderResponse, error) {
+	if config.ChunkSize <= 0 || opts.Size <= config.ChunkSize || !providers.SupportsChunks(provider) {
+		return providers.UploadWithOptions(ctx, provider, body, opts)
+	}
+	return uploadChunked(ctx, provider.(providers.Chunked), body, opts, config)
+}
+
+// uploadChunked sends body in order, one chunk at a time. Each chunk is read
+// into memory first so a failed chunk can be retried on its own without
+// resending what the server already has.
+func uploadChunked(ctx context.Context, provider providers.Chunked, body io.Reader, opts providers.UploadOptions, config UploadConfig) (*providers.ProviderResponse, error) {
+	if opts.Digests != nil {
+		ctx = providers.WithFileDigests(ctx, *opts.Digests)
+	}
+
+	buf := make([]byte, config.ChunkSize)
+	var response *providers.ProviderResponse
+	chunks := 0
+	for offset := int64(0); offset < opts.Size; offset += config.ChunkSize {
+		length := min(config.ChunkSize, opts.Size-offset)
+		if _, err := io.ReadFull(body, buf[:length]); err != nil {
+			return nil, providers.ErrFileRead(err)
+		}
+
+		var err error
+		response, err = sendChunk(ctx, provider, opts.FilePath, buf[:length], offset, opts.Size, config)
+		if err != nil {
+			return nil, err
+		}
+		chunks++
+	}
+	if response == nil {
+		return nil, nil
+	}
+
+	if response.Metadata == nil {
+		response.Metadata = make(map[string]string, len(opts.Metadata)+1)
+	}
+	for key, value := range opts.Metadata {
+		response.Metadata[key] = value
+	}
+	response.Metadata[MetadataChunks] = strconv.Itoa(chunks)
+	return response, nil
+}
+
+// sendChunk uploads one chunk, retrying retryable failures up to
+// UploadConfig.RetryAttempts times
+func sendChunk(ctx context.Context, provider providers.Chunked, filePath string, chunk []byte, offset, size int64, config UploadConfig) (*providers.ProviderResponse, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := provider.UploadChunk(ctx, filePath, bytes.NewReader(chunk), offset, int64(len(chunk)), size)
+		if err == nil || attempt >= config.RetryAttempts || !providers.IsRetryable(err) {
+			return response, err
+		}
+
+		logging.Debug("Retrying chunk", logrus.Fields{
+			"filepath": filePath,
+			"offset":   offset,
+			"attempt":  attempt + 1,
+			"error":    err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(config.RetryDelay):
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// chunkServer stands in for a server taking ranged PUTs: it appends each chunk
+// at its offset and fails the chunk at failAt once
+type chunkServer struct {
+	*recordingProvider
+	stored  []byte
+	offsets []int64
+	failAt  int64
+	failed  bool
+}
+
+func (p *chunkServer) SupportsChunks() bool { return true }
+
+func (p *chunkServer) UploadChunk(ctx context.Context, filePath string, chunk io.Reader, offset, length, size int64) (*providers.ProviderResponse, error) {
+	data, err := io.ReadAll(chunk)
+	if err != nil {
+		return nil, err
+	}
+	if offset == p.failAt && !p.failed {
+		p.failed = true
+		return nil, providers.NewNetworkError("connection reset", nil)
+	}
+	if offset != int64(len(p.stored)) || int64(len(data)) != length {
+		return nil, fmt.Errorf("unexpected range %d+%d with %d bytes stored", offset, length, len(p.stored))
+	}
+	p.stored = append(p.stored, data...)
+	p.offsets = append(p.offsets, offset)
+	return &providers.ProviderResponse{URL: "https://example.com/" + filepath.Base(filePath)}, nil
+}
+
+func TestUpload_ChunkedReassemblesFile(t *testing.T) {
+	data := []byte("the quick brown fox jumps over")
+	path := filepath.Join(t.TempDir(), "fox.txt")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	server := &chunkServer{recordingProvider: newRecordingProvider("chunks"), failAt: 10}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{path}, UploadConfig{
+		Concurrency:   1,
+		Providers:     []Provider{server},
+		ChunkSize:     10,
+		RetryAttempts: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("expected one successful result, got %+v", results)
+	}
+	if !bytes.Equal(server.stored, data) {
+		t.Errorf("expected the chunks to reassemble the file, got %q", server.stored)
+	}
+	if fmt.Sprint(server.offsets) != "[0 10 20]" {
+		t.Errorf("expected three chunks in order, got offsets %v", server.offsets)
+	}
+	if !server.failed {
+		t.Error("expected the failed chunk to be retried")
+	}
+	if got := results[0].Metadata[MetadataChunks]; got != "3" {
+		t.Errorf("expected chunk count 3 in metadata, got %q", got)
+	}
+	if len(server.bodies) != 0 {
+		t.Error("expected no whole-file upload")
+	}
+}
+
+func TestUpload_SmallFileSkipsChunking(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 10)
+	server := &chunkServer{recordingProvider: newRecordingProvider("chunks"), failAt: -1}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{server},
+		ChunkSize:   10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("expected one successful result, got %+v", results)
+	}
+	if len(server.offsets) != 0 || len(server.bodies["a.bin"]) != 10 {
+		t.Error("expected a file that fits in one chunk to be uploaded whole")
+	}
+}
@@ -0,0 +1,134 @@
+package uploader
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// PathFilter decides which files inside a scanned folder are uploaded, from
+// include and exclude globs. Patterns use / as the separator and are matched
+// against the path relative to the folder; a pattern without a / matches the
+// name at any depth. * and ? stay within one path segment, ** spans any
+// number of segments and [...] matches a character class.
+//
+// Exclude wins over include, and a directory matching an exclude pattern is
+// not walked at all. With include patterns, only files matching one of them
+// are uploaded. Files named directly on the command line are never filtered.
+type PathFilter struct {
+	include []pathGlob
+	exclude []pathGlob
+}
+
+// pathGlob is one compiled pattern
+type pathGlob struct {
+	re       *regexp.Regexp
+	nameOnly bool // Match the base name rather than the relative path
+}
+
+// NewPathFilter compiles include and exclude patterns. It returns nil, which
+// lets every file through, when both are empty.
+func NewPathFilter(include, exclude []string) (*PathFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+	filter := &PathFilter{}
+	var err error
+	if filter.include, err = compileGlobs(include); err != nil {
+		return nil, err
+	}
+	if filter.exclude, err = compileGlobs(exclude); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// MatchFile reports whether the file at rel, relative to the scanned folder, is uploaded
+func (f *PathFilter) MatchFile(rel string) bool {
+	if f == nil {
+		return true
+	}
+	if matchAny(f.exclude, rel) {
+		return false
+	}
+	return len(f.include) == 0 || matchAny(f.include, rel)
+}
+
+// SkipDir reports whether the directory at rel is excluded and need not be walked
+func (f *PathFilter) SkipDir(rel string) bool {
+	return f != nil && matchAny(f.exclude, rel)
+}
+
+// matchAny reports whether rel matches one of globs
+func matchAny(globs []pathGlob, rel string) bool {
+	rel = strings.TrimPrefix(path.Clean("/"+rel), "/")
+	for _, glob := range globs {
+		target := rel
+		if glob.nameOnly {
+			target = path.Base(rel)
+		}
+		if glob.re.MatchString(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileGlobs compiles every non-empty pattern
+func compileGlobs(patterns []string) ([]pathGlob, error) {
+	var globs []pathGlob
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		trimmed := strings.Trim(pattern, "/")
+		re, err := regexp.Compile(globToRegexp(trimmed))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		globs = append(globs, pathGlob{re: re, nameOnly: !strings.Contains(trimmed, "/")})
+	}
+	return globs, nil
+}
+
+// globToRegexp translates a glob into an anchored regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?") // **/ also matches no directory at all
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package uploader
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScan_IncludeAndExclude(t *testing.T) {
+	root := writeTree(t,
+		"a.jpg",
+		"notes.txt",
+		"photos/b.jpg",
+		"photos/raw/c.jpg",
+		"photos/raw/c.cr2",
+		"node_modules/pkg/d.jpg",
+		"docs/guide/intro.md",
+	)
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "include by name at any depth",
+			include:  []string{"*.jpg"},
+			expected: []string{"a.jpg", "node_modules/pkg/d.jpg", "photos/b.jpg", "photos/raw/c.jpg"},
+		},
+		{
+			name:     "exclude wins over include",
+			include:  []string{"*.jpg"},
+			exclude:  []string{"node_modules", "photos/raw/*"},
+			expected: []string{"a.jpg", "photos/b.jpg"},
+		},
+		{
+			name:     "double star spans directories",
+			include:  []string{"docs/**/*.md", "photos/**"},
+			expected: []string{"docs/guide/intro.md", "photos/b.jpg", "photos/raw/c.cr2", "photos/raw/c.jpg"},
+		},
+		{
+			name:     "exclude only",
+			exclude:  []string{"*.jpg", "docs"},
+			expected: []string{"notes.txt", "photos/raw/c.cr2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewPathFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := scanFiles(t, root, &DefaultScanner{Filter: filter}); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestScan_ExcludedDirectoryIsNotWalked(t *testing.T) {
+	root := writeTree(t, "keep.txt", "skip/inner.txt", "skip/deeper/more.txt")
+	filter, err := NewPathFilter(nil, []string{"skip"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without pruning the directories themselves would still be emitted
+	fileCh, _ := (&DefaultScanner{Filter: filter}).Scan(context.Background(), []string{root})
+	for fileInfo := range fileCh {
+		if rel, _ := filepath.Rel(root, fileInfo.Path); rel == "skip" || filepath.Dir(rel) != "." {
+			t.Errorf("expected the excluded directory to be pruned, got %s", rel)
+		}
+	}
+}
+
+func TestScan_ExplicitFileIsNotFiltered(t *testing.T) {
+	root := writeTree(t, "a.txt")
+	filter, err := NewPathFilter([]string{"*.jpg"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fileCh, _ := (&DefaultScanner{Filter: filter}).Scan(context.Background(), []string{filepath.Join(root, "a.txt")})
+	var names []string
+	for fileInfo := range fileCh {
+		names = append(names, fileInfo.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"a.txt"}) {
+		t.Errorf("expected the named file to be scanned, got %v", names)
+	}
+}
+
+func TestNewPathFilter(t *testing.T) {
+	if filter, err := NewPathFilter(nil, nil); filter != nil || err != nil {
+		t.Errorf("expected no filter without patterns, got %v, %v", filter, err)
+	}
+	if _, err := NewPathFilter([]string{"[z-a]"}, nil); err == nil {
+		t.Error("expected an error for an invalid character class")
+	}
+}
@@ -0,0 +1,60 @@
+package uploader
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultIOBufferSize is the read buffer used for files when UploadConfig.IOBufferSize is 0
+const DefaultIOBufferSize = 256 << 10
+
+// MinIOBufferSize is the smallest accepted read buffer; below a page the extra
+// syscalls cost more than the memory saved
+const MinIOBufferSize = 4 << 10
+
+// ValidateIOBufferSize checks an --io-buffer-size value; 0 selects the default
+func ValidateIOBufferSize(size int64) error {
+	if size != 0 && size < MinIOBufferSize {
+		return fmt.Errorf("I/O buffer size must be at least %d bytes, got %d", MinIOBufferSize, size)
+	}
+	return nil
+}
+
+// ioBufferSize returns the read buffer size configured for a run
+func (c UploadConfig) ioBufferSize() int {
+	if c.IOBufferSize <= 0 {
+		return DefaultIOBufferSize
+	}
+	return c.IOBufferSize
+}
+
+// bufferedReader reads through a bufio.Reader while staying rewindable, so
+// provider retries can still resend the body
+type bufferedReader struct {
+	*bufio.Reader
+	source io.Reader
+}
+
+func newBufferedReader(source io.Reader, size int) *bufferedReader {
+	return &bufferedReader{Reader: bufio.NewReaderSize(source, size), source: source}
+}
+
+// Seek repositions the underlying reader and drops whatever was read ahead
+func (br *bufferedReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := br.source.(io.Seeker)
+	if !ok {
+		return 0, errors.New("buffered reader: underlying reader is not seekable")
+	}
+	if whence == io.SeekCurrent {
+		// The underlying position is ahead of the caller by the buffered bytes
+		offset -= int64(br.Buffered())
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return 0, err
+	}
+	br.Reset(br.source)
+	return pos, nil
+}
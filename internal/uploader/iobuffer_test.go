@@ -0,0 +1,103 @@
+package uploader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateIOBufferSize(t *testing.T) {
+	for _, size := range []int64{0, MinIOBufferSize, DefaultIOBufferSize, 8 << 20} {
+		if err := ValidateIOBufferSize(size); err != nil {
+			t.Errorf("expected %d to be accepted, got %v", size, err)
+		}
+	}
+	for _, size := range []int64{1, 512, MinIOBufferSize - 1} {
+		if err := ValidateIOBufferSize(size); err == nil {
+			t.Errorf("expected %d to be rejected", size)
+		}
+	}
+}
+
+func TestBufferedReader_SeekDropsReadAhead(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	reader := newBufferedReader(bytes.NewReader(data), MinIOBufferSize)
+
+	head := make([]byte, 10)
+	if _, err := io.ReadFull(reader, head); err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if pos, err := reader.Seek(0, io.SeekCurrent); err != nil || pos != 10 {
+		t.Fatalf("expected position 10 despite read-ahead, got %d (%v)", pos, err)
+	}
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("unexpected seek error: %v", err)
+	}
+	again, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Errorf("expected the full body after rewinding, got %d bytes", len(again))
+	}
+}
+
+// benchmarkFileSize is large enough that per-read overhead dominates over setup
+const benchmarkFileSize = 64 << 20
+
+func writeBenchmarkFile(b *testing.B) string {
+	b.Helper()
+	path := filepath.Join(b.TempDir(), "large.bin")
+	data := make([]byte, benchmarkFileSize)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		b.Fatalf("failed to write benchmark file: %v", err)
+	}
+	return path
+}
+
+var benchmarkBufferSizes = []int{MinIOBufferSize, 32 << 10, DefaultIOBufferSize, 1 << 20}
+
+// BenchmarkHashFile compares prehash throughput across buffer sizes, run with
+// go test -bench HashFile ./internal/uploader
+func BenchmarkHashFile(b *testing.B) {
+	path := writeBenchmarkFile(b)
+	for _, size := range benchmarkBufferSizes {
+		b.Run(fmt.Sprintf("%dKiB", size>>10), func(b *testing.B) {
+			buf := make([]byte, size)
+			b.SetBytes(benchmarkFileSize)
+			for i := 0; i < b.N; i++ {
+				if hashFile(FileInfo{Name: "large.bin", Path: path}, buf) == nil {
+					b.Fatal("hashing failed")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkBufferedRead compares the upload read path across buffer sizes,
+// draining the file with io.ReadAll the way providers consume their body
+func BenchmarkBufferedRead(b *testing.B) {
+	path := writeBenchmarkFile(b)
+	for _, size := range benchmarkBufferSizes {
+		b.Run(fmt.Sprintf("%dKiB", size>>10), func(b *testing.B) {
+			b.SetBytes(benchmarkFileSize)
+			for i := 0; i < b.N; i++ {
+				file, err := os.Open(path)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := io.ReadAll(bufio.NewReaderSize(file, size)); err != nil {
+					b.Fatal(err)
+				}
+				file.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package uploader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// nameHashLen is the number of hex characters of the hash suffix added to trimmed names
+const nameHashLen = 8
+
+// MinNameLen is the smallest name limit that still fits a hash suffix
+const MinNameLen = nameHashLen + 8
+
+// TrimName shortens name to at most maxLen bytes, keeping the extension. A
+// trimmed name gets a "-<hash>" suffix derived from the full original name, so
+// long names sharing a prefix stay distinct after trimming. Names within the
+// limit, and any name when maxLen is 0, are returned unchanged.
+func TrimName(name string, maxLen int) string {
+	if maxLen <= 0 || len(name) <= maxLen {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(sum[:])[:nameHashLen]
+
+	ext := filepath.Ext(name)
+	if len(ext)+len(suffix) >= maxLen {
+		ext = "" // An extension this long cannot be kept
+	}
+
+	keep := maxLen - len(ext) - len(suffix)
+	if keep < 0 {
+		keep = 0
+	}
+	base := name[:len(name)-len(ext)]
+	if keep > len(base) {
+		keep = len(base)
+	}
+	base = base[:keep]
+	// Avoid cutting a multi-byte character in half
+	for !utf8.ValidString(base) {
+		base = base[:len(base)-1]
+	}
+	return base + suffix + ext
+}
+
+// ValidateMaxNameLen checks a --max-name-len value; 0 disables trimming
+func ValidateMaxNameLen(maxLen int) error {
+	if maxLen != 0 && maxLen < MinNameLen {
+		return fmt.Errorf("maximum name length must be 0 (no limit) or at least %d, got %d", MinNameLen, maxLen)
+	}
+	return nil
+}
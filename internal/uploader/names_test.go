@@ -0,0 +1,107 @@
+package uploader
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTrimName(t *testing.T) {
+	if got := TrimName("short.txt", 32); got != "short.txt" {
+		t.Errorf("expected names within the limit to be unchanged, got %q", got)
+	}
+	if got := TrimName(strings.Repeat("a", 100)+".txt", 0); got != strings.Repeat("a", 100)+".txt" {
+		t.Errorf("expected no trimming without a limit, got %q", got)
+	}
+
+	names := []string{
+		"quarterly-financial-report-final-version-a.pdf",
+		"quarterly-financial-report-final-version-b.pdf",
+		"quarterly-financial-report-final-version-c.pdf",
+	}
+	seen := make(map[string]string)
+	for _, name := range names {
+		trimmed := TrimName(name, 30)
+		if len(trimmed) > 30 {
+			t.Errorf("%q trimmed to %q, longer than 30 bytes", name, trimmed)
+		}
+		if !strings.HasSuffix(trimmed, ".pdf") {
+			t.Errorf("expected the extension to be kept, got %q", trimmed)
+		}
+		if other, ok := seen[trimmed]; ok {
+			t.Errorf("%q and %q collide as %q", name, other, trimmed)
+		}
+		seen[trimmed] = name
+
+		// Naive truncation would have made them identical
+		if naive := name[:26] + ".pdf"; naive == trimmed {
+			t.Errorf("expected a hash suffix, got %q", trimmed)
+		}
+	}
+
+	if TrimName(names[0], 30) != TrimName(names[0], 30) {
+		t.Error("expected trimming to be deterministic")
+	}
+}
+
+func TestTrimName_MultiByte(t *testing.T) {
+	trimmed := TrimName(strings.Repeat("é", 30)+".txt", 20)
+	if len(trimmed) > 20 || !strings.HasSuffix(trimmed, ".txt") {
+		t.Errorf("unexpected trimmed name %q", trimmed)
+	}
+	if !strings.HasPrefix(trimmed, "é") || strings.ContainsRune(trimmed, '�') {
+		t.Errorf("expected whole characters only, got %q", trimmed)
+	}
+}
+
+func TestValidateMaxNameLen(t *testing.T) {
+	for _, valid := range []int{0, MinNameLen, 255} {
+		if err := ValidateMaxNameLen(valid); err != nil {
+			t.Errorf("expected %d to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []int{-1, 1, MinNameLen - 1} {
+		if err := ValidateMaxNameLen(invalid); err == nil {
+			t.Errorf("expected %d to be rejected", invalid)
+		}
+	}
+}
+
+func TestUpload_MaxNameLen(t *testing.T) {
+	long := strings.Repeat("x", 40) + ".bin"
+	paths := writeFiles(t, []string{long, "short.bin"}, 10)
+	provider := newRecordingProvider("names")
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{provider},
+		MaxNameLen:  20,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, result := range collectResults(t, resultCh, progressCh) {
+		if result.Error != nil {
+			t.Fatalf("unexpected error for %s: %v", result.FileName, result.Error)
+		}
+		if result.FileName == "short.bin" {
+			if _, ok := result.Response.Metadata["upload_name"]; ok {
+				t.Error("expected short names to be uploaded unchanged")
+			}
+			continue
+		}
+		uploadName := result.Response.Metadata["upload_name"]
+		if uploadName != TrimName(long, 20) || result.Response.Metadata["original_name"] != long {
+			t.Errorf("unexpected name metadata %+v", result.Response.Metadata)
+		}
+	}
+
+	if _, ok := provider.bodies[TrimName(long, 20)]; !ok {
+		t.Errorf("expected the provider to receive the trimmed name, got %v", provider.bodies)
+	}
+	if _, ok := provider.bodies[filepath.Base(paths[1])]; !ok {
+		t.Errorf("expected the short name unchanged, got %v", provider.bodies)
+	}
+}
@@ -0,0 +1,42 @@
+package uploader
+
+import "context"
+
+// planFiles reads every file from in before forwarding any, so the run's
+// totals are known up front, and publishes them as a RunPlan progress event
+// ahead of the first upload. Directories pass through but are not counted.
+// The output closes once everything is forwarded or ctx is done.
+func planFiles(ctx context.Context, in <-chan FileInfo, progress *progressBroadcaster) <-chan FileInfo {
+	out := make(chan FileInfo)
+
+	go func() {
+		defer close(out)
+
+		var files []FileInfo
+		plan := RunPlan{}
+		for fileInfo := range in {
+			files = append(files, fileInfo)
+			if fileInfo.IsDir {
+				continue
+			}
+			plan.Files++
+			if fileInfo.Size > 0 {
+				plan.Bytes += fileInfo.Size
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		progress.Publish(ProgressInfo{TotalBytes: plan.Bytes, Plan: &plan})
+		for _, fileInfo := range files {
+			select {
+			case out <- fileInfo:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
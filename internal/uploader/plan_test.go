@@ -0,0 +1,47 @@
+package uploader
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestUpload_PlanTotalsPublishedFirst(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin", "c.bin"}, 10)
+
+	var mu sync.Mutex
+	var events []ProgressInfo
+	listener := ProgressListenerFunc(func(info ProgressInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, info)
+	})
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{filepath.Dir(paths[0])}, UploadConfig{
+		Concurrency:       2,
+		Providers:         []Provider{newRecordingProvider("test")},
+		ProgressListeners: []ProgressListener{listener},
+		PlanTotals:        true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results := collectResults(t, resultCh, progressCh); len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) == 0 || events[0].Plan == nil {
+		t.Fatalf("expected the plan as the first progress event, got %+v", events)
+	}
+	if plan := *events[0].Plan; plan.Files != 3 || plan.Bytes != 30 {
+		t.Errorf("expected 3 files and 30 bytes planned, got %+v", plan)
+	}
+	for _, event := range events[1:] {
+		if event.Plan != nil {
+			t.Error("expected a single plan event")
+		}
+	}
+}
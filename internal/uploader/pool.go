@@ -1,14 +1,21 @@
 package uploader
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/transform"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 )
@@ -28,6 +35,15 @@ func NewDefaultUploader() *DefaultUploader {
 	}
 }
 
+// scannerFor returns the scanner for a run: the default scanner picks up
+// config.Filter and config.IncludeHidden, a custom one is used as it is
+func (u *DefaultUploader) scannerFor(config UploadConfig) Scanner {
+	if _, ok := u.scanner.(*DefaultScanner); ok {
+		return &DefaultScanner{Filter: config.Filter, IncludeHidden: config.IncludeHidden}
+	}
+	return u.scanner
+}
+
 // Upload uploads files to multiple providers with concurrency control
 func (u *DefaultUploader) Upload(ctx context.Context, paths []string, config UploadConfig) (<-chan UploadResult, <-chan ProgressInfo, error) {
 	// Create result channel
@@ -39,21 +55,48 @@ func (u *DefaultUploader) Upload(ctx context.Context, paths []string, config Upl
 
 	// Create error group
 	g, ctx := errgroup.WithContext(ctx)
+	results := newResultSender(ctx, resultCh)
 
-	// Scan for files
+	// Scan for files. The scan outlives cancellation so the files that never
+	// started can still be reported as cancelled.
+	scanCtx, stopScan := context.WithCancel(context.WithoutCancel(ctx))
 	logging.FileScan(paths)
-	fileCh, errCh := u.scanner.Scan(ctx, paths)
+	fileCh, errCh := u.scannerFor(config).Scan(scanCtx, paths)
+
+	// Internal listeners see every event even if nobody reads the progress channel
+	progress := newProgressBroadcaster(u.progressCh, config.ProgressListeners)
+	if config.PlanTotals {
+		fileCh = planFiles(scanCtx, fileCh, progress)
+	}
+	if config.PreHash {
+		fileCh = prehashFiles(scanCtx, ctx, fileCh, config.Concurrency, config.ioBufferSize())
+	}
+	budget := newByteBudget(config.MaxTotalBytes)
+	limits := newProviderLimits(config.Providers, config.Concurrency)
+	quotas := newQuotaTracker()
+
+	// Racing runs every (file, provider) attempt as its own job on the global
+	// slots, shared out by provider weight. A file then only holds its place
+	// in line while its attempts wait for slots.
+	fileSlots := sem
+	var attemptSlots *semaphore.Weighted
+	if config.Strategy == StrategyRace && len(config.Providers) > 1 {
+		fileSlots = semaphore.NewWeighted(int64(config.Concurrency))
+		attemptSlots = sem
+		limits = newRaceLimits(config.Providers, config.Concurrency)
+	}
 
 	// Start a goroutine to process files and launch uploads
 	go func() {
 		defer close(resultCh)
 		defer close(u.progressCh)
+		defer stopScan()
 
 		// Process all files
 		for {
 			select {
 			case <-ctx.Done():
-				return
+				goto AllFilesProcessed // Cancelled or deadline hit, wait for in-flight uploads
 
 			case fileInfo, ok := <-fileCh:
 				if !ok {
@@ -67,145 +110,550 @@ func (u *DefaultUploader) Upload(ctx context.Context, paths []string, config Upl
 				}
 
 				// Acquire semaphore slot
-				if err := sem.Acquire(ctx, 1); err != nil {
+				if err := fileSlots.Acquire(ctx, 1); err != nil {
 					logging.ErrorContext("semaphore_acquire", err, map[string]interface{} {
 						"file": fileInfo.Name,
 					})
-					return
+					results.Send(cancelledResult(fileInfo, err))
+					goto AllFilesProcessed
 				}
 
 				g.Go(func() error {
-					defer sem.Release(1)
-					return u.uploadFile(ctx, fileInfo, config, resultCh)
+					defer fileSlots.Release(1)
+					return u.uploadFile(ctx, fileInfo, config, progress, budget, limits, attemptSlots, quotas, results)
 				})
 
 			case err := <-errCh:
 				if err != nil {
 					logging.ErrorContext("scan", err, nil)
 					// Send error result but continue processing other files
-					resultCh <- UploadResult{
+					results.Send(UploadResult{
 						Error: fmt.Errorf("scan error: %w", err),
-					}
+					})
 				}
 			}
 		}
 
 	AllFilesProcessed:
+		// Files that had not started when the run was cancelled
+		if ctx.Err() != nil {
+			drainCancelled(fileCh, errCh, ctx.Err(), results, stopScan)
+		}
+
 		// Wait for all upload goroutines to complete
-		if err := g.Wait(); err != nil && err != context.Canceled {
-			resultCh <- UploadResult{
+		if err := g.Wait(); err != nil && !isCancellation(err) {
+			results.Send(UploadResult{
 				Error: fmt.Errorf("upload failed: %w", err),
-			}
+			})
 		}
 	}()
 
 	return resultCh, u.progressCh, nil
 }
 
-func (u *DefaultUploader) uploadFile(ctx context.Context, fileInfo FileInfo, config UploadConfig, resultCh chan<- UploadResult) error {
+// drainCancelled reports a cancelled result for every file still coming from
+// the scan, so files that never started are accounted for like interrupted
+// uploads. Once the consumer is gone the scan is stopped and the rest of the
+// files are discarded.
+func drainCancelled(fileCh <-chan FileInfo, errCh <-chan error, cause error, results *resultSender, stopScan context.CancelFunc) {
+	for fileCh != nil {
+		select {
+		case fileInfo, ok := <-fileCh:
+			if !ok {
+				fileCh = nil
+				continue
+			}
+			if fileInfo.IsDir {
+				continue
+			}
+			if !results.Send(cancelledResult(fileInfo, cause)) {
+				stopScan()
+			}
+
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil // Stop selecting on the closed channel
+				continue
+			}
+			logging.ErrorContext("scan", err, nil)
+			if !results.Send(UploadResult{Error: fmt.Errorf("scan error: %w", err)}) {
+				stopScan()
+			}
+		}
+	}
+}
+
+// uploadSource is the content of a file: read in order by one provider at a
+// time, or at any offset by every provider at once when racing
+type uploadSource interface {
+	io.ReadSeeker
+	io.ReaderAt
+}
+
+// uploadFile sends one file according to config.Strategy. attemptSlots is set
+// when racing: each provider's attempt then takes its own slot from it.
+func (u *DefaultUploader) uploadFile(ctx context.Context, fileInfo FileInfo, config UploadConfig, progress *progressBroadcaster, budget *byteBudget, limits providerLimits, attemptSlots *semaphore.Weighted, quotas *quotaTracker, results *resultSender) error {
 	logging.UploadStart(fileInfo.Name, fileInfo.Size)
 
+	// Standard input has no length; stream it or spool it to a file that has one
+	openPath := fileInfo.Path
+	if fileInfo.Path == StdinPath {
+		if config.StdinName != "" {
+			fileInfo.Name = config.StdinName
+		}
+		if streamsDirectly(config) {
+			return u.streamUpload(ctx, fileInfo, config, progress, results)
+		}
+		spooled, size, err := spoolStdin(config.Stdin, config.ioBufferSize())
+		if err != nil {
+			results.Send(UploadResult{
+				FileName: fileInfo.Name,
+				FilePath: fileInfo.Path,
+				Error:    fmt.Errorf("failed to buffer standard input: %w", err),
+			})
+			return nil
+		}
+		defer os.Remove(spooled)
+		openPath = spooled
+		fileInfo.Size = size
+	}
+
 	// Open file
-	file, err := os.Open(fileInfo.Path)
+	file, err := os.Open(openPath)
 	if err != nil {
 		logging.ErrorContext("file_open", err, map[string]interface{} {
 			"file": fileInfo.Name,
 			"path": fileInfo.Path,
 		})
-		resultCh <- UploadResult{
+		results.Send(UploadResult{
 			FileName: fileInfo.Name,
 			FilePath: fileInfo.Path,
 			Error:    fmt.Errorf("failed to open file: %w", err),
-		}
+		})
 		return nil // Don't fail the entire operation for one file
 	}
 	defer file.Close()
 
-	// Try each provider until one succeeds
-	var lastErr error
-	for _, provider := range config.Providers {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
+	// Apply content transforms before any provider sees the bytes
+	var source uploadSource = file
+	size := fileInfo.Size
+	stripped := false
+	if config.StripMetadata && transform.IsStrippable(fileInfo.Path) {
+		source, size, stripped, err = stripImageMetadata(file)
+		if err != nil {
+			logging.ErrorContext("strip_metadata", err, map[string]interface{} {
+				"file": fileInfo.Name,
+				"path": fileInfo.Path,
+			})
+			results.Send(UploadResult{
+				FileName: fileInfo.Name,
+				FilePath: fileInfo.Path,
+				Error:    fmt.Errorf("failed to strip image metadata: %w", err),
+			})
+			return nil
 		}
+	}
 
-		start := time.Now()
+	// Digests from the prehash stage describe the file on disk, not transformed content
+	digests := fileInfo.Digests
+	if stripped && digests != nil {
+		transformed, err := providers.ComputeFileDigestsBuffer(source, make([]byte, config.ioBufferSize()))
+		if err == nil {
+			_, err = source.Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			results.Send(UploadResult{
+				FileName: fileInfo.Name,
+				FilePath: fileInfo.Path,
+				Error:    fmt.Errorf("failed to hash stripped content: %w", err),
+			})
+			return nil
+		}
+		digests = &transformed
+	}
 
-		// Create progress tracking reader
-		progressReader := &progressReader{
-			reader:    file,
-			totalSize: fileInfo.Size,
-			onProgress: func(bytesRead int64) {
-				progress := ProgressInfo{
-					FileName:      fileInfo.Name,
-					BytesUploaded: bytesRead,
-					TotalBytes:    fileInfo.Size,
-					Percentage:    float64(bytesRead) / float64(fileInfo.Size) * 100,
-				}
+	// Providers name the upload after the base of the path they receive
+	uploadName := TrimName(fileInfo.Name, config.MaxNameLen)
+	uploadPath := fileInfo.Path
+	if uploadName != filepath.Base(fileInfo.Path) {
+		uploadPath = filepath.Join(filepath.Dir(fileInfo.Path), uploadName)
+	}
 
-				select {
-				case u.progressCh <- progress:
-				default:
-					// Progress channel full, skip this update
-				}
-			},
+	// Everything the providers need to know besides the body; the metadata
+	// ends up in each successful response
+	opts := providers.UploadOptions{
+		FilePath: uploadPath,
+		Size:     size,
+		Digests:  digests,
+	}
+	if stripped {
+		opts.Transforms = []string{"exif_stripped"}
+		opts.Metadata = map[string]string{"exif_stripped": "true"}
+	}
+	if uploadName != fileInfo.Name {
+		if opts.Metadata == nil {
+			opts.Metadata = make(map[string]string)
+		}
+		opts.Metadata["original_name"] = fileInfo.Name
+		opts.Metadata["upload_name"] = uploadName
+	}
+
+	// Racing gives every provider its own reader over the content
+	mirror := config.Strategy == StrategyMirror
+	race := attemptSlots != nil
+
+	// Stop starting new uploads once the run's byte cap would be exceeded
+	reservation := size
+	if mirror || race {
+		reservation = size * int64(len(config.Providers))
+	}
+	if !budget.Reserve(reservation) {
+		logging.Warn("Skipping file, byte budget exhausted", logrus.Fields{
+			"file":      fileInfo.Name,
+			"size":      size,
+			"committed": budget.Committed(),
+		})
+		results.Send(skippedResult(fileInfo, ErrByteBudgetExhausted))
+		return nil
+	}
+	if race {
+		u.raceFile(ctx, fileInfo, size, source, opts, digests, config, progress, budget, limits, attemptSlots, quotas, results)
+		return nil
+	}
+	account := &budgetAccount{budget: budget, reserved: reservation, abort: config.AbortOverBudget}
+	defer account.Settle()
+
+	var body io.Reader = source
+	if budget != nil {
+		body = &budgetReader{reader: source, account: account}
+	}
+	// Larger reads mean fewer syscalls on big sequential files; reset after every seek
+	buffered := newBufferedReader(body, config.ioBufferSize())
+
+	// Try each provider until one succeeds, or every provider when mirroring.
+	// Failover tries providers that already hit a limit in this run last.
+	providerOrder := config.Providers
+	if !mirror {
+		providerOrder = quotas.Order(config.Providers)
+	}
+	var lastErr error
+	for _, provider := range providerOrder {
+		if ctx.Err() != nil {
+			results.Send(cancelledResult(fileInfo, ctx.Err()))
+			return nil
 		}
 
+		start := time.Now()
+		uploadCtx, progressReader, hasher := newAttempt(ctx, fileInfo, size, config, progress, buffered)
+
 		// Reset file offset for each provider
-		_, err = file.Seek(0, io.SeekStart)
+		_, err = source.Seek(0, io.SeekStart)
 		if err != nil {
 			lastErr = err
 			continue
 		}
+		buffered.Reset(body)
+
+		// Wait for a slot if the provider limits its parallel uploads
+		release, err := limits.acquire(ctx, provider.Name())
+		if err != nil {
+			results.Send(cancelledResult(fileInfo, err))
+			return nil
+		}
 
 		// Upload to provider
-		response, err := provider.Upload(ctx, fileInfo.Path, progressReader, fileInfo.Size)
+		response, err := sendFile(uploadCtx, provider, progressReader, opts, config)
+		release()
 		duration := time.Since(start)
 
 		if err != nil {
+			if ctx.Err() != nil {
+				// Interrupted rather than failed
+				results.Send(cancelledResult(fileInfo, ctx.Err()))
+				return nil
+			}
+			if account.aborted {
+				logging.UploadError(fileInfo.Name, provider.Name(), err)
+				results.Send(UploadResult{
+					FileName: fileInfo.Name,
+					FilePath: fileInfo.Path,
+					Size:     size,
+					Provider: provider.Name(),
+					Error:    fmt.Errorf("upload aborted: %w", ErrByteBudgetExhausted),
+				})
+				return nil
+			}
 			lastErr = err
 			logging.UploadError(fileInfo.Name, provider.Name(), err)
+			if providers.IsQuotaError(err) {
+				quotas.Mark(provider.Name())
+			}
+			if mirror {
+				// Each provider reports its own outcome; a failure does not stop the others
+				results.Send(UploadResult{
+					FileName: fileInfo.Name,
+					FilePath: fileInfo.Path,
+					Size:     size,
+					Provider: provider.Name(),
+					Duration: duration,
+					Error:    err,
+				})
+			}
 			continue
 		}
 
-		// Extract URL from response
-		url := ""
-		if response != nil {
-			url = response.URL
+		// Success!
+		result := successResult(fileInfo, provider, size, response, hasher, digests, duration)
+		logging.UploadComplete(fileInfo.Name, result.URL, duration)
+
+		results.Send(result)
+		if mirror {
+			continue
 		}
+		return nil
+	}
 
-		// Success!
-		result := UploadResult{
-			FileName:   fileInfo.Name,
-			FilePath:   fileInfo.Path,
-			Size:       fileInfo.Size,
-			URL:        url,
-			Provider:   provider.Name(),
-			Duration:   duration,
-			UploadTime: time.Now(),
-			Response:   response,
+	if mirror {
+		return nil // Failures were reported per provider
+	}
+
+	// All providers failed
+	results.Send(UploadResult{
+		FileName: fileInfo.Name,
+		FilePath: fileInfo.Path,
+		Error:    fmt.Errorf("all providers failed, last error: %w", lastErr),
+	})
+
+	return nil
+}
+
+// newAttempt wraps body for one upload attempt: it hashes the bytes when
+// checksums are on and reports progress, including retries, for the file
+func newAttempt(ctx context.Context, fileInfo FileInfo, size int64, config UploadConfig, progress *progressBroadcaster, body io.Reader) (context.Context, *progressReader, *hashingReader) {
+	// Retries restart the body; progress carries the retry so the UI can say so
+	var retry, maxRetries atomic.Int64
+	progressFor := func(bytesRead int64, speed float64) ProgressInfo {
+		info := ProgressInfo{
+			FileName:      fileInfo.Name,
+			BytesUploaded: bytesRead,
+			TotalBytes:    size,
+			Percentage:    float64(bytesRead) / float64(size) * 100,
+			Speed:         speed,
+			ETA:           estimateETA(bytesRead, size, speed),
+			Retry:         int(retry.Load()),
+			MaxRetries:    int(maxRetries.Load()),
 		}
+		if info.Retry > 0 {
+			info.Phase = fmt.Sprintf("retrying (attempt %d)", info.Retry+1)
+		}
+		return info
+	}
+	uploadCtx := providers.WithRetryNotifier(ctx, func(event providers.RetryEvent) {
+		retry.Store(int64(event.Retry))
+		maxRetries.Store(int64(event.MaxRetries))
+		progress.Publish(progressFor(0, 0))
+	})
+
+	// Hash the bytes as they stream to the provider when checksums are on
+	reader := body
+	var hasher *hashingReader
+	if config.Checksum {
+		hasher = newHashingReader(body)
+		reader = hasher
+	}
 
-		logging.UploadComplete(fileInfo.Name, url, duration)
+	return uploadCtx, &progressReader{
+		reader:    reader,
+		totalSize: size,
+		speed:     newSpeedEstimator(time.Now),
+		onProgress: func(bytesRead int64, speed float64) {
+			progress.Publish(progressFor(bytesRead, speed))
+		},
+	}, hasher
+}
 
-		select {
-		case resultCh <- result:
-		case <-ctx.Done():
-			return ctx.Err()
+// successResult builds the result of a finished upload. The checksum comes
+// from the prehash digests or the bytes sent; when there are both, the bytes
+// sent must match the prehash or the file changed while it was uploaded. A
+// computed checksum is also recorded in the response metadata.
+func successResult(fileInfo FileInfo, provider Provider, size int64, response *providers.ProviderResponse, hasher *hashingReader, digests *providers.FileDigests, duration time.Duration) UploadResult {
+	// Extract URL from response
+	url := ""
+	if response != nil {
+		url = response.URL
+	}
+	checksum := ""
+	if digests != nil {
+		checksum = digests.SHA256
+	}
+	var mismatch error
+	if hasher != nil {
+		if sent := hasher.Sum(); sent != "" {
+			if checksum != "" && sent != checksum {
+				mismatch = fmt.Errorf("%w: sent %s, prehashed %s", ErrChecksumMismatch, sent, checksum)
+			}
+			checksum = sent
 		}
+	}
+	if response != nil && checksum != "" {
+		if response.Metadata == nil {
+			response.Metadata = make(map[string]string)
+		}
+		response.Metadata[MetadataChecksum] = checksum
+	}
 
-		return nil
+	result := UploadResult{
+		FileName:   fileInfo.Name,
+		FilePath:   fileInfo.Path,
+		Size:       size,
+		URL:        url,
+		Provider:   provider.Name(),
+		Duration:   duration,
+		SpeedBps:   speedBps(size, duration),
+		UploadTime: time.Now(),
+		Response:   response,
+		Checksum:   checksum,
+		Error:      mismatch,
+	}
+	if response != nil {
+		result.DeleteURL = response.DeleteURL
+		result.ID = response.ID
+		result.Expires = response.Expires
+		result.Metadata = response.Metadata
 	}
+	return result
+}
 
-	// All providers failed
-	resultCh <- UploadResult{
+// providerLimits holds a semaphore for every provider that declares a maximum
+// concurrency lower than the run's, keyed by provider name
+type providerLimits map[string]*semaphore.Weighted
+
+// newProviderLimits caps each provider at the lower of its declared maximum and
+// the global concurrency
+func newProviderLimits(providerList []Provider, concurrency int) providerLimits {
+	limits := make(providerLimits)
+	for _, provider := range providerList {
+		limiter, ok := provider.(providers.ConcurrencyLimiter)
+		if !ok {
+			continue
+		}
+		limit := limiter.MaxConcurrency()
+		if limit <= 0 || (concurrency > 0 && limit >= concurrency) {
+			continue
+		}
+		limits[provider.Name()] = semaphore.NewWeighted(int64(limit))
+		logging.Debug("Provider concurrency limited", logrus.Fields{
+			"provider":        provider.Name(),
+			"max_concurrency": limit,
+		})
+	}
+	return limits
+}
+
+// acquire waits for an upload slot of the named provider and returns its release
+// function; providers without a limit return immediately
+func (l providerLimits) acquire(ctx context.Context, name string) (func(), error) {
+	sem, ok := l[name]
+	if !ok {
+		return func() {}, nil
+	}
+	if err := sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { sem.Release(1) }, nil
+}
+
+// speedBps returns the average speed of an upload, or 0 when no time was measured
+func speedBps(size int64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return float64(size) / duration.Seconds()
+}
+
+// stripImageMetadata reads an image fully and returns a seekable copy with its
+// metadata removed, along with the new size
+func stripImageMetadata(file io.Reader) (uploadSource, int64, bool, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	cleaned, stripped, err := transform.StripMetadata(data)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return bytes.NewReader(cleaned), int64(len(cleaned)), stripped, nil
+}
+
+// cancelledResult builds the result reported for a file whose upload was interrupted
+func cancelledResult(fileInfo FileInfo, cause error) UploadResult {
+	return UploadResult{
+		FileName:  fileInfo.Name,
+		FilePath:  fileInfo.Path,
+		Size:      fileInfo.Size,
+		Cancelled: true,
+		Error:     fmt.Errorf("upload cancelled: %w", cause),
+	}
+}
+
+// skippedResult builds the result reported for a file that was never started
+func skippedResult(fileInfo FileInfo, reason error) UploadResult {
+	return UploadResult{
 		FileName: fileInfo.Name,
 		FilePath: fileInfo.Path,
-		Error:    fmt.Errorf("all providers failed, last error: %w", lastErr),
+		Size:     fileInfo.Size,
+		Skipped:  true,
+		Error:    fmt.Errorf("upload skipped: %w", reason),
 	}
+}
 
-	return nil
+// resultGrace is how long a result produced after the batch context ended
+// waits for the consumer before the run stops delivering results
+var resultGrace = 5 * time.Second
+
+// resultSender delivers results to the consumer. Consumers like Drain keep
+// reading after cancellation so interrupted uploads are still reported, but
+// one that stopped reading must not block the workers forever: once the
+// batch context is done a send waits at most resultGrace, and after one send
+// times out the remaining results are dropped.
+type resultSender struct {
+	ctx       context.Context
+	ch        chan<- UploadResult
+	abandoned atomic.Bool
+}
+
+func newResultSender(ctx context.Context, ch chan<- UploadResult) *resultSender {
+	return &resultSender{ctx: ctx, ch: ch}
+}
+
+// Send delivers result, reporting false when the consumer is gone
+func (s *resultSender) Send(result UploadResult) bool {
+	if s.abandoned.Load() {
+		return false
+	}
+	select {
+	case s.ch <- result:
+		return true
+	case <-s.ctx.Done():
+	}
+
+	timer := time.NewTimer(resultGrace)
+	defer timer.Stop()
+	select {
+	case s.ch <- result:
+		return true
+	case <-timer.C:
+		s.abandoned.Store(true)
+		logging.Warn("Dropping upload results, nothing is reading them", logrus.Fields{
+			"file": result.FileName,
+		})
+		return false
+	}
+}
+
+// isCancellation reports whether err stems from context cancellation or an expired deadline
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
 // GetProgress returns the progress channel
@@ -218,12 +666,28 @@ type progressReader struct {
 	reader     io.Reader
 	totalSize  int64
 	bytesRead  int64
-	onProgress func(int64)
+	speed      *speedEstimator
+	onProgress func(bytesRead int64, speed float64)
+}
+
+// Seek rewinds the underlying reader so providers can resend the body on retry
+func (pr *progressReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := pr.reader.(io.Seeker)
+	if !ok {
+		return 0, errors.New("progress reader: underlying reader is not seekable")
+	}
+	pos, err := seeker.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	pr.bytesRead = pos
+	pr.speed.Reset(pos)
+	return pos, nil
 }
 
 func (pr *progressReader) Read(p []byte) (n int, err error) {
 	n, err = pr.reader.Read(p)
 	pr.bytesRead += int64(n)
-	pr.onProgress(pr.bytesRead)
+	pr.onProgress(pr.bytesRead, pr.speed.Update(pr.bytesRead))
 	return n, err
 }
\ No newline at end of file
@@ -0,0 +1,376 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestMain(m *testing.M) {
+	logging.Init(false, io.Discard)
+	os.Exit(m.Run())
+}
+
+// recordingProvider captures every uploaded body
+type recordingProvider struct {
+	name   string
+	mu     sync.Mutex
+	bodies map[string][]byte
+}
+
+func newRecordingProvider(name string) *recordingProvider {
+	return &recordingProvider{name: name, bodies: make(map[string][]byte)}
+}
+
+func (p *recordingProvider) Name() string { return p.name }
+
+func (p *recordingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.bodies[filepath.Base(filePath)] = data
+	p.mu.Unlock()
+	return &providers.ProviderResponse{URL: "https://example.com/" + filepath.Base(filePath)}, nil
+}
+
+func (p *recordingProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *recordingProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *recordingProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+func collectResults(t *testing.T, resultCh <-chan UploadResult, progressCh <-chan ProgressInfo) []UploadResult {
+	t.Helper()
+	go func() {
+		for range progressCh {
+		}
+	}()
+	var results []UploadResult
+	for result := range resultCh {
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestUpload_StripMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 4)), nil); err != nil {
+		t.Fatalf("failed to encode jpeg: %v", err)
+	}
+	encoded := buf.Bytes()
+	exif := []byte{0xFF, 0xE1, 0x00, 0x10, 'E', 'x', 'i', 'f', 0, 0, 'l', 'o', 'c', 'a', 't', 'i', 'o', 'n'}
+	withExif := append(append(append([]byte{}, encoded[:2]...), exif...), encoded[2:]...)
+
+	dir := t.TempDir()
+	photo := filepath.Join(dir, "photo.jpg")
+	notes := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(photo, withExif, 0644); err != nil {
+		t.Fatalf("failed to write photo: %v", err)
+	}
+	if err := os.WriteFile(notes, []byte("Exif\x00\x00 in a text file"), 0644); err != nil {
+		t.Fatalf("failed to write notes: %v", err)
+	}
+
+	provider := newRecordingProvider("recorder")
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{photo, notes}, UploadConfig{
+		Concurrency:   2,
+		Providers:     []Provider{provider},
+		StripMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, result := range collectResults(t, resultCh, progressCh) {
+		if result.Error != nil {
+			t.Fatalf("unexpected upload error: %v", result.Error)
+		}
+		stripped := result.Response.Metadata["exif_stripped"]
+		if result.FileName == "photo.jpg" && stripped != "true" {
+			t.Errorf("expected exif_stripped metadata for photo, got %q", stripped)
+		}
+		if result.FileName == "notes.txt" && stripped != "" {
+			t.Errorf("non-image should not be marked as stripped, got %q", stripped)
+		}
+	}
+
+	if uploaded := provider.bodies["photo.jpg"]; bytes.Contains(uploaded, []byte("Exif\x00\x00")) {
+		t.Error("uploaded photo still contains the EXIF segment")
+	} else if !bytes.Equal(uploaded, encoded) {
+		t.Error("uploaded photo should match the original image without EXIF")
+	}
+	if uploaded := provider.bodies["notes.txt"]; !bytes.Contains(uploaded, []byte("Exif\x00\x00")) {
+		t.Error("non-image file should be uploaded untouched")
+	}
+}
+
+func TestSpeedBps(t *testing.T) {
+	if got := speedBps(10*1024*1024, 2*time.Second); got != 5*1024*1024 {
+		t.Errorf("expected 5 MiB/s, got %v", got)
+	}
+	if got := speedBps(1024, 0); got != 0 {
+		t.Errorf("expected 0 for a zero duration, got %v", got)
+	}
+}
+
+// limitedProvider declares a concurrency limit and records the peak number of
+// uploads it saw at once
+type limitedProvider struct {
+	*recordingProvider
+	max    int
+	active atomic.Int32
+	peak   atomic.Int32
+}
+
+func (p *limitedProvider) MaxConcurrency() int { return p.max }
+
+func (p *limitedProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	n := p.active.Add(1)
+	defer p.active.Add(-1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return p.recordingProvider.Upload(ctx, filePath, file, size)
+}
+
+func TestUpload_ProviderMaxConcurrency(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin", "c.bin", "d.bin"}, 10)
+	provider := &limitedProvider{recordingProvider: newRecordingProvider("limited"), max: 1}
+	wrapped := providers.NewConsistencyWrapper(provider, providers.DefaultWrapperConfig())
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 4,
+		Providers:   []Provider{wrapped},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("unexpected error for %s: %v", result.FileName, result.Error)
+		}
+	}
+	if peak := provider.peak.Load(); peak != 1 {
+		t.Errorf("expected at most 1 concurrent upload, saw %d", peak)
+	}
+}
+
+func TestNewProviderLimits(t *testing.T) {
+	limits := newProviderLimits([]Provider{
+		&limitedProvider{recordingProvider: newRecordingProvider("one"), max: 1},
+		&limitedProvider{recordingProvider: newRecordingProvider("above"), max: 8},
+		&limitedProvider{recordingProvider: newRecordingProvider("none"), max: 0},
+		newRecordingProvider("plain"),
+	}, 4)
+
+	if _, ok := limits["one"]; !ok {
+		t.Error("expected a limit for a provider below the global concurrency")
+	}
+	for _, name := range []string{"above", "none", "plain"} {
+		if _, ok := limits[name]; ok {
+			t.Errorf("expected no limit for %s", name)
+		}
+	}
+}
+
+func TestUpload_MirrorReportsEveryProvider(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 10)
+	first := newRecordingProvider("first")
+	second := newRecordingProvider("second")
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{first, second},
+		Strategy:    StrategyMirror,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 2 {
+		t.Fatalf("expected one result per provider, got %d", len(results))
+	}
+	for i, name := range []string{"first", "second"} {
+		if results[i].Provider != name || results[i].Error != nil {
+			t.Errorf("result %d: expected success from %s, got %+v", i, name, results[i])
+		}
+	}
+	if len(first.bodies["a.bin"]) != 10 || len(second.bodies["a.bin"]) != 10 {
+		t.Error("expected both providers to receive the whole file")
+	}
+}
+
+func TestUpload_MirrorKeepsGoingAfterFailure(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 10)
+	failing := &drainThenFailProvider{recordingProvider: newRecordingProvider("failing")}
+	working := newRecordingProvider("working")
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{failing, working},
+		Strategy:    StrategyMirror,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 2 {
+		t.Fatalf("expected one result per provider, got %d", len(results))
+	}
+	if results[0].Provider != "failing" || results[0].Error == nil {
+		t.Errorf("expected a failure from the first provider, got %+v", results[0])
+	}
+	if results[1].Provider != "working" || results[1].Error != nil || results[1].URL == "" {
+		t.Errorf("expected a success from the second provider, got %+v", results[1])
+	}
+	if len(working.bodies["a.bin"]) != 10 {
+		t.Error("expected the file to be re-read from the start for the second provider")
+	}
+}
+
+// blockingProvider reads part of the body, announces the upload on started
+// and blocks until its context is cancelled
+type blockingProvider struct {
+	*recordingProvider
+	started chan string
+}
+
+func (p *blockingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	io.ReadFull(file, make([]byte, 1))
+	p.started <- filepath.Base(filePath)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestUpload_CancelReportsInFlightUploads(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin"}, 10)
+	provider := &blockingProvider{recordingProvider: newRecordingProvider("blocking"), started: make(chan string, 2)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resultCh, progressCh, err := NewDefaultUploader().Upload(ctx, paths, UploadConfig{
+		Concurrency: 2,
+		Providers:   []Provider{provider},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Cancel once both uploads are under way
+	<-provider.started
+	<-provider.started
+	cancel()
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 2 {
+		t.Fatalf("expected a result for each in-flight upload, got %+v", results)
+	}
+	for _, result := range results {
+		if !result.Cancelled || !errors.Is(result.Error, context.Canceled) || result.FilePath == "" {
+			t.Errorf("expected a cancelled result naming the file, got %+v", result)
+		}
+	}
+	if _, ok := <-progressCh; ok {
+		t.Error("expected the progress channel to be closed")
+	}
+}
+
+func TestResultSender_DeliversAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ch := make(chan UploadResult)
+	results := newResultSender(ctx, ch)
+
+	go func() { <-ch }()
+	if !results.Send(UploadResult{FileName: "a.txt", Cancelled: true}) {
+		t.Error("expected a reading consumer to get results after cancellation")
+	}
+}
+
+func TestResultSender_StopsWhenConsumerIsGone(t *testing.T) {
+	defer func(grace time.Duration) { resultGrace = grace }(resultGrace)
+	resultGrace = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := newResultSender(ctx, make(chan UploadResult))
+
+	done := make(chan bool)
+	go func() {
+		first := results.Send(UploadResult{FileName: "a.txt"})
+		second := results.Send(UploadResult{FileName: "b.txt"})
+		done <- first || second
+	}()
+	select {
+	case delivered := <-done:
+		if delivered {
+			t.Error("expected nothing to be delivered without a reader")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected sends to give up once nothing reads the results")
+	}
+}
+
+func TestUpload_DeadlineReportsEveryFile(t *testing.T) {
+	names := []string{"a.bin", "b.bin", "c.bin", "d.bin", "e.bin", "f.bin"}
+	configs := map[string]UploadConfig{
+		"plain":       {},
+		"plan totals": {PlanTotals: true},
+		"prehash":     {PreHash: true},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			paths := writeFiles(t, names, 10)
+			provider := &blockingProvider{recordingProvider: newRecordingProvider("blocking"), started: make(chan string, len(names))}
+
+			// Two uploads block until the deadline while the other files wait for a slot
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			config.Concurrency = 2
+			config.Providers = []Provider{provider}
+			resultCh, progressCh, err := NewDefaultUploader().Upload(ctx, paths, config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			results := collectResults(t, resultCh, progressCh)
+			reported := make(map[string]bool)
+			for _, result := range results {
+				if !result.Cancelled || !errors.Is(result.Error, context.DeadlineExceeded) {
+					t.Errorf("expected a cancelled result, got %+v", result)
+				}
+				reported[filepath.Base(result.FilePath)] = true
+			}
+			if len(results) != len(names) || len(reported) != len(names) {
+				t.Errorf("expected one cancelled result for each of %d files, got %+v", len(names), results)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package uploader
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// prehashFiles hashes every file read from in with up to workers goroutines,
+// each reading through a buffer of bufSize bytes, and forwards it with its
+// digests attached. Directories pass through untouched, and
+// a file that cannot be read is forwarded without digests so the upload stage
+// reports the error. Once batchCtx is done files pass through unhashed, since
+// they are only reported as cancelled. The output closes once in is drained
+// or ctx is done.
+func prehashFiles(ctx, batchCtx context.Context, in <-chan FileInfo, workers, bufSize int) <-chan FileInfo {
+	if workers < 1 {
+		workers = 1
+	}
+	out := make(chan FileInfo, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, bufSize)
+			for fileInfo := range in {
+				if !fileInfo.IsDir && fileInfo.Path != StdinPath && batchCtx.Err() == nil {
+					fileInfo.Digests = hashFile(fileInfo, buf)
+				}
+				select {
+				case out <- fileInfo:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// hashFile computes the digests of a file on disk, returning nil on failure
+func hashFile(fileInfo FileInfo, buf []byte) *providers.FileDigests {
+	file, err := os.Open(fileInfo.Path)
+	if err != nil {
+		logging.ErrorContext("prehash", err, map[string]interface{}{
+			"file": fileInfo.Name,
+			"path": fileInfo.Path,
+		})
+		return nil
+	}
+	defer file.Close()
+
+	digests, err := providers.ComputeFileDigestsBuffer(file, buf)
+	if err != nil {
+		logging.ErrorContext("prehash", err, map[string]interface{}{
+			"file": fileInfo.Name,
+			"path": fileInfo.Path,
+		})
+		return nil
+	}
+	return &digests
+}
@@ -0,0 +1,82 @@
+package uploader
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// digestProvider records the digests it finds in the upload context
+type digestProvider struct {
+	*recordingProvider
+	mu      sync.Mutex
+	digests map[string]providers.FileDigests
+}
+
+func (p *digestProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if digests, ok := providers.FileDigestsFromContext(ctx); ok {
+		p.mu.Lock()
+		p.digests[filepath.Base(filePath)] = digests
+		p.mu.Unlock()
+	}
+	return p.recordingProvider.Upload(ctx, filePath, file, size)
+}
+
+func TestUpload_PreHashDigestsReachProvider(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin", "c.bin"}, 100)
+	provider := &digestProvider{recordingProvider: newRecordingProvider("digest"), digests: make(map[string]providers.FileDigests)}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 2,
+		Providers:   []Provider{provider},
+		PreHash:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range collectResults(t, resultCh, progressCh) {
+		if result.Error != nil {
+			t.Fatalf("unexpected upload error: %v", result.Error)
+		}
+	}
+
+	content := []byte(strings.Repeat("x", 100))
+	sha := sha256.Sum256(content)
+	sum := md5.Sum(content)
+	for _, name := range []string{"a.bin", "b.bin", "c.bin"} {
+		digests, ok := provider.digests[name]
+		if !ok {
+			t.Errorf("no digests reached the provider for %s", name)
+			continue
+		}
+		if digests.SHA256 != hex.EncodeToString(sha[:]) || digests.MD5 != hex.EncodeToString(sum[:]) {
+			t.Errorf("unexpected digests for %s: %+v", name, digests)
+		}
+	}
+}
+
+func TestUpload_WithoutPreHashHasNoDigests(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 10)
+	provider := &digestProvider{recordingProvider: newRecordingProvider("digest"), digests: make(map[string]providers.FileDigests)}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{provider},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	collectResults(t, resultCh, progressCh)
+
+	if len(provider.digests) != 0 {
+		t.Errorf("expected no digests without prehash, got %v", provider.digests)
+	}
+}
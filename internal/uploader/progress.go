@@ -0,0 +1,168 @@
+package uploader
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// speedSampleInterval is the minimum time between two rate samples, so
+	// bursts of small reads do not produce wild estimates
+	speedSampleInterval = 100 * time.Millisecond
+	// speedSmoothing is the weight of the newest sample in the moving average
+	speedSmoothing = 0.3
+)
+
+// ProgressListener receives every progress event of an upload run. Listeners are
+// called synchronously from the upload goroutines and must be safe for concurrent use.
+type ProgressListener interface {
+	OnProgress(info ProgressInfo)
+}
+
+// ProgressListenerFunc adapts a function to the ProgressListener interface
+type ProgressListenerFunc func(info ProgressInfo)
+
+// OnProgress calls f(info)
+func (f ProgressListenerFunc) OnProgress(info ProgressInfo) {
+	f(info)
+}
+
+// progressBroadcaster fans progress events out to internal listeners and the
+// external progress channel. Listeners always receive every event; the channel
+// is best effort and drops updates when nobody reads it.
+type progressBroadcaster struct {
+	listeners []ProgressListener
+	external  chan<- ProgressInfo
+}
+
+func newProgressBroadcaster(external chan<- ProgressInfo, listeners []ProgressListener) *progressBroadcaster {
+	return &progressBroadcaster{
+		listeners: listeners,
+		external:  external,
+	}
+}
+
+// Publish delivers info to all listeners and offers it to the external channel
+func (b *progressBroadcaster) Publish(info ProgressInfo) {
+	for _, listener := range b.listeners {
+		listener.OnProgress(info)
+	}
+
+	select {
+	case b.external <- info:
+	default:
+		// Progress channel full or unread, skip this update
+	}
+}
+
+// ProgressMetrics is a ProgressListener that keeps running totals of the bytes
+// transferred in an upload run
+type ProgressMetrics struct {
+	mu     sync.Mutex
+	files  map[string]int64
+	events int64
+}
+
+// NewProgressMetrics creates an empty ProgressMetrics
+func NewProgressMetrics() *ProgressMetrics {
+	return &ProgressMetrics{files: make(map[string]int64)}
+}
+
+// OnProgress records the latest byte count reported for a file
+func (m *ProgressMetrics) OnProgress(info ProgressInfo) {
+	if info.Plan != nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[info.FileName] = info.BytesUploaded
+	m.events++
+}
+
+// BytesUploaded returns the bytes transferred so far across all files
+func (m *ProgressMetrics) BytesUploaded() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var total int64
+	for _, n := range m.files {
+		total += n
+	}
+	return total
+}
+
+// FileBytes returns the bytes transferred so far for a single file
+func (m *ProgressMetrics) FileBytes(fileName string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.files[fileName]
+}
+
+// Events returns the number of progress events received
+func (m *ProgressMetrics) Events() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.events
+}
+
+// speedEstimator turns a growing byte count into an exponentially weighted
+// moving average of bytes per second. It is not safe for concurrent use; each
+// upload owns one.
+type speedEstimator struct {
+	now       func() time.Time
+	lastTime  time.Time
+	lastBytes int64
+	rate      float64
+	sampled   bool
+}
+
+func newSpeedEstimator(now func() time.Time) *speedEstimator {
+	return &speedEstimator{now: now, lastTime: now()}
+}
+
+// Update records the byte count reached and returns the current rate
+func (e *speedEstimator) Update(bytes int64) float64 {
+	if e == nil {
+		return 0
+	}
+	now := e.now()
+	elapsed := now.Sub(e.lastTime)
+	if elapsed < speedSampleInterval {
+		return e.rate
+	}
+
+	sample := float64(bytes-e.lastBytes) / elapsed.Seconds()
+	if e.sampled {
+		e.rate = speedSmoothing*sample + (1-speedSmoothing)*e.rate
+	} else {
+		e.rate = sample
+		e.sampled = true
+	}
+	e.lastTime = now
+	e.lastBytes = bytes
+	return e.rate
+}
+
+// Reset restarts the estimate from the given byte count, e.g. after a retry
+// rewound the body
+func (e *speedEstimator) Reset(bytes int64) {
+	if e == nil {
+		return
+	}
+	e.lastTime = e.now()
+	e.lastBytes = bytes
+	e.rate = 0
+	e.sampled = false
+}
+
+// estimateETA returns how long the remaining bytes take at the given speed,
+// or 0 when the speed or total is unknown
+func estimateETA(bytesDone, total int64, speed float64) time.Duration {
+	if speed <= 0 || total <= 0 {
+		return 0
+	}
+	remaining := total - bytesDone
+	if remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / speed * float64(time.Second))
+}
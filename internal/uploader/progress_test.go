@@ -0,0 +1,236 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// smallReadProvider consumes the body in tiny reads to generate many progress events
+type smallReadProvider struct {
+	*recordingProvider
+}
+
+func (p *smallReadProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	buf := make([]byte, 16)
+	for {
+		_, err := file.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &providers.ProviderResponse{URL: "https://example.com/" + filepath.Base(filePath)}, nil
+}
+
+func TestUpload_ListenersReceiveProgressWhenChannelUnread(t *testing.T) {
+	dir := t.TempDir()
+	content := bytes.Repeat([]byte("x"), 8*1024)
+	path := filepath.Join(dir, "big.bin")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	metrics := NewProgressMetrics()
+	uploader := NewDefaultUploader()
+	resultCh, _, err := uploader.Upload(context.Background(), []string{path}, UploadConfig{
+		Concurrency:       1,
+		Providers:         []Provider{&smallReadProvider{recordingProvider: newRecordingProvider("small")}},
+		ProgressListeners: []ProgressListener{metrics},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only results are consumed; the progress channel is never read
+	for result := range resultCh {
+		if result.Error != nil {
+			t.Fatalf("unexpected upload error: %v", result.Error)
+		}
+	}
+
+	if got := metrics.BytesUploaded(); got != int64(len(content)) {
+		t.Errorf("expected %d bytes recorded, got %d", len(content), got)
+	}
+	if got := metrics.FileBytes("big.bin"); got != int64(len(content)) {
+		t.Errorf("expected %d bytes for big.bin, got %d", len(content), got)
+	}
+	if events := metrics.Events(); events <= int64(cap(uploader.progressCh)) {
+		t.Errorf("expected more events than the channel buffer holds, got %d", events)
+	}
+}
+
+func TestProgressListenerFunc(t *testing.T) {
+	var received []ProgressInfo
+	b := newProgressBroadcaster(make(chan ProgressInfo), []ProgressListener{
+		ProgressListenerFunc(func(info ProgressInfo) { received = append(received, info) }),
+	})
+
+	b.Publish(ProgressInfo{FileName: "a.txt", BytesUploaded: 10})
+	b.Publish(ProgressInfo{FileName: "a.txt", BytesUploaded: 20})
+
+	if len(received) != 2 || received[1].BytesUploaded != 20 {
+		t.Errorf("expected both events delivered to the listener, got %+v", received)
+	}
+}
+
+// failOnceProvider drains the body and fails the first attempt with a retryable error
+type failOnceProvider struct {
+	*recordingProvider
+	calls int
+}
+
+func (p *failOnceProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	p.calls++
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return nil, err
+	}
+	if p.calls == 1 {
+		return nil, providers.NewNetworkError("connection reset", nil)
+	}
+	return &providers.ProviderResponse{URL: "https://example.com/" + filepath.Base(filePath)}, nil
+}
+
+func TestUpload_RetryAnnotatesProgress(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 1024)
+
+	config := providers.DefaultWrapperConfig()
+	config.RetryDelay = time.Millisecond
+	config.Jitter = false
+	provider := providers.NewConsistencyWrapper(&failOnceProvider{recordingProvider: newRecordingProvider("flaky")}, config)
+
+	var mu sync.Mutex
+	var events []ProgressInfo
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{provider},
+		ProgressListeners: []ProgressListener{ProgressListenerFunc(func(info ProgressInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, info)
+		})},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range collectResults(t, resultCh, progressCh) {
+		if result.Error != nil {
+			t.Fatalf("unexpected upload error: %v", result.Error)
+		}
+	}
+
+	retryIndex := -1
+	for i, info := range events {
+		if info.Phase != "" {
+			retryIndex = i
+			break
+		}
+		if info.Retry != 0 {
+			t.Errorf("first attempt should not be marked as a retry: %+v", info)
+		}
+	}
+	if retryIndex < 0 {
+		t.Fatalf("expected a retry phase event, got %+v", events)
+	}
+
+	marker := events[retryIndex]
+	if marker.Phase != "retrying (attempt 2)" || marker.Retry != 1 || marker.MaxRetries != config.MaxRetries {
+		t.Errorf("unexpected retry marker %+v", marker)
+	}
+	if marker.BytesUploaded != 0 {
+		t.Errorf("retry should reset progress to 0, got %d", marker.BytesUploaded)
+	}
+	last := events[len(events)-1]
+	if last.Retry != 1 || last.BytesUploaded != 1024 {
+		t.Errorf("expected the retried attempt to complete with its annotation, got %+v", last)
+	}
+}
+
+// throttledReader returns chunk bytes per read, sleeping delay before each
+type throttledReader struct {
+	reader io.Reader
+	chunk  int
+	delay  time.Duration
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	if len(p) > r.chunk {
+		p = p[:r.chunk]
+	}
+	return r.reader.Read(p)
+}
+
+func TestProgressReader_Speed(t *testing.T) {
+	// 1 KiB every 10ms is about 100 KiB/s
+	data := make([]byte, 48*1024)
+	var speeds []float64
+	reader := &progressReader{
+		reader:    &throttledReader{reader: bytes.NewReader(data), chunk: 1024, delay: 10 * time.Millisecond},
+		totalSize: int64(len(data)),
+		speed:     newSpeedEstimator(time.Now),
+		onProgress: func(bytesRead int64, speed float64) {
+			speeds = append(speeds, speed)
+		},
+	}
+
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	last := speeds[len(speeds)-1]
+	if last < 20*1024 || last > 110*1024 {
+		t.Errorf("expected a speed near 100 KiB/s, got %.0f B/s", last)
+	}
+}
+
+func TestSpeedEstimator_Smoothing(t *testing.T) {
+	clock := time.Unix(0, 0)
+	estimator := newSpeedEstimator(func() time.Time { return clock })
+
+	clock = clock.Add(50 * time.Millisecond)
+	if got := estimator.Update(1000); got != 0 {
+		t.Errorf("expected no estimate before the first sample interval, got %v", got)
+	}
+
+	clock = clock.Add(950 * time.Millisecond)
+	if got := estimator.Update(1000); got != 1000 {
+		t.Errorf("expected the first sample to set the rate, got %v", got)
+	}
+
+	clock = clock.Add(time.Second)
+	if got := estimator.Update(3000); got != 0.3*2000+0.7*1000 {
+		t.Errorf("expected a smoothed rate, got %v", got)
+	}
+
+	estimator.Reset(0)
+	if got := estimator.Update(0); got != 0 {
+		t.Errorf("expected the rate to restart after a reset, got %v", got)
+	}
+}
+
+func TestEstimateETA(t *testing.T) {
+	// 30 MiB of 100 MiB sent in 10s leaves 70 MiB at 3 MiB/s
+	speed := float64(30*1024*1024) / (10 * time.Second).Seconds()
+	if got := estimateETA(30*1024*1024, 100*1024*1024, speed); got.Round(time.Millisecond) != 23333*time.Millisecond {
+		t.Errorf("expected about 23.3s, got %v", got)
+	}
+	if got := estimateETA(100, 100, speed); got != 0 {
+		t.Errorf("expected no time left for a finished upload, got %v", got)
+	}
+	if got := estimateETA(10, 100, 0); got != 0 {
+		t.Errorf("expected 0 without a measured speed, got %v", got)
+	}
+	if got := estimateETA(10, 0, speed); got != 0 {
+		t.Errorf("expected 0 without a known total, got %v", got)
+	}
+}
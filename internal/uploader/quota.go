@@ -0,0 +1,45 @@
+package uploader
+
+import (
+	"sync"
+)
+
+// quotaTracker remembers the providers that refused an upload because of a
+// provider-imposed limit during a run. A daily or storage limit does not clear
+// between files, so later files try those providers last.
+type quotaTracker struct {
+	mu        sync.Mutex
+	exhausted map[string]bool
+}
+
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{exhausted: make(map[string]bool)}
+}
+
+// Mark records that the named provider reported a quota error
+func (q *quotaTracker) Mark(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.exhausted[name] = true
+}
+
+// Order returns the providers with those that hit a limit moved to the end,
+// keeping the configured order within each group. They stay in the list in
+// case every other provider fails too.
+func (q *quotaTracker) Order(list []Provider) []Provider {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.exhausted) == 0 {
+		return list
+	}
+	ordered := make([]Provider, 0, len(list))
+	var limited []Provider
+	for _, provider := range list {
+		if q.exhausted[provider.Name()] {
+			limited = append(limited, provider)
+			continue
+		}
+		ordered = append(ordered, provider)
+	}
+	return append(ordered, limited...)
+}
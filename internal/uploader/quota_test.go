@@ -0,0 +1,77 @@
+package uploader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// limitedQuotaProvider refuses every upload the way a provider over its daily limit does
+type limitedQuotaProvider struct {
+	*recordingProvider
+	calls int
+}
+
+func (p *limitedQuotaProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+	return nil, providers.QuotaError(p.name, http.StatusForbidden, `{"error":"Daily limit reached"}`)
+}
+
+func TestUpload_QuotaErrorFailsOverAndDemotesProvider(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		paths = append(paths, path)
+	}
+
+	limited := &limitedQuotaProvider{recordingProvider: newRecordingProvider("limited")}
+	fallback := newRecordingProvider("fallback")
+	wrapped := providers.NewConsistencyWrapper(limited, providers.DefaultWrapperConfig())
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{wrapped, fallback},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, result := range collectResults(t, resultCh, progressCh) {
+		if result.Error != nil || result.Provider != "fallback" {
+			t.Errorf("expected %s to fail over to the fallback provider, got %+v", result.FileName, result)
+		}
+	}
+	if len(fallback.bodies) != 3 {
+		t.Errorf("expected every file on the fallback provider, got %d", len(fallback.bodies))
+	}
+	// Not retried, and tried last once it reported the limit
+	if limited.calls != 1 {
+		t.Errorf("expected the limited provider to be asked once, got %d calls", limited.calls)
+	}
+}
+
+func TestQuotaTracker_Order(t *testing.T) {
+	a, b, c := newRecordingProvider("a"), newRecordingProvider("b"), newRecordingProvider("c")
+	quotas := newQuotaTracker()
+	list := []Provider{a, b, c}
+
+	if got := quotas.Order(list); got[0] != a || got[1] != b || got[2] != c {
+		t.Error("expected the configured order before any limit is hit")
+	}
+
+	quotas.Mark("a")
+	if got := quotas.Order(list); got[0] != b || got[1] != c || got[2] != a {
+		t.Errorf("expected the limited provider last, got %s, %s, %s", got[0].Name(), got[1].Name(), got[2].Name())
+	}
+}
@@ -0,0 +1,147 @@
+package uploader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// raceFile uploads a file to every provider at once and reports the first
+// success. Each attempt is its own job: it waits for its provider's share of
+// the slots and then for one of the run's slots, so the run never has more
+// than the configured concurrency of uploads in flight. The winner cancels
+// the file's context, which stops the remaining attempts; they are not
+// reported. When every attempt fails the file gets a single failure result,
+// like the first success strategy.
+func (u *DefaultUploader) raceFile(ctx context.Context, fileInfo FileInfo, size int64, source io.ReaderAt, opts providers.UploadOptions, digests *providers.FileDigests, config UploadConfig, progress *progressBroadcaster, budget *byteBudget, limits providerLimits, slots *semaphore.Weighted, quotas *quotaTracker, results *resultSender) {
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		won     bool
+		aborted bool
+		lastErr error
+	)
+	var g errgroup.Group
+	for _, provider := range config.Providers {
+		g.Go(func() error {
+			// Each attempt reads its own section of the content and is charged its own share of the reservation
+			account := &budgetAccount{budget: budget, reserved: size, abort: config.AbortOverBudget}
+			defer account.Settle()
+
+			var body io.Reader = io.NewSectionReader(source, 0, size)
+			if budget != nil {
+				body = &budgetReader{reader: body, account: account}
+			}
+			buffered := newBufferedReader(body, config.ioBufferSize())
+			uploadCtx, progressReader, hasher := newAttempt(raceCtx, fileInfo, size, config, progress, buffered)
+
+			// The provider's share first, then a run slot, so a waiting
+			// attempt never holds a run slot another provider could use
+			release, err := limits.acquire(raceCtx, provider.Name())
+			if err != nil {
+				return nil // Another provider won or the run was cancelled
+			}
+			if err := slots.Acquire(raceCtx, 1); err != nil {
+				release()
+				return nil
+			}
+			start := time.Now()
+			response, err := sendFile(uploadCtx, provider, progressReader, opts, config)
+			duration := time.Since(start)
+			slots.Release(1)
+			release()
+
+			mu.Lock()
+			if won {
+				mu.Unlock()
+				return nil // Lost the race
+			}
+			if err != nil {
+				if raceCtx.Err() == nil {
+					logging.UploadError(fileInfo.Name, provider.Name(), err)
+					if providers.IsQuotaError(err) {
+						quotas.Mark(provider.Name())
+					}
+					aborted = aborted || account.aborted
+					lastErr = err
+				}
+				mu.Unlock()
+				return nil
+			}
+			won = true
+			mu.Unlock()
+			cancel()
+
+			// Sending can wait on the consumer, so it happens outside the lock
+			result := successResult(fileInfo, provider, size, response, hasher, digests, duration)
+			logging.UploadComplete(fileInfo.Name, result.URL, duration)
+			results.Send(result)
+			return nil
+		})
+	}
+	g.Wait()
+
+	switch {
+	case won:
+	case ctx.Err() != nil:
+		// Interrupted rather than failed
+		results.Send(cancelledResult(fileInfo, ctx.Err()))
+	case aborted:
+		results.Send(UploadResult{
+			FileName: fileInfo.Name,
+			FilePath: fileInfo.Path,
+			Size:     size,
+			Error:    fmt.Errorf("upload aborted: %w", ErrByteBudgetExhausted),
+		})
+	default:
+		results.Send(UploadResult{
+			FileName: fileInfo.Name,
+			FilePath: fileInfo.Path,
+			Error:    fmt.Errorf("all providers failed, last error: %w", lastErr),
+		})
+	}
+}
+
+// newRaceLimits caps each provider at its weighted share of the run's
+// concurrency when racing, or at its own max_concurrency when that is lower.
+// Shares round up, so the weights never leave a run slot unusable.
+func newRaceLimits(providerList []Provider, concurrency int) providerLimits {
+	if concurrency <= 0 {
+		return newProviderLimits(providerList, concurrency)
+	}
+	total := 0
+	for _, provider := range providerList {
+		total += providers.ProviderWeight(provider)
+	}
+
+	limits := make(providerLimits)
+	for _, provider := range providerList {
+		weight := providers.ProviderWeight(provider)
+		limit := (concurrency*weight + total - 1) / total
+		if limiter, ok := provider.(providers.ConcurrencyLimiter); ok {
+			if declared := limiter.MaxConcurrency(); declared > 0 && declared < limit {
+				limit = declared
+			}
+		}
+		if limit >= concurrency {
+			continue
+		}
+		limits[provider.Name()] = semaphore.NewWeighted(int64(limit))
+		logging.Debug("Provider share of upload slots", logrus.Fields{
+			"provider": provider.Name(),
+			"weight":   weight,
+			"slots":    limit,
+		})
+	}
+	return limits
+}
@@ -0,0 +1,238 @@
+package uploader
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// stallingProvider accepts the body and then waits until its upload is
+// cancelled; started is closed once the upload began
+type stallingProvider struct {
+	*recordingProvider
+	started   chan struct{}
+	cancelled chan error
+}
+
+func (p *stallingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	close(p.started)
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		p.cancelled <- ctx.Err()
+		return nil, ctx.Err()
+	case <-time.After(5 * time.Second):
+		return nil, io.ErrUnexpectedEOF
+	}
+}
+
+// waitingProvider succeeds once wait is closed
+type waitingProvider struct {
+	*recordingProvider
+	wait <-chan struct{}
+}
+
+func (p *waitingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	<-p.wait
+	return p.recordingProvider.Upload(ctx, filePath, file, size)
+}
+
+func TestUpload_RaceCancelsLosingProvider(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 10)
+	slow := &stallingProvider{recordingProvider: newRecordingProvider("slow"), started: make(chan struct{}), cancelled: make(chan error, 1)}
+	// The fast provider wins only once the slow upload is under way
+	fast := &waitingProvider{recordingProvider: newRecordingProvider("fast"), wait: slow.started}
+
+	// Each attempt takes a run slot, so both need one to race
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 2,
+		Providers:   []Provider{slow, fast},
+		Strategy:    StrategyRace,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 1 {
+		t.Fatalf("expected a single result for the file, got %d", len(results))
+	}
+	if results[0].Provider != "fast" || results[0].Error != nil {
+		t.Errorf("expected a success from the fast provider, got %+v", results[0])
+	}
+	if len(fast.bodies["a.bin"]) != 10 {
+		t.Error("expected the fast provider to receive the whole file")
+	}
+
+	select {
+	case err := <-slow.cancelled:
+		if err != context.Canceled {
+			t.Errorf("expected the slow upload to be cancelled, got %v", err)
+		}
+	default:
+		t.Error("expected the slow provider's attempt to be cancelled once the fast one won")
+	}
+}
+
+func TestUpload_RaceReportsFailureOnce(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin"}, 10)
+	first := &drainThenFailProvider{recordingProvider: newRecordingProvider("first")}
+	second := &drainThenFailProvider{recordingProvider: newRecordingProvider("second")}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{first, second},
+		Strategy:    StrategyRace,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 1 {
+		t.Fatalf("expected a single result for the file, got %d", len(results))
+	}
+	if results[0].Error == nil || results[0].Cancelled {
+		t.Errorf("expected a failure once every provider failed, got %+v", results[0])
+	}
+}
+
+// inFlight records the peak number of uploads running at once across providers
+type inFlight struct {
+	active atomic.Int32
+	peak   atomic.Int32
+}
+
+// countingProvider takes a moment per upload and counts it in a shared inFlight
+type countingProvider struct {
+	*recordingProvider
+	counter *inFlight
+}
+
+func (p *countingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	n := p.counter.active.Add(1)
+	defer p.counter.active.Add(-1)
+	for {
+		peak := p.counter.peak.Load()
+		if n <= peak || p.counter.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return p.recordingProvider.Upload(ctx, filePath, file, size)
+}
+
+func TestUpload_RaceKeepsRunConcurrency(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin", "c.bin", "d.bin"}, 10)
+	counter := &inFlight{}
+	first := &countingProvider{recordingProvider: newRecordingProvider("first"), counter: counter}
+	second := &countingProvider{recordingProvider: newRecordingProvider("second"), counter: counter}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 2,
+		Providers:   []Provider{first, second},
+		Strategy:    StrategyRace,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 4 {
+		t.Fatalf("expected one result per file, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("unexpected error for %s: %v", result.FileName, result.Error)
+		}
+	}
+	if peak := counter.peak.Load(); peak > 2 {
+		t.Errorf("expected at most 2 uploads at once across providers, saw %d", peak)
+	}
+}
+
+// weightedProvider declares a scheduling weight
+type weightedProvider struct {
+	*recordingProvider
+	weight int
+}
+
+func (p *weightedProvider) Weight() int { return p.weight }
+
+// slotsOf counts how many slots the limit of the named provider hands out
+func slotsOf(limits providerLimits, name string) int {
+	sem, ok := limits[name]
+	if !ok {
+		return -1
+	}
+	n := 0
+	for sem.TryAcquire(1) {
+		n++
+	}
+	return n
+}
+
+func TestNewRaceLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		providers   []Provider
+		concurrency int
+		expected    map[string]int // -1 means no limit
+	}{
+		{
+			name:        "equal weights split the slots",
+			providers:   []Provider{newRecordingProvider("a"), newRecordingProvider("b")},
+			concurrency: 4,
+			expected:    map[string]int{"a": 2, "b": 2},
+		},
+		{
+			name: "weights share out the slots",
+			providers: []Provider{
+				&weightedProvider{recordingProvider: newRecordingProvider("heavy"), weight: 3},
+				&weightedProvider{recordingProvider: newRecordingProvider("light"), weight: 1},
+			},
+			concurrency: 4,
+			expected:    map[string]int{"heavy": 3, "light": 1},
+		},
+		{
+			name: "shares round up",
+			providers: []Provider{
+				newRecordingProvider("a"), newRecordingProvider("b"), newRecordingProvider("c"),
+			},
+			concurrency: 4,
+			expected:    map[string]int{"a": 2, "b": 2, "c": 2},
+		},
+		{
+			name: "a lower max_concurrency wins",
+			providers: []Provider{
+				&limitedProvider{recordingProvider: newRecordingProvider("limited"), max: 1},
+				newRecordingProvider("plain"),
+			},
+			concurrency: 6,
+			expected:    map[string]int{"limited": 1, "plain": 3},
+		},
+		{
+			name:        "a share of every slot is no limit",
+			providers:   []Provider{newRecordingProvider("a"), newRecordingProvider("b")},
+			concurrency: 1,
+			expected:    map[string]int{"a": -1, "b": -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := newRaceLimits(tt.providers, tt.concurrency)
+			for name, want := range tt.expected {
+				if got := slotsOf(limits, name); got != want {
+					t.Errorf("%s: expected %d slots, got %d", name, want, got)
+				}
+			}
+		})
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // DefaultScanner implements the Scanner interface
-type DefaultScanner struct{}
+type DefaultScanner struct {
+	Filter        *PathFilter // Selects files inside scanned folders, nil for all of them
+	IncludeHidden bool        // Keep files and directories inside folders whose name starts with "."
+}
 
 // Scan scans the given paths and returns channels for file info and errors
 func (s *DefaultScanner) Scan(ctx context.Context, paths []string) (<-chan FileInfo, <-chan error) {
@@ -41,6 +45,15 @@ func (s *DefaultScanner) Scan(ctx context.Context, paths []string) (<-chan FileI
 }
 
 func (s *DefaultScanner) walkPath(ctx context.Context, root string, fileCh chan<- FileInfo) error {
+	if root == StdinPath {
+		select {
+		case fileCh <- stdinFileInfo():
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
 	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -56,6 +69,22 @@ func (s *DefaultScanner) walkPath(ctx context.Context, root string, fileCh chan<
 			return nil
 		}
 
+		// Filter what folders contain, hidden entries included; the root itself was asked for explicitly
+		if path != root {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			hidden := !s.IncludeHidden && strings.HasPrefix(info.Name(), ".")
+			if info.IsDir() && (hidden || s.Filter.SkipDir(rel)) {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() && (hidden || !s.Filter.MatchFile(rel)) {
+				return nil
+			}
+		}
+
 		fileInfo := FileInfo{
 			Path:     path,
 			Name:     info.Name(),
@@ -0,0 +1,70 @@
+package uploader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// scanFiles scans root and returns the emitted files relative to root, sorted
+func scanFiles(t *testing.T, root string, scanner *DefaultScanner) []string {
+	t.Helper()
+	fileCh, errCh := scanner.Scan(context.Background(), []string{root})
+
+	var files []string
+	for fileInfo := range fileCh {
+		if fileInfo.IsDir {
+			continue
+		}
+		rel, err := filepath.Rel(root, fileInfo.Path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	for err := range errCh {
+		t.Fatalf("unexpected scan error: %v", err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// writeTree creates a temporary directory holding the given slash-separated files
+func writeTree(t *testing.T, paths ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, path := range paths {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte("x"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	return root
+}
+
+func TestScan_SkipsHiddenByDefault(t *testing.T) {
+	root := writeTree(t, "main.go", ".env", ".git/config", ".git/objects/ab/cdef", "src/.cache/x", "src/app.go")
+
+	if got := scanFiles(t, root, &DefaultScanner{}); !reflect.DeepEqual(got, []string{"main.go", "src/app.go"}) {
+		t.Errorf("expected hidden files and directories to be skipped, got %v", got)
+	}
+
+	expected := []string{".env", ".git/config", ".git/objects/ab/cdef", "main.go", "src/.cache/x", "src/app.go"}
+	if got := scanFiles(t, root, &DefaultScanner{IncludeHidden: true}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected hidden entries with IncludeHidden, got %v", got)
+	}
+}
+
+func TestScan_HiddenRootIsScanned(t *testing.T) {
+	root := filepath.Join(writeTree(t, ".config/woof.yaml"), ".config")
+
+	if got := scanFiles(t, root, &DefaultScanner{}); !reflect.DeepEqual(got, []string{"woof.yaml"}) {
+		t.Errorf("expected a hidden folder named explicitly to be scanned, got %v", got)
+	}
+}
@@ -0,0 +1,183 @@
+package uploader
+
+import (
+	"sort"
+	"time"
+)
+
+// ExpiryWarningWindow is how soon a link must expire to be listed in the summary
+const ExpiryWarningWindow = 24 * time.Hour
+
+// ExpiringLink is a successful upload whose link expires soon
+type ExpiringLink struct {
+	FileName string
+	Provider string
+	URL      string
+	Expires  time.Time
+}
+
+// RetryStats counts how many successful uploads needed retries, from the
+// attempt count the consistency wrapper records in the response metadata
+type RetryStats struct {
+	FirstTry int // Succeeded on the first attempt (or without attempt data)
+	Retried  int // Succeeded after at least one retry
+	Retries  int // Retries spent across all successful uploads
+}
+
+// Add counts a successful result; other results are ignored
+func (s *RetryStats) Add(result UploadResult) {
+	if result.Cancelled || result.Skipped || result.Error != nil {
+		return
+	}
+	if attempts := result.Attempts(); attempts > 1 {
+		s.Retried++
+		s.Retries += attempts - 1
+		return
+	}
+	s.FirstTry++
+}
+
+// ProviderCount tallies the results attributed to one provider
+type ProviderCount struct {
+	Succeeded int
+	Failed    int
+}
+
+// Summary describes a finished upload run
+type Summary struct {
+	Succeeded     int
+	Failed        int
+	Cancelled     int
+	Skipped       int
+	Files         int                      // Distinct files with a result; mirrored files count once
+	BytesUploaded int64                    // Bytes of successful uploads
+	Duration      time.Duration            // Wall time from the start of Drain until all results arrived
+	ExpiringSoon  []ExpiringLink           // Links expiring within ExpiryWarningWindow, soonest first
+	Providers     map[string]ProviderCount // Results per provider; a file every provider failed has no provider and is left out
+	RetryStats
+
+	files map[string]bool
+}
+
+// ProviderNames returns the providers in Providers, sorted
+func (s Summary) ProviderNames() []string {
+	names := make([]string, 0, len(s.Providers))
+	for name := range s.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Total returns the number of results in the run
+func (s Summary) Total() int {
+	return s.Succeeded + s.Failed + s.Cancelled + s.Skipped
+}
+
+// Add counts a result towards the summary
+func (s *Summary) Add(result UploadResult) {
+	s.RetryStats.Add(result)
+	s.countFile(result)
+	s.countProvider(result)
+	switch {
+	case result.Cancelled:
+		s.Cancelled++
+	case result.Skipped:
+		s.Skipped++
+	case result.Error != nil:
+		s.Failed++
+	default:
+		s.Succeeded++
+		s.BytesUploaded += result.Size
+		if result.Expires != nil && time.Until(*result.Expires) <= ExpiryWarningWindow {
+			s.ExpiringSoon = append(s.ExpiringSoon, ExpiringLink{
+				FileName: result.FileName,
+				Provider: result.Provider,
+				URL:      result.URL,
+				Expires:  *result.Expires,
+			})
+			sort.SliceStable(s.ExpiringSoon, func(i, j int) bool {
+				return s.ExpiringSoon[i].Expires.Before(s.ExpiringSoon[j].Expires)
+			})
+		}
+	}
+}
+
+// countFile counts the result's file towards Files unless it was seen before
+func (s *Summary) countFile(result UploadResult) {
+	key := result.FilePath
+	if key == "" {
+		key = result.FileName
+	}
+	if s.files == nil {
+		s.files = make(map[string]bool)
+	}
+	if !s.files[key] {
+		s.files[key] = true
+		s.Files++
+	}
+}
+
+// countProvider tallies a success or failure against the provider that produced it
+func (s *Summary) countProvider(result UploadResult) {
+	if result.Provider == "" || result.Cancelled || result.Skipped {
+		return
+	}
+	if s.Providers == nil {
+		s.Providers = make(map[string]ProviderCount)
+	}
+	count := s.Providers[result.Provider]
+	if result.Error != nil {
+		count.Failed++
+	} else {
+		count.Succeeded++
+	}
+	s.Providers[result.Provider] = count
+}
+
+// Sink receives the output of an upload run. The CLI adapts its output handlers
+// to a Sink; applications embedding woof can supply their own to drive a GUI.
+type Sink interface {
+	OnResult(result UploadResult) error
+	OnProgress(progress ProgressInfo) error
+	OnSummary(summary Summary) error
+}
+
+// Drain feeds the channels returned by Uploader.Upload into sink until the result
+// channel closes, then reports the summary. Progress events still queued when the
+// results end are dropped. The first sink error stops delivery; the channels are
+// still drained so the uploader can finish.
+func Drain(resultCh <-chan UploadResult, progressCh <-chan ProgressInfo, sink Sink) (Summary, error) {
+	start := time.Now()
+	var summary Summary
+	var sinkErr error
+
+	for resultCh != nil {
+		select {
+		case result, ok := <-resultCh:
+			if !ok {
+				resultCh = nil
+				continue
+			}
+			summary.Add(result)
+			if sinkErr == nil {
+				sinkErr = sink.OnResult(result)
+			}
+
+		case progress, ok := <-progressCh:
+			if !ok {
+				progressCh = nil // Stop selecting on the closed channel
+				continue
+			}
+			if sinkErr == nil {
+				sinkErr = sink.OnProgress(progress)
+			}
+		}
+	}
+
+	summary.Duration = time.Since(start)
+	if sinkErr != nil {
+		return summary, sinkErr
+	}
+	return summary, sink.OnSummary(summary)
+}
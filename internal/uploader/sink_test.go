@@ -0,0 +1,202 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// memorySink records every callback
+type memorySink struct {
+	mu        sync.Mutex
+	results   []UploadResult
+	progress  []ProgressInfo
+	summaries []Summary
+}
+
+func (s *memorySink) OnResult(result UploadResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, result)
+	return nil
+}
+
+func (s *memorySink) OnProgress(progress ProgressInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progress = append(s.progress, progress)
+	return nil
+}
+
+func (s *memorySink) OnSummary(summary Summary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summaries = append(s.summaries, summary)
+	return nil
+}
+
+func TestDrain_CustomSink(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin"}, 64)
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 2,
+		Providers:   []Provider{newRecordingProvider("recorder")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sink := &memorySink{}
+	summary, err := Drain(resultCh, progressCh, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(sink.results))
+	}
+	if len(sink.progress) == 0 {
+		t.Error("expected progress callbacks")
+	}
+	if len(sink.summaries) != 1 {
+		t.Fatalf("expected exactly one summary, got %d", len(sink.summaries))
+	}
+	if !reflect.DeepEqual(sink.summaries[0], summary) || summary.Succeeded != 2 || summary.BytesUploaded != 128 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
+
+// failingSink rejects every result
+type failingSink struct {
+	memorySink
+}
+
+func (s *failingSink) OnResult(result UploadResult) error {
+	s.memorySink.OnResult(result)
+	return errors.New("display closed")
+}
+
+func TestDrain_StopsDeliveringAfterSinkError(t *testing.T) {
+	paths := writeFiles(t, []string{"a.bin", "b.bin", "c.bin"}, 16)
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{newRecordingProvider("recorder")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sink := &failingSink{}
+	summary, err := Drain(resultCh, progressCh, sink)
+	if err == nil {
+		t.Fatal("expected the sink error to be returned")
+	}
+	if len(sink.results) != 1 || len(sink.summaries) != 0 {
+		t.Errorf("expected delivery to stop after the error, got %d results and %d summaries", len(sink.results), len(sink.summaries))
+	}
+	if summary.Total() != 3 {
+		t.Errorf("expected all results to be drained, got %d", summary.Total())
+	}
+}
+
+// expiringProvider reports an expiry per file name
+type expiringProvider struct {
+	*recordingProvider
+	expires map[string]time.Time
+}
+
+func (p *expiringProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	response, err := p.recordingProvider.Upload(ctx, filePath, file, size)
+	if err != nil {
+		return nil, err
+	}
+	if expires, ok := p.expires[filepath.Base(filePath)]; ok {
+		response.Expires = &expires
+	}
+	return response, nil
+}
+
+func TestDrain_SummaryListsExpiringLinks(t *testing.T) {
+	paths := writeFiles(t, []string{"soon.bin", "later.bin", "sooner.bin", "kept.bin"}, 8)
+	now := time.Now()
+	provider := &expiringProvider{
+		recordingProvider: newRecordingProvider("expiring"),
+		expires: map[string]time.Time{
+			"soon.bin":   now.Add(12 * time.Hour),
+			"later.bin":  now.Add(72 * time.Hour),
+			"sooner.bin": now.Add(time.Hour),
+		},
+	}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), paths, UploadConfig{
+		Concurrency: 2,
+		Providers:   []Provider{provider},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sink := &memorySink{}
+	summary, err := Drain(resultCh, progressCh, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, result := range sink.results {
+		expected, ok := provider.expires[result.FileName]
+		if !ok {
+			if result.Expires != nil {
+				t.Errorf("%s: expected no expiry, got %v", result.FileName, result.Expires)
+			}
+			continue
+		}
+		if result.Expires == nil || !result.Expires.Equal(expected) {
+			t.Errorf("%s: expected expiry %v, got %v", result.FileName, expected, result.Expires)
+		}
+	}
+
+	if len(summary.ExpiringSoon) != 2 {
+		t.Fatalf("expected 2 links expiring within 24h, got %+v", summary.ExpiringSoon)
+	}
+	if summary.ExpiringSoon[0].FileName != "sooner.bin" || summary.ExpiringSoon[1].FileName != "soon.bin" {
+		t.Errorf("expected soonest first, got %+v", summary.ExpiringSoon)
+	}
+}
+
+func TestSummary_TalliesMixedResults(t *testing.T) {
+	var summary Summary
+	for _, result := range []UploadResult{
+		{FileName: "a.bin", FilePath: "/tmp/a.bin", Provider: "GoFile", Size: 10},
+		{FileName: "a.bin", FilePath: "/tmp/a.bin", Provider: "BuzzHeavier", Error: errors.New("timeout")},
+		{FileName: "b.bin", FilePath: "/tmp/b.bin", Provider: "GoFile", Size: 5},
+		{FileName: "c.bin", FilePath: "/tmp/c.bin", Error: errors.New("all providers failed")},
+		{FileName: "d.bin", FilePath: "/tmp/d.bin", Cancelled: true, Error: context.Canceled},
+	} {
+		summary.Add(result)
+	}
+
+	if summary.Files != 4 || summary.Total() != 5 {
+		t.Errorf("expected 5 results for 4 files, got %d for %d", summary.Total(), summary.Files)
+	}
+	if summary.Succeeded != 2 || summary.Failed != 2 || summary.Cancelled != 1 || summary.BytesUploaded != 15 {
+		t.Errorf("unexpected totals %+v", summary)
+	}
+	expected := map[string]ProviderCount{
+		"GoFile":      {Succeeded: 2},
+		"BuzzHeavier": {Failed: 1},
+	}
+	if !reflect.DeepEqual(summary.Providers, expected) {
+		t.Errorf("expected provider counts %v, got %v", expected, summary.Providers)
+	}
+	if names := summary.ProviderNames(); !reflect.DeepEqual(names, []string{"BuzzHeavier", "GoFile"}) {
+		t.Errorf("expected sorted provider names, got %v", names)
+	}
+}
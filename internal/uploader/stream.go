@@ -0,0 +1,141 @@
+package uploader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+	"github.com/sirupsen/logrus"
+)
+
+// StdinPath is the path that selects UploadConfig.Stdin as the upload source
+const StdinPath = "-"
+
+// UnknownSize is the size of a source whose length is not known until it is read
+const UnknownSize int64 = -1
+
+// defaultStdinName names the standard input upload when UploadConfig.StdinName is empty
+const defaultStdinName = "stdin"
+
+// stdinFileInfo describes the standard input source emitted by the scanner
+func stdinFileInfo() FileInfo {
+	return FileInfo{Path: StdinPath, Name: defaultStdinName, Size: UnknownSize, Modified: time.Now()}
+}
+
+// streamsDirectly reports whether standard input can go straight to the
+// provider. The stream can only be read once, so this requires a single
+// provider that accepts bodies of unknown length; otherwise the input is
+// spooled to a temporary file first so its length is known and every
+// provider, retry or mirror can reread it.
+func streamsDirectly(config UploadConfig) bool {
+	return len(config.Providers) == 1 && config.Strategy != StrategyMirror &&
+		providers.AcceptsUnknownLength(config.Providers[0])
+}
+
+// spoolStdin copies r into a temporary file and returns its path; the caller removes it
+func spoolStdin(r io.Reader, bufSize int) (string, int64, error) {
+	if r == nil {
+		return "", 0, fmt.Errorf("no standard input configured")
+	}
+	file, err := os.CreateTemp("", "woof-stdin-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	// Hide WriterTo/ReaderFrom so the copy uses the configured buffer
+	size, err := io.CopyBuffer(struct{ io.Writer }{file}, struct{ io.Reader }{r}, make([]byte, bufSize))
+	if err != nil {
+		os.Remove(file.Name())
+		return "", 0, err
+	}
+	return file.Name(), size, nil
+}
+
+// streamUpload sends standard input to the single configured provider as it
+// is read. Without a seekable body there are no retries or fallbacks, and
+// the byte budget is not consulted since the size is unknown up front.
+func (u *DefaultUploader) streamUpload(ctx context.Context, fileInfo FileInfo, config UploadConfig, progress *progressBroadcaster, results *resultSender) error {
+	provider := config.Providers[0]
+	logging.Debug("Streaming standard input", logrus.Fields{
+		"provider": provider.Name(),
+		"file":     fileInfo.Name,
+	})
+
+	var source io.Reader = config.Stdin
+	var hasher *hashingReader
+	if config.Checksum {
+		hasher = newHashingReader(source)
+		source = hasher
+	}
+
+	reader := &progressReader{
+		reader:    source,
+		totalSize: UnknownSize,
+		speed:     newSpeedEstimator(time.Now),
+		onProgress: func(bytesRead int64, speed float64) {
+			progress.Publish(ProgressInfo{
+				FileName:      fileInfo.Name,
+				BytesUploaded: bytesRead,
+				TotalBytes:    UnknownSize,
+				Speed:         speed,
+			})
+		},
+	}
+
+	start := time.Now()
+	opts := providers.UploadOptions{FilePath: TrimName(fileInfo.Name, config.MaxNameLen), Size: UnknownSize}
+	response, err := providers.UploadWithOptions(ctx, provider, reader, opts)
+	duration := time.Since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			results.Send(cancelledResult(fileInfo, ctx.Err()))
+			return nil
+		}
+		logging.UploadError(fileInfo.Name, provider.Name(), err)
+		results.Send(UploadResult{
+			FileName: fileInfo.Name,
+			FilePath: fileInfo.Path,
+			Size:     reader.bytesRead,
+			Provider: provider.Name(),
+			Duration: duration,
+			Error:    err,
+		})
+		return nil
+	}
+
+	result := UploadResult{
+		FileName:   fileInfo.Name,
+		FilePath:   fileInfo.Path,
+		Size:       reader.bytesRead,
+		Provider:   provider.Name(),
+		Duration:   duration,
+		SpeedBps:   speedBps(reader.bytesRead, duration),
+		UploadTime: time.Now(),
+		Response:   response,
+	}
+	if response != nil {
+		result.URL = response.URL
+		result.DeleteURL = response.DeleteURL
+		result.ID = response.ID
+		result.Expires = response.Expires
+		result.Metadata = response.Metadata
+	}
+	if hasher != nil {
+		result.Checksum = hasher.Sum()
+		if response != nil {
+			if response.Metadata == nil {
+				response.Metadata = make(map[string]string)
+			}
+			response.Metadata[MetadataChecksum] = result.Checksum
+			result.Metadata = response.Metadata
+		}
+	}
+	logging.UploadComplete(fileInfo.Name, result.URL, duration)
+	results.Send(result)
+	return nil
+}
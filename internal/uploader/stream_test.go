@@ -0,0 +1,116 @@
+package uploader
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// sizeRecordingProvider records the size and body type each upload is given
+type sizeRecordingProvider struct {
+	*recordingProvider
+	streaming bool
+	sizes     []int64
+	seekable  []bool
+}
+
+func (p *sizeRecordingProvider) AcceptsUnknownLength() bool { return p.streaming }
+
+func (p *sizeRecordingProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	p.mu.Lock()
+	p.sizes = append(p.sizes, size)
+	_, seekable := file.(io.Seeker)
+	p.seekable = append(p.seekable, seekable)
+	p.mu.Unlock()
+	return p.recordingProvider.Upload(ctx, filePath, file, size)
+}
+
+// stdinOnly hides everything but Read, as os.Stdin on a pipe would behave
+type stdinOnly struct{ io.Reader }
+
+func uploadStdin(t *testing.T, config UploadConfig) []UploadResult {
+	t.Helper()
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{StdinPath}, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results := collectResults(t, resultCh, progressCh)
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("expected one successful result, got %+v", results)
+	}
+	return results
+}
+
+func TestUpload_StdinStreamsToCapableProvider(t *testing.T) {
+	provider := &sizeRecordingProvider{recordingProvider: newRecordingProvider("streaming"), streaming: true}
+	content := strings.Repeat("piped data ", 100)
+
+	results := uploadStdin(t, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{provider},
+		Stdin:       stdinOnly{strings.NewReader(content)},
+		StdinName:   "log.txt",
+	})
+
+	if len(provider.sizes) != 1 || provider.sizes[0] != UnknownSize {
+		t.Errorf("expected the provider to get an unknown size, got %v", provider.sizes)
+	}
+	if string(provider.bodies["log.txt"]) != content {
+		t.Errorf("expected the piped content under the stdin name, got %q", provider.bodies)
+	}
+	if results[0].Size != int64(len(content)) || results[0].FileName != "log.txt" {
+		t.Errorf("expected the result to report the streamed size, got %+v", results[0])
+	}
+}
+
+func TestUpload_StdinBufferedForIncapableProvider(t *testing.T) {
+	provider := &sizeRecordingProvider{recordingProvider: newRecordingProvider("sized")}
+	content := strings.Repeat("piped data ", 100)
+
+	results := uploadStdin(t, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{provider},
+		Stdin:       stdinOnly{strings.NewReader(content)},
+	})
+
+	if len(provider.sizes) != 1 || provider.sizes[0] != int64(len(content)) {
+		t.Errorf("expected the provider to get the buffered length, got %v", provider.sizes)
+	}
+	if len(provider.seekable) != 1 || !provider.seekable[0] {
+		t.Error("expected a rewindable body so retries can resend it")
+	}
+	if string(provider.bodies[defaultStdinName]) != content {
+		t.Errorf("expected the piped content, got %q", provider.bodies)
+	}
+	if results[0].Size != int64(len(content)) {
+		t.Errorf("expected the result to report the buffered size, got %d", results[0].Size)
+	}
+}
+
+func TestUpload_StdinBufferedWhenMirroring(t *testing.T) {
+	first := &sizeRecordingProvider{recordingProvider: newRecordingProvider("first"), streaming: true}
+	second := &sizeRecordingProvider{recordingProvider: newRecordingProvider("second"), streaming: true}
+
+	resultCh, progressCh, err := NewDefaultUploader().Upload(context.Background(), []string{StdinPath}, UploadConfig{
+		Concurrency: 1,
+		Providers:   []Provider{first, second},
+		Strategy:    StrategyMirror,
+		Stdin:       stdinOnly{strings.NewReader("data")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results := collectResults(t, resultCh, progressCh); len(results) != 2 {
+		t.Fatalf("expected a result per provider, got %+v", results)
+	}
+
+	// A stream can be read once, so both copies come from the buffer
+	for _, p := range []*sizeRecordingProvider{first, second} {
+		if len(p.sizes) != 1 || p.sizes[0] != 4 {
+			t.Errorf("%s: expected the buffered length, got %v", p.name, p.sizes)
+		}
+	}
+}
@@ -2,10 +2,13 @@ package uploader
 
 import (
 	"context"
+	"encoding/json"
 	"io"
+	"strconv"
 	"time"
 
 	"github.com/parnexcodes/woof/internal/providers"
+	"github.com/parnexcodes/woof/internal/timefmt"
 )
 
 // UploadResult represents the result of a file upload
@@ -14,15 +17,69 @@ type UploadResult struct {
 	FilePath    string                     `json:"filepath"`
 	Size        int64                      `json:"size"`
 	URL         string                     `json:"url"`            // Convenience field, extracted from Response
+	DeleteURL   string                     `json:"delete_url,omitempty"` // Copied from Response, empty when the provider offers none
+	ID          string                     `json:"id,omitempty"`
+	Expires     *time.Time                 `json:"expires,omitempty"`    // When the provider removes the file, nil if it keeps it
+	Metadata    map[string]string          `json:"metadata,omitempty"`
+	Checksum    string                     `json:"checksum,omitempty"` // SHA-256 of the uploaded content, set with UploadConfig.PreHash or UploadConfig.Checksum
 	Provider    string                     `json:"provider"`
 	Duration    time.Duration              `json:"duration"`
+	SpeedBps    float64                    `json:"speed_bps,omitempty"` // Average upload speed in bytes per second
 	Error       error                      `json:"error,omitempty"`
+	Cancelled   bool                       `json:"cancelled,omitempty"` // Upload was interrupted before it could finish
+	Skipped     bool                       `json:"skipped,omitempty"`   // Upload was never started, Error holds the reason
 	UploadTime  time.Time                  `json:"upload_time"`
 	ProgressInfo interface{}               `json:"-"`
 	// Enhanced response data
 	Response    *providers.ProviderResponse `json:"response"`
 }
 
+// MarshalJSON renders the error as its message and formats the duration,
+// upload time and expiry with the configured time format
+func (r UploadResult) MarshalJSON() ([]byte, error) {
+	type result UploadResult
+
+	errText := ""
+	if r.Error != nil {
+		errText = r.Error.Error()
+	}
+	uploadTime := ""
+	if !r.UploadTime.IsZero() {
+		uploadTime = timefmt.Timestamp(r.UploadTime)
+	}
+	expires := ""
+	if r.Expires != nil {
+		expires = timefmt.Timestamp(*r.Expires)
+	}
+
+	return json.Marshal(struct {
+		result
+		Duration   string `json:"duration"`
+		Error      string `json:"error,omitempty"`
+		UploadTime string `json:"upload_time,omitempty"`
+		Expires    string `json:"expires,omitempty"`
+	}{
+		result:     result(r),
+		Duration:   timefmt.Duration(r.Duration),
+		Error:      errText,
+		UploadTime: uploadTime,
+		Expires:    expires,
+	})
+}
+
+// Attempts returns how many attempts the upload took according to the
+// consistency wrapper, or 0 when the response does not record it
+func (r UploadResult) Attempts() int {
+	if r.Response == nil {
+		return 0
+	}
+	attempts, err := strconv.Atoi(r.Response.Metadata[providers.MetadataAttempts])
+	if err != nil {
+		return 0
+	}
+	return attempts
+}
+
 // ProgressInfo represents upload progress information
 type ProgressInfo struct {
 	FileName      string  `json:"filename"`
@@ -30,17 +87,30 @@ type ProgressInfo struct {
 	TotalBytes    int64   `json:"total_bytes"`
 	Percentage    float64 `json:"percentage"`
 	Speed         float64 `json:"speed"` // bytes per second
+	ETA           time.Duration `json:"eta"` // Estimated time left, 0 when unknown (see ETAKnown)
+	Phase         string  `json:"phase,omitempty"`       // e.g. "retrying (attempt 2)"
+	Retry         int     `json:"retry,omitempty"`       // Retry number of the current attempt, 0 on the first try
+	MaxRetries    int     `json:"max_retries,omitempty"` // Retry limit, set once a retry happened
+	Plan          *RunPlan `json:"plan,omitempty"`       // Set only on the event announcing the run's totals, see UploadConfig.PlanTotals
 }
 
-// Provider interface for different file hosting services with enhanced capabilities
-type Provider interface {
-	Name() string
-	Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error)
-	ValidateFile(ctx context.Context, filePath string, size int64) error
-	GetMaxFileSize() int64
-	GetSupportedExtensions() []string
+// RunPlan is what a run is about to upload, announced by a ProgressInfo whose
+// Plan is set before the first upload starts. Such an event carries no file
+// progress.
+type RunPlan struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"` // Sizes on disk; standard input counts as 0
 }
 
+// ETAKnown reports whether ETA holds an estimate; without a measured speed or
+// a known total size there is nothing to estimate from
+func (p ProgressInfo) ETAKnown() bool {
+	return p.Speed > 0 && p.TotalBytes > 0
+}
+
+// Provider is the canonical provider interface defined in internal/providers
+type Provider = providers.Provider
+
 // FileInfo represents information about a file to be uploaded
 type FileInfo struct {
 	Path     string
@@ -48,6 +118,7 @@ type FileInfo struct {
 	Size     int64
 	Modified time.Time
 	IsDir    bool
+	Digests  *providers.FileDigests // Set by the prehash stage, nil otherwise
 }
 
 // Scanner interface for scanning files and directories
@@ -55,6 +126,15 @@ type Scanner interface {
 	Scan(ctx context.Context, paths []string) (<-chan FileInfo, <-chan error)
 }
 
+// UploadStrategy selects how a file is distributed over the configured providers
+type UploadStrategy string
+
+const (
+	StrategyFirstSuccess UploadStrategy = "first_success" // Try providers in order until one succeeds (default)
+	StrategyMirror       UploadStrategy = "mirror"        // Upload to every provider, one result per provider
+	StrategyRace         UploadStrategy = "race"          // Upload to every provider at once, keep the first success and cancel the rest
+)
+
 // UploadConfig holds configuration for upload operations
 type UploadConfig struct {
 	Concurrency   int
@@ -63,6 +143,21 @@ type UploadConfig struct {
 	Verbose       bool
 	RetryAttempts int
 	RetryDelay    time.Duration
+	StripMetadata bool // Remove EXIF/XMP/text metadata from JPEG, PNG and TIFF files before upload
+	ProgressListeners []ProgressListener // Receive every progress event, independent of the progress channel
+	MaxTotalBytes int64 // Cap on bytes transferred in the run, 0 means unlimited
+	AbortOverBudget bool // Abort in-flight uploads that cross MaxTotalBytes instead of letting them finish
+	MaxNameLen    int  // Trim uploaded file names to this many bytes, 0 means no limit
+	Strategy      UploadStrategy // Empty means StrategyFirstSuccess
+	PreHash       bool // Compute SHA-256 and MD5 of every file before uploading and pass them to providers
+	IOBufferSize  int  // Read buffer for files in the upload and hashing paths, 0 means DefaultIOBufferSize
+	Stdin         io.Reader // Source read for the StdinPath ("-") path
+	StdinName     string    // Name the standard input upload gets, "stdin" when empty
+	Checksum      bool      // Compute a SHA-256 of the bytes as they are sent, reported as UploadResult.Checksum and checked against PreHash
+	ChunkSize     int64     // Split larger files into pieces of this size for providers that accept chunked uploads, 0 disables
+	Filter        *PathFilter // Include and exclude globs applied to files inside folders, nil uploads everything
+	IncludeHidden bool        // Upload files and directories inside folders whose name starts with ".", skipped otherwise
+	PlanTotals    bool        // Scan everything before the first upload and publish a RunPlan progress event
 }
 
 // Uploader interface for upload operations
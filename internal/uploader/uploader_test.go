@@ -0,0 +1,59 @@
+package uploader
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/timefmt"
+)
+
+func TestUploadResult_MarshalJSON(t *testing.T) {
+	defer timefmt.SetDefault(timefmt.Default())
+
+	uploadTime := time.Date(2024, 5, 17, 12, 30, 0, 0, time.UTC)
+	result := UploadResult{
+		FileName:   "a.txt",
+		Duration:   1234567 * time.Microsecond,
+		Error:      errors.New("quota exceeded"),
+		UploadTime: uploadTime,
+	}
+
+	tests := []struct {
+		formatter  timefmt.Formatter
+		uploadTime string
+	}{
+		{formatter: timefmt.Formatter{Format: timefmt.FormatRFC3339, UTC: true}, uploadTime: "2024-05-17T12:30:00Z"},
+		{formatter: timefmt.Formatter{Format: timefmt.FormatUnix, UTC: true}, uploadTime: "1715949000"},
+		{formatter: timefmt.Formatter{Format: timefmt.FormatLocal, UTC: true}, uploadTime: "2024-05-17 12:30:00 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.formatter.Format), func(t *testing.T) {
+			timefmt.SetDefault(tt.formatter)
+
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var decoded map[string]interface{}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("invalid JSON %s: %v", data, err)
+			}
+
+			if decoded["upload_time"] != tt.uploadTime {
+				t.Errorf("expected upload_time %q, got %v", tt.uploadTime, decoded["upload_time"])
+			}
+			if decoded["duration"] != "1.235s" {
+				t.Errorf("expected rounded duration, got %v", decoded["duration"])
+			}
+			if decoded["error"] != "quota exceeded" {
+				t.Errorf("expected error message, got %v", decoded["error"])
+			}
+			if decoded["filename"] != "a.txt" {
+				t.Errorf("expected filename to be kept, got %v", decoded["filename"])
+			}
+		})
+	}
+}
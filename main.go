@@ -1,7 +1,13 @@
 package main
 
-import "github.com/parnexcodes/woof/cmd"
+import (
+	"os"
+
+	"github.com/parnexcodes/woof/cmd"
+)
 
 func main() {
-	cmd.Execute()
-}
\ No newline at end of file
+	if err := cmd.Execute(); err != nil {
+		os.Exit(cmd.ExitCode(err))
+	}
+}
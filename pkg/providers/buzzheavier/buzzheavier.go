@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"path/filepath"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/parnexcodes/woof/internal/logging"
@@ -22,15 +24,33 @@ type BuzzHeavierResponse struct {
 	} `json:"data"`
 }
 
-// BuzzHeavierProvider implements the provider interface for BuzzHeavier
+// BuzzHeavierProvider implements the provider interface for BuzzHeavier. The
+// embedded BaseProvider holds the HTTP client, request signer, timeout, size
+// and extension limits and the concurrency cap.
 type BuzzHeavierProvider struct {
-	UploadURL            string
-	DownloadBaseURL      string
-	Timeout              time.Duration
-	HTTPClient           *http.Client
-	// Provider capabilities
-	MaxFileSize          int64
-	SupportedExtensions  map[string]bool
+	*providers.BaseProvider
+	UploadURL       string
+	DownloadBaseURL string
+	// APIURL and the AccountID are used for deleting uploaded files
+	APIURL    string
+	AccountID string
+}
+
+var (
+	_ providers.Provider           = (*BuzzHeavierProvider)(nil)
+	_ providers.TimeoutProvider    = (*BuzzHeavierProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*BuzzHeavierProvider)(nil)
+	_ providers.Deleter            = (*BuzzHeavierProvider)(nil)
+	_ providers.OptionsUploader    = (*BuzzHeavierProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("buzzheavier", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 1,
+	})
 }
 
 // New creates a new BuzzHeavier provider
@@ -45,6 +65,12 @@ func New(config map[string]interface{}) (*BuzzHeavierProvider, error) {
 		downloadBaseURL = "https://buzzheavier.com"
 	}
 
+	apiURL, ok := config["api_url"].(string)
+	if !ok {
+		apiURL = "https://buzzheavier.com/api"
+	}
+	accountID, _ := config["account_id"].(string)
+
 	timeoutStr, ok := config["timeout"].(string)
 	if !ok {
 		timeoutStr = "10m"
@@ -66,123 +92,143 @@ func New(config map[string]interface{}) (*BuzzHeavierProvider, error) {
 	}
 	logging.ProviderConfig("BuzzHeavier", providerConfig)
 
-// Provider configuration
+	// Provider configuration
 	maxSize := int64(10 * 1024 * 1024 * 1024) // 10GB default
 	if size, ok := config["max_file_size"].(int64); ok {
 		maxSize = size
 	}
 
-	// Support all file types by default
-	supportedExtensions := make(map[string]bool)
-	supportedExtensions["*"] = true
-
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("BuzzHeavier", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
 	return &BuzzHeavierProvider{
-		UploadURL:            uploadURL,
-		DownloadBaseURL:      downloadBaseURL,
-		Timeout:              timeout,
-		HTTPClient: &http.Client{
-			Timeout: timeout,
-		},
-		MaxFileSize:          maxSize,
-		SupportedExtensions:  supportedExtensions,
+		BaseProvider:    base,
+		UploadURL:       uploadURL,
+		DownloadBaseURL: downloadBaseURL,
+		APIURL:          apiURL,
+		AccountID:       accountID,
 	}, nil
 }
 
-// Name returns the provider name
-func (p *BuzzHeavierProvider) Name() string {
-	return "BuzzHeavier"
+// Delete removes a file given its ID or download URL. BuzzHeavier only
+// deletes files owned by the configured account.
+func (p *BuzzHeavierProvider) Delete(ctx context.Context, deleteToken string) error {
+	if p.AccountID == "" {
+		return providers.NewAuthenticationError("deleting BuzzHeavier files requires the account_id setting", nil)
+	}
+	fileID := fileIDFromToken(deleteToken)
+	if fileID == "" {
+		return providers.ErrMissingID()
+	}
+
+	deleteURL := fmt.Sprintf("%s/fs/%s", strings.TrimRight(p.APIURL, "/"), url.PathEscape(fileID))
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodDelete, deleteURL, nil, map[string]string{
+		"Authorization": "Bearer " + p.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The body is only read for the status error, so it is not parsed
+	responseBody, _ := p.ParseResponse(resp, time.Since(start), nil)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return providers.NewAuthenticationError("BuzzHeavier rejected the account ID", providers.ErrAPIStatus(resp.StatusCode, string(responseBody)))
+	default:
+		return providers.ErrAPIStatus(resp.StatusCode, string(responseBody))
+	}
 }
 
-// uploadWithResponse implements the upload method with standardized response
-func (p *BuzzHeavierProvider) uploadWithResponse(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+// fileIDFromToken accepts a bare file ID or a download URL and returns the ID
+func fileIDFromToken(token string) string {
+	token = strings.TrimSpace(token)
+	if parsed, err := url.Parse(token); err == nil && parsed.Host != "" {
+		id := path.Base(strings.TrimRight(parsed.Path, "/"))
+		if id == "/" || id == "." {
+			return ""
+		}
+		return id
+	}
+	return token
+}
+
+// UploadWithOptions uploads a file to BuzzHeavier and returns a structured response
+func (p *BuzzHeavierProvider) UploadWithOptions(ctx context.Context, file io.Reader, opts providers.UploadOptions) (*providers.ProviderResponse, error) {
+	size := opts.Size
+
 	// Validate the file first
-	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+	if err := p.ValidateFile(ctx, opts.FilePath, size); err != nil {
 		return nil, err
 	}
 
-	// Extract filename from path
-	filename := filepath.Base(filePath)
+	filename := opts.FileName()
 	uploadURL := fmt.Sprintf("%s/%s", p.UploadURL, filename)
 
 	// Read entire content to ensure we have the complete data and correct size
 	buf, err := io.ReadAll(file)
 	if err != nil {
-		p.logProviderError("file_read", err, map[string]interface{}{
-			"file":  filename,
-			"size":  size,
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
 		})
-		return nil, providers.NewNetworkError("failed to read file", err)
+		return nil, providers.ErrFileRead(err)
 	}
 	actualSize := int64(len(buf))
 
-	// Create HTTP request with context
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(buf))
-	if err != nil {
-		p.logProviderError("http_request_create", err, map[string]interface{}{
-			"method": http.MethodPut,
-			"url":    uploadURL,
-		})
-		return nil, providers.NewNetworkError("failed to create request", err)
+	// Send the file's type unless the caller chose one
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = providers.DetectContentType(filename, buf)
 	}
 
-	// Set content type and content length
-	req.Header.Set("Content-Type", "application/octet-stream")
-	req.Header.Set("Content-Length", fmt.Sprintf("%d", actualSize))
-
-	// Log HTTP request details
-	logging.HTTPRequest(http.MethodPut, uploadURL, map[string]string{
-		"Content-Type":   "application/octet-stream",
-		"Content-Length": fmt.Sprintf("%d", actualSize),
-	})
-
 	// Make request and measure duration
 	start := time.Now()
-	resp, err := p.HTTPClient.Do(req)
+	resp, err := p.MakeRequest(ctx, http.MethodPut, uploadURL, bytes.NewReader(buf), map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", actualSize),
+	})
 	duration := time.Since(start)
-
 	if err != nil {
-		p.logProviderError("http_request", err, map[string]interface{}{
-			"url": uploadURL,
-		})
-		return nil, providers.NewNetworkError("failed to upload file", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Read response body for debugging
-	responseBody, _ := io.ReadAll(resp.Body)
-
-	// Log HTTP response
-	logging.HTTPResponse(resp.StatusCode, string(responseBody), duration)
+	var response BuzzHeavierResponse
+	responseBody, err := p.ParseResponse(resp, duration, &response)
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, providers.NewAPIError(
-			fmt.Sprintf("%d", resp.StatusCode),
-			fmt.Sprintf("upload failed with status %d: %s", resp.StatusCode, string(responseBody)),
-			err,
-		)
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
 	}
-
-	// Parse JSON response (from already read body)
-	var response BuzzHeavierResponse
-	if err := json.Unmarshal(responseBody, &response); err != nil {
-		p.logProviderError("json_parse", err, map[string]interface{}{
-			"response": string(responseBody),
-		})
-		return nil, providers.NewAPIError("JSON_PARSE_ERROR", "failed to parse response", err)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check response code
 	if response.Code != http.StatusOK && response.Code != http.StatusCreated {
-		return nil, providers.NewAPIError(
-			fmt.Sprintf("%d", response.Code),
-			fmt.Sprintf("upload failed with code %d", response.Code),
-			nil,
-		)
+		if quotaErr := providers.QuotaError(p.Name(), response.Code, string(responseBody)); quotaErr != nil {
+			return nil, quotaErr
+		}
+		return nil, providers.ErrResponseCode(response.Code)
 	}
 
 	if response.Data.ID == "" {
-		return nil, providers.NewAPIError("MISSING_ID", "upload response missing file ID", nil)
+		return nil, providers.ErrMissingID()
+	}
+
+	// A different echoed size means the connection dropped part of the body
+	if err := providers.VerifyEchoedSize(echoedSize(responseBody), actualSize); err != nil {
+		p.LogProviderError("short_body", err, map[string]interface{}{
+			"file": filename,
+			"size": actualSize,
+		})
+		return nil, err
 	}
 
 	// Construct download URL
@@ -199,6 +245,8 @@ func (p *BuzzHeavierProvider) uploadWithResponse(ctx context.Context, filePath s
 			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
 			"original_name": filename,
 			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"content_type":  contentType,
+			"endpoint":      providers.ResponseEndpoint(resp),
 		},
 		ProviderData: &BuzzHeavierResponse{
 			Code: response.Code,
@@ -211,49 +259,20 @@ func (p *BuzzHeavierProvider) uploadWithResponse(ctx context.Context, filePath s
 	return result, nil
 }
 
-// ValidateFile validates a file before upload
-func (p *BuzzHeavierProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
-	// Check file size
-	if p.MaxFileSize > 0 && size > p.MaxFileSize {
-		logging.ErrorContext("file_too_large", fmt.Errorf("file too large"), map[string]interface{}{
-			"provider":     "BuzzHeavier",
-			"file_size":    size,
-			"max_size":     p.MaxFileSize,
-			"file_path":    filePath,
-		})
-		return providers.NewFileTooLargeError(
-			fmt.Sprintf("file size %d bytes exceeds maximum %d bytes", size, p.MaxFileSize),
-			nil,
-		)
-	}
-
-	return nil
-}
-
-// GetMaxFileSize returns the maximum file size supported by the provider
-func (p *BuzzHeavierProvider) GetMaxFileSize() int64 {
-	return p.MaxFileSize
+// Upload uploads a file to BuzzHeavier and returns a structured response
+func (p *BuzzHeavierProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	return p.UploadWithOptions(ctx, file, providers.UploadOptions{FilePath: filePath, Size: size})
 }
 
-// GetSupportedExtensions returns the list of supported file extensions
-func (p *BuzzHeavierProvider) GetSupportedExtensions() []string {
-	var extensions []string
-	for ext := range p.SupportedExtensions {
-		extensions = append(extensions, ext)
+// echoedSize extracts the stored size from an upload response, nil when the server does not report one
+func echoedSize(responseBody []byte) *int64 {
+	var echoed struct {
+		Data struct {
+			Size *int64 `json:"size"`
+		} `json:"data"`
 	}
-	return extensions
-}
-
-// logProviderError logs provider errors with context
-func (p *BuzzHeavierProvider) logProviderError(operation string, err error, fields map[string]interface{}) {
-	if fields == nil {
-		fields = make(map[string]interface{})
+	if err := json.Unmarshal(responseBody, &echoed); err != nil {
+		return nil
 	}
-	fields["provider"] = "BuzzHeavier"
-	logging.ErrorContext(operation, err, fields)
+	return echoed.Data.Size
 }
-
-// Upload uploads a file to BuzzHeavier and returns a structured response
-func (p *BuzzHeavierProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
-	return p.uploadWithResponse(ctx, filePath, file, size)
-}
\ No newline at end of file
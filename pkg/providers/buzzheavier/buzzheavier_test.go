@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/parnexcodes/woof/internal/logging"
 	"github.com/parnexcodes/woof/internal/providers"
@@ -151,6 +152,81 @@ func TestBuzzHeavierProvider_Upload_Success(t *testing.T) {
 	if response.Metadata["original_name"] != "test.txt" {
 		t.Errorf("Upload() Metadata original_name = %v, want %v", response.Metadata["original_name"], "test.txt")
 	}
+
+	if response.Metadata["endpoint"] != ts.URL {
+		t.Errorf("Upload() Metadata endpoint = %v, want %v", response.Metadata["endpoint"], ts.URL)
+	}
+}
+
+func TestBuzzHeavierProvider_UploadWithOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/report.pdf" {
+			t.Errorf("Path = %v, want %v", r.URL.Path, "/report.pdf")
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("Content-Type = %v, want %v", got, "application/pdf")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":201,"data":{"id":"pdf1"}}`))
+	}))
+	defer ts.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": ts.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	file := bytes.NewReader([]byte("%PDF"))
+	response, err := provider.UploadWithOptions(context.Background(), file, providers.UploadOptions{
+		FilePath:    "/docs/report.pdf",
+		Size:        int64(file.Len()),
+		ContentType: "application/pdf",
+	})
+	if err != nil {
+		t.Fatalf("UploadWithOptions() error = %v", err)
+	}
+	if response.ID != "pdf1" {
+		t.Errorf("UploadWithOptions() ID = %v, want %v", response.ID, "pdf1")
+	}
+}
+
+func TestBuzzHeavierProvider_Upload_DetectsContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		want     string
+	}{
+		{"png", "/tmp/photo.png", "png bytes", "image/png"},
+		{"json", "/tmp/data.json", `{"a":1}`, "application/json"},
+		{"extensionless binary", "/tmp/blob", "\x00\x01\x02\xfe\xff", "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Content-Type"); got != tt.want {
+					t.Errorf("Content-Type = %v, want %v", got, tt.want)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"code":201,"data":{"id":"ct1"}}`))
+			}))
+			defer ts.Close()
+
+			provider, err := New(map[string]interface{}{"upload_url": ts.URL})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			file := bytes.NewReader([]byte(tt.content))
+			response, err := provider.Upload(context.Background(), tt.filePath, file, int64(file.Len()))
+			if err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+			if got := response.Metadata["content_type"]; got != tt.want {
+				t.Errorf("Upload() Metadata content_type = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
 
 func TestBuzzHeavierProvider_Upload_HttpError(t *testing.T) {
@@ -214,6 +290,11 @@ func TestBuzzHeavierProvider_Upload_InvalidJSON(t *testing.T) {
 	if err.Error()[:len(expectedErr)] != expectedErr {
 		t.Errorf("Error = %v, want to contain %v", err.Error(), expectedErr)
 	}
+
+	var provErr *providers.ProviderError
+	if !errors.As(err, &provErr) || provErr.Code != providers.CodeJSONParse {
+		t.Errorf("Error code = %v, want %v", err, providers.CodeJSONParse)
+	}
 }
 
 func TestBuzzHeavierProvider_Upload_BadResponseCode(t *testing.T) {
@@ -326,4 +407,184 @@ func TestBuzzHeavierProvider_GetSupportedExtensions(t *testing.T) {
 	if len(extensions) != 1 || extensions[0] != "*" {
 		t.Errorf("GetSupportedExtensions() = %v, want [*]", extensions)
 	}
-}
\ No newline at end of file
+}
+
+func TestBuzzHeavierProvider_Delete(t *testing.T) {
+	var gotMethod, gotPath, gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotAuth = r.Method, r.URL.Path, r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	provider, err := New(map[string]interface{}{
+		"api_url":    ts.URL + "/api",
+		"account_id": "acct123",
+		"timeout":    "5s",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// A download URL works as well as the bare ID
+	if err := provider.Delete(context.Background(), "https://buzzheavier.com/abc123"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/api/fs/abc123" || gotAuth != "Bearer acct123" {
+		t.Errorf("unexpected request %s %s (Authorization %q)", gotMethod, gotPath, gotAuth)
+	}
+}
+
+func TestBuzzHeavierProvider_Delete_Errors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	withoutAccount, _ := New(map[string]interface{}{"api_url": ts.URL})
+	err := withoutAccount.Delete(context.Background(), "abc123")
+	if providers.GetErrorType(err) != providers.ErrorTypeAuthentication {
+		t.Errorf("expected an authentication error without account_id, got %v", err)
+	}
+
+	rejected, _ := New(map[string]interface{}{"api_url": ts.URL, "account_id": "wrong"})
+	err = rejected.Delete(context.Background(), "abc123")
+	if providers.GetErrorType(err) != providers.ErrorTypeAuthentication {
+		t.Errorf("expected an authentication error for a rejected account, got %v", err)
+	}
+}
+
+func TestBuzzHeavierProvider_UserAgent(t *testing.T) {
+	agents := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents <- r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":200,"data":{"id":"abc123"}}`))
+	}))
+	defer ts.Close()
+
+	for _, tt := range []struct {
+		name     string
+		settings map[string]interface{}
+		expected string
+	}{
+		{name: "default", settings: map[string]interface{}{}, expected: providers.DefaultUserAgent},
+		{name: "override", settings: map[string]interface{}{"user_agent": "mirror-bot/2.0"}, expected: "mirror-bot/2.0"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.settings["upload_url"] = ts.URL
+			provider, err := New(tt.settings)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if _, err := provider.Upload(context.Background(), "/path/to/test.txt", bytes.NewReader([]byte("test")), 4); err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+			if agent := <-agents; agent != tt.expected {
+				t.Errorf("User-Agent = %q, want %q", agent, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuzzHeavierProvider_Upload_EmptyBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": ts.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = provider.Upload(context.Background(), "/test.txt", bytes.NewReader([]byte("data")), 4)
+	var provErr *providers.ProviderError
+	if !errors.As(err, &provErr) || provErr.Code != providers.CodeJSONParse {
+		t.Errorf("Error = %v, want code %v", err, providers.CodeJSONParse)
+	}
+}
+
+func TestBuzzHeavierProvider_Upload_UnexpectedSuccessStatus(t *testing.T) {
+	// Only 200 and 201 count as a stored upload, even with a valid body
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"code":200,"data":{"id":"abc123"}}`))
+	}))
+	defer ts.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": ts.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = provider.Upload(context.Background(), "/test.txt", bytes.NewReader([]byte("data")), 4)
+	var provErr *providers.ProviderError
+	if !errors.As(err, &provErr) || provErr.Type != providers.ErrorTypeAPI || provErr.Code != "202" {
+		t.Errorf("Error = %v, want an API error with code 202", err)
+	}
+}
+
+func TestBuzzHeavierProvider_Upload_Signed(t *testing.T) {
+	var gotSignature, gotBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Signature")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Write([]byte(`{"code":201,"data":{"id":"abc123"}}`))
+	}))
+	defer ts.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url":     ts.URL,
+		"signing_key":    "secret",
+		"signing_header": "X-Signature",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := provider.Upload(context.Background(), "/test.txt", bytes.NewReader([]byte("data")), 4); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if gotBody != "data" {
+		t.Errorf("Body = %q, want %q", gotBody, "data")
+	}
+	if gotSignature == "" {
+		t.Error("expected the upload to carry a signature")
+	}
+}
+
+func TestBuzzHeavierProvider_Upload_LogsResponseDuration(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"code":201,"data":{"id":"abc123"}}`))
+	}))
+	defer ts.Close()
+
+	logs := &bytes.Buffer{}
+	logging.Init(true, logs)
+	defer logging.Init(false, os.Stderr)
+
+	provider, err := New(map[string]interface{}{"upload_url": ts.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := provider.Upload(context.Background(), "/test.txt", bytes.NewReader([]byte("data")), 4); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	var durationMS int64 = -1
+	for _, line := range bytes.Split(logs.Bytes(), []byte("\n")) {
+		var entry struct {
+			Msg        string `json:"msg"`
+			DurationMS int64  `json:"duration_ms"`
+		}
+		if json.Unmarshal(line, &entry) == nil && entry.Msg == "HTTP response" {
+			durationMS = entry.DurationMS
+		}
+	}
+	if durationMS < 50 {
+		t.Errorf("logged response duration = %dms, want at least the server's 50ms delay", durationMS)
+	}
+}
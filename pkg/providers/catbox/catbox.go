@@ -0,0 +1,223 @@
+package catbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// maxUploadSize is the upload limit enforced by catbox.moe (200 MB)
+const maxUploadSize = int64(200 * 1024 * 1024)
+
+// CatboxProvider implements the provider interface for catbox.moe. The
+// embedded BaseProvider holds the HTTP client, request signer, timeout, size
+// and extension limits and the concurrency cap.
+type CatboxProvider struct {
+	*providers.BaseProvider
+	UploadURL string
+	// UserHash ties uploads to a catbox account; empty uploads anonymously
+	UserHash string
+}
+
+var (
+	_ providers.Provider           = (*CatboxProvider)(nil)
+	_ providers.TimeoutProvider    = (*CatboxProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*CatboxProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("catbox", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 4,
+	})
+}
+
+// New creates a new catbox.moe provider
+func New(config map[string]interface{}) (*CatboxProvider, error) {
+	uploadURL, ok := config["upload_url"].(string)
+	if !ok {
+		uploadURL = "https://catbox.moe/user/api.php"
+	}
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "Catbox",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	userHash, _ := config["userhash"].(string)
+
+	providerConfig := map[string]interface{}{
+		"upload_url":    uploadURL,
+		"timeout":       timeout.String(),
+		"userhash_used": userHash != "",
+	}
+	logging.ProviderConfig("Catbox", providerConfig)
+
+	// catbox.moe rejects files over 200 MB
+	maxSize := maxUploadSize
+	if size, ok := config["max_file_size"].(int64); ok && size > 0 && size < maxUploadSize {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("Catbox", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &CatboxProvider{
+		BaseProvider: base,
+		UploadURL:    uploadURL,
+		UserHash:     userHash,
+	}, nil
+}
+
+// Upload uploads a file to catbox.moe and returns a structured response
+func (p *CatboxProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Base(filePath)
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+	actualSize := int64(len(buf))
+
+	// The declared size may be unknown; check what was actually read as well
+	if err := p.ValidateFile(ctx, filePath, actualSize); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := p.buildMultipartBody(filename, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", body.Len()),
+	})
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	// The answer is plain text, so it is not parsed as JSON
+	responseBody, err := p.ParseResponse(resp, duration, nil)
+	if resp.StatusCode != http.StatusOK {
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// catbox.moe answers with the file URL as plain text
+	fileURL, err := providers.ParsePlainTextURL(responseBody)
+	if err != nil {
+		p.LogProviderError("url_parse", err, map[string]interface{}{
+			"response": string(responseBody),
+		})
+		// Limits are reported as a plain-text message in place of the URL
+		if quotaErr := providers.QuotaError(p.Name(), 0, string(responseBody)); quotaErr != nil {
+			return nil, quotaErr
+		}
+		return nil, err
+	}
+
+	result := &providers.ProviderResponse{
+		URL:         fileURL,
+		DownloadURL: fileURL,
+		ID:          fileID(fileURL),
+		Metadata: map[string]string{
+			"provider":      "Catbox",
+			"upload_method": "multipart_form",
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"userhash_used": strconv.FormatBool(p.UserHash != ""),
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+	}
+
+	logging.UploadComplete(filename, fileURL, duration)
+
+	return result, nil
+}
+
+// buildMultipartBody writes the upload form: reqtype, the optional userhash and
+// the file part, in the order the catbox API documents them
+func (p *CatboxProvider) buildMultipartBody(filename string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("reqtype", "fileupload"); err != nil {
+		return nil, "", providers.NewNetworkError("failed to write reqtype field", err)
+	}
+
+	if p.UserHash != "" {
+		if err := writer.WriteField("userhash", p.UserHash); err != nil {
+			return nil, "", providers.NewNetworkError("failed to write userhash field", err)
+		}
+	}
+
+	part, err := writer.CreateFormFile("fileToUpload", filename)
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to write form file", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return nil, "", providers.NewNetworkError("failed to close form writer", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
+
+// fileID returns the name catbox assigned to the file, e.g. "abc123.png"
+func fileID(fileURL string) string {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(parsed.Path)
+}
@@ -0,0 +1,109 @@
+package catbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+// catboxServer checks the upload form and answers with a plain-text URL
+func catboxServer(t *testing.T, wantUserHash []string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+
+		assert.Equal(t, []string{"fileupload"}, r.MultipartForm.Value["reqtype"])
+		assert.Equal(t, wantUserHash, r.MultipartForm.Value["userhash"])
+
+		file, header, err := r.FormFile("fileToUpload")
+		require.NoError(t, err)
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "cat.png", header.Filename)
+		assert.Equal(t, "meow", string(content))
+
+		w.Write([]byte("https://files.catbox.moe/abc123.png"))
+	}))
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://catbox.moe/user/api.php", provider.UploadURL)
+	assert.Equal(t, int64(200*1024*1024), provider.GetMaxFileSize())
+	assert.Empty(t, provider.UserHash)
+	assert.Equal(t, "Catbox", provider.Name())
+}
+
+func TestUpload_Anonymous(t *testing.T) {
+	server := catboxServer(t, nil)
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "/tmp/cat.png", bytes.NewReader([]byte("meow")), 4)
+	require.NoError(t, err)
+	assert.Equal(t, "https://files.catbox.moe/abc123.png", resp.URL)
+	assert.Equal(t, "https://files.catbox.moe/abc123.png", resp.DownloadURL)
+	assert.Equal(t, "abc123.png", resp.ID)
+	assert.Equal(t, "false", resp.Metadata["userhash_used"])
+}
+
+func TestUpload_WithUserHash(t *testing.T) {
+	server := catboxServer(t, []string{"hash123"})
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL,
+		"userhash":   "hash123",
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "cat.png", bytes.NewReader([]byte("meow")), 4)
+	require.NoError(t, err)
+	assert.Equal(t, "true", resp.Metadata["userhash_used"])
+}
+
+func TestUpload_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte("No files were uploaded."))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "cat.png", bytes.NewReader([]byte("meow")), 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "412")
+}
+
+func TestValidateFile_TooLarge(t *testing.T) {
+	provider, err := New(map[string]interface{}{})
+	require.NoError(t, err)
+
+	err = provider.ValidateFile(context.Background(), "big.bin", provider.GetMaxFileSize()+1)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.ErrorTypeFileTooLarge, providerErr.Type)
+	assert.NoError(t, provider.ValidateFile(context.Background(), "ok.bin", provider.GetMaxFileSize()))
+}
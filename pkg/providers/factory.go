@@ -1,26 +1,47 @@
 package providers
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/parnexcodes/woof/internal/config"
 	"github.com/parnexcodes/woof/internal/logging"
-	"github.com/parnexcodes/woof/internal/uploader"
 	providerpkg "github.com/parnexcodes/woof/internal/providers"
-	"github.com/parnexcodes/woof/pkg/providers/buzzheavier"
-	"github.com/parnexcodes/woof/pkg/providers/gofile"
+
+	// Each provider package registers itself with the factory in init
+	_ "github.com/parnexcodes/woof/pkg/providers/buzzheavier"
+	_ "github.com/parnexcodes/woof/pkg/providers/catbox"
+	_ "github.com/parnexcodes/woof/pkg/providers/fileio"
+	_ "github.com/parnexcodes/woof/pkg/providers/gofile"
+	_ "github.com/parnexcodes/woof/pkg/providers/litterbox"
+	_ "github.com/parnexcodes/woof/pkg/providers/null0x0"
+	_ "github.com/parnexcodes/woof/pkg/providers/tmpfiles"
+	_ "github.com/parnexcodes/woof/pkg/providers/uguu"
+	_ "github.com/parnexcodes/woof/pkg/providers/webdav"
 )
 
 // Factory creates provider instances based on configuration
 type Factory struct {
-	wrapperConfig providerpkg.WrapperConfig
+	wrapperConfig  providerpkg.WrapperConfig
+	dryRun         bool
+	timeout        time.Duration
+	connectTimeout time.Duration
 }
 
 // FactoryConfig holds configuration for the factory
 type FactoryConfig struct {
 	EnableConsistencyWrapper bool                       `json:"enable_consistency_wrapper"`
 	WrapperConfig            providerpkg.WrapperConfig    `json:"wrapper_config"`
+	// DryRun initializes providers without network-dependent setup
+	DryRun                   bool                       `json:"dry_run"`
+	// Timeout replaces every provider's timeout setting when positive
+	Timeout                  time.Duration              `json:"timeout"`
+	// ConnectTimeout replaces every provider's connect_timeout setting when positive
+	ConnectTimeout           time.Duration              `json:"connect_timeout"`
 }
 
 // DefaultFactoryConfig returns sensible defaults for factory configuration
@@ -42,42 +63,34 @@ func NewFactory() *Factory {
 func NewFactoryWithConfig(config FactoryConfig) *Factory {
 	return &Factory{
 		wrapperConfig: config.WrapperConfig,
+		dryRun:         config.DryRun,
+		timeout:        config.Timeout,
+		connectTimeout: config.ConnectTimeout,
 	}
 }
 
 // CreateProvider creates a provider instance from configuration
-func (f *Factory) CreateProvider(providerConfig config.ProviderConfig) (uploader.Provider, error) {
+func (f *Factory) CreateProvider(providerConfig config.ProviderConfig) (providerpkg.Provider, error) {
 	return f.CreateProviderWithWrapper(providerConfig, DefaultFactoryConfig().EnableConsistencyWrapper)
 }
 
 // CreateProviderWithWrapper creates a provider with optional consistency wrapper
-func (f *Factory) CreateProviderWithWrapper(providerConfig config.ProviderConfig, enableWrapper bool) (uploader.Provider, error) {
+func (f *Factory) CreateProviderWithWrapper(providerConfig config.ProviderConfig, enableWrapper bool) (providerpkg.Provider, error) {
 	logging.ProviderConfig(providerConfig.Name, providerConfig.Settings)
 
-	// Create the base provider
-	var provider uploader.Provider
-	var err error
+	// Read "<name>_file" settings (e.g. mounted secrets) into "<name>"
+	settings, err := config.ResolveSettingFiles(providerConfig.Settings)
+	if err != nil {
+		logging.ErrorContext("provider_creation", err, map[string]interface{}{
+			"provider": providerConfig.Name,
+		})
+		return nil, fmt.Errorf("failed to create provider '%s': %w", providerConfig.Name, err)
+	}
+	providerConfig.Settings = f.applyTimeouts(settings)
 
-	switch strings.ToLower(providerConfig.Name) {
-	case "buzzheavier":
-		provider, err = buzzheavier.New(providerConfig.Settings)
-		if err != nil {
-			logging.ErrorContext("provider_creation", err, map[string]interface{}{
-				"provider": providerConfig.Name,
-				"settings": providerConfig.Settings,
-			})
-			return nil, fmt.Errorf("failed to create provider '%s': %w", providerConfig.Name, err)
-		}
-	case "gofile":
-		provider, err = gofile.New(providerConfig.Settings)
-		if err != nil {
-			logging.ErrorContext("provider_creation", err, map[string]interface{}{
-				"provider": providerConfig.Name,
-				"settings": providerConfig.Settings,
-			})
-			return nil, fmt.Errorf("failed to create provider '%s': %w", providerConfig.Name, err)
-		}
-	default:
+	// Create the base provider from its registration
+	registration, ok := providerpkg.LookupProvider(providerConfig.Name)
+	if !ok {
 		err := fmt.Errorf("unknown provider: %s", providerConfig.Name)
 		logging.ErrorContext("provider_creation", err, map[string]interface{}{
 			"provider": providerConfig.Name,
@@ -85,28 +98,57 @@ func (f *Factory) CreateProviderWithWrapper(providerConfig config.ProviderConfig
 		return nil, err
 	}
 
-	// Apply consistency wrapper if enabled
-	if enableWrapper {
-		logging.ProviderConfig(provider.Name(), map[string]interface{}{
-			"wrapper_enabled":         true,
-			"validation_enabled":      f.wrapperConfig.PreUploadValidation,
-			"auto_retry_enabled":      f.wrapperConfig.AutoRetry,
-			"max_retries":             f.wrapperConfig.MaxRetries,
+	provider, err := registration.New(providerConfig.Settings)
+	if err != nil {
+		logging.ErrorContext("provider_creation", err, map[string]interface{}{
+			"provider": providerConfig.Name,
+			"settings": providerConfig.Settings,
 		})
-		provider = providerpkg.NewConsistencyWrapper(provider, f.wrapperConfig)
+		return nil, fmt.Errorf("failed to create provider '%s': %w", providerConfig.Name, err)
+	}
+
+	return f.wrap(provider, enableWrapper), nil
+}
+
+// wrap applies the consistency wrapper to provider when enableWrapper is set
+func (f *Factory) wrap(provider providerpkg.Provider, enableWrapper bool) providerpkg.Provider {
+	if !enableWrapper {
+		return provider
 	}
+	logging.ProviderConfig(provider.Name(), map[string]interface{}{
+		"wrapper_enabled":         true,
+		"validation_enabled":      f.wrapperConfig.PreUploadValidation,
+		"auto_retry_enabled":      f.wrapperConfig.AutoRetry,
+		"max_retries":             f.wrapperConfig.MaxRetries,
+	})
+	return providerpkg.NewConsistencyWrapper(provider, f.wrapperConfig)
+}
 
-	return provider, nil
+// applyTimeouts sets the factory's timeout overrides on settings, which must
+// be a copy the caller owns
+func (f *Factory) applyTimeouts(settings map[string]interface{}) map[string]interface{} {
+	if f.timeout > 0 {
+		settings["timeout"] = f.timeout.String()
+	}
+	if f.connectTimeout > 0 {
+		settings["connect_timeout"] = f.connectTimeout.String()
+	}
+	return settings
 }
 
 // CreateProviders creates multiple provider instances from configuration
-func (f *Factory) CreateProviders(providerConfigs []config.ProviderConfig) ([]uploader.Provider, error) {
+func (f *Factory) CreateProviders(providerConfigs []config.ProviderConfig) ([]providerpkg.Provider, error) {
 	return f.CreateProvidersWithWrapper(providerConfigs, DefaultFactoryConfig().EnableConsistencyWrapper)
 }
 
 // CreateProvidersWithWrapper creates multiple providers with optional consistency wrapper
-func (f *Factory) CreateProvidersWithWrapper(providerConfigs []config.ProviderConfig, enableWrapper bool) ([]uploader.Provider, error) {
-	var providers []uploader.Provider
+func (f *Factory) CreateProvidersWithWrapper(providerConfigs []config.ProviderConfig, enableWrapper bool) ([]providerpkg.Provider, error) {
+	var providers []providerpkg.Provider
+
+	providerConfigs, err := sortByPriority(providerConfigs)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, providerConfig := range providerConfigs {
 		if !providerConfig.Enabled {
@@ -125,88 +167,172 @@ func (f *Factory) CreateProvidersWithWrapper(providerConfigs []config.ProviderCo
 	return providers, nil
 }
 
-// CreateProvidersFromNames creates providers for a specific list of provider names
-func (f *Factory) CreateProvidersFromNames(providerNames []string, allConfigs []config.ProviderConfig) ([]uploader.Provider, error) {
-	nameSet := make(map[string]bool)
+// sortByPriority returns a copy of providerConfigs ordered by their priority
+// setting, lowest first, so first-success uploads try the preferred provider
+// first. Providers without a priority follow those with one; ties keep the
+// configuration order.
+func sortByPriority(providerConfigs []config.ProviderConfig) ([]config.ProviderConfig, error) {
+	type ranked struct {
+		config   config.ProviderConfig
+		priority int
+	}
+	ranking := make([]ranked, 0, len(providerConfigs))
+	for _, providerConfig := range providerConfigs {
+		priority, set, err := providerpkg.PriorityFromSettings(providerConfig.Settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider '%s': %w", providerConfig.Name, err)
+		}
+		if !set {
+			priority = math.MaxInt
+		}
+		ranking = append(ranking, ranked{config: providerConfig, priority: priority})
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].priority < ranking[j].priority
+	})
+
+	sorted := make([]config.ProviderConfig, 0, len(ranking))
+	for _, entry := range ranking {
+		sorted = append(sorted, entry.config)
+	}
+	return sorted, nil
+}
+
+// CreateProvidersFromNames creates providers for a specific list of provider
+// names. Naming a provider enables it, so opt-in providers that are disabled
+// in the configuration can still be picked for a run. Names and registered
+// aliases such as null0x0 for 0x0 select the same provider.
+func (f *Factory) CreateProvidersFromNames(providerNames []string, allConfigs []config.ProviderConfig) ([]providerpkg.Provider, error) {
+	nameSet := make(map[string]string) // Canonical name to the name requested
 	for _, name := range providerNames {
-		nameSet[strings.ToLower(name)] = true
+		nameSet[canonicalName(name)] = name
 	}
 
 	var selectedConfigs []config.ProviderConfig
 	for _, config := range allConfigs {
-		if nameSet[strings.ToLower(config.Name)] {
+		key := canonicalName(config.Name)
+		if _, ok := nameSet[key]; ok {
+			config.Enabled = true
 			selectedConfigs = append(selectedConfigs, config)
-			delete(nameSet, strings.ToLower(config.Name))
+			delete(nameSet, key)
 		}
 	}
 
 	// Check if any requested providers were not found
 	if len(nameSet) > 0 {
-		var missing []string
-		for name := range nameSet {
+		var missing, unconfigured []string
+		for key, name := range nameSet {
+			if registration, ok := providerpkg.LookupProvider(key); ok && registration.RequiresSetup() {
+				unconfigured = append(unconfigured, name)
+				continue
+			}
 			missing = append(missing, name)
 		}
-		return nil, fmt.Errorf("unknown providers: %v", missing)
+		sort.Strings(missing)
+		sort.Strings(unconfigured)
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("unknown providers: %v", missing)
+		}
+		return nil, fmt.Errorf("providers require configuration: %v (add them to the providers list in the config file with their settings)", unconfigured)
 	}
 
 	return f.CreateProviders(selectedConfigs)
 }
 
-// CreateAllProviders creates all available providers with consistency wrapper enabled
-func (f *Factory) CreateAllProviders() ([]uploader.Provider, error) {
-	return f.CreateAllProvidersWithWrapper(DefaultFactoryConfig().EnableConsistencyWrapper)
+// canonicalName returns the registered name for a provider name or alias,
+// or the lowercased name when nothing is registered under it
+func canonicalName(name string) string {
+	if canonical, ok := providerpkg.CanonicalProviderName(name); ok {
+		return canonical
+	}
+	return strings.ToLower(name)
 }
 
-// CreateAllProvidersWithWrapper creates all available providers with optional consistency wrapper
-func (f *Factory) CreateAllProvidersWithWrapper(enableWrapper bool) ([]uploader.Provider, error) {
-	// Define all available providers with default settings
-	var providers []uploader.Provider
-
-	// BuzzHeavier provider with default settings
-	logging.ProviderConfig("buzzheavier", map[string]interface{}{"mode": "all_providers_defaults"})
-	buzzProvider, err := buzzheavier.New(map[string]interface{}{})
-	if err != nil {
-		logging.ErrorContext("create_all_providers", err, map[string]interface{}{
-			"provider": "buzzheavier",
-		})
-		return nil, fmt.Errorf("failed to create buzzheavier provider: %w", err)
+// InitializeProviders runs one-time setup for the created providers before a
+// batch starts, so failures surface before any upload begins. A dry-run
+// factory marks the context so providers stay offline.
+func (f *Factory) InitializeProviders(ctx context.Context, providers []providerpkg.Provider) error {
+	if f.dryRun {
+		ctx = providerpkg.WithDryRun(ctx)
 	}
+	return providerpkg.InitializeAll(ctx, providers)
+}
 
-	// Apply consistency wrapper if enabled
-	if enableWrapper {
-		logging.ProviderConfig(buzzProvider.Name(), map[string]interface{}{
-			"wrapper_enabled":         true,
-			"validation_enabled":      f.wrapperConfig.PreUploadValidation,
-			"auto_retry_enabled":      f.wrapperConfig.AutoRetry,
-			"max_retries":             f.wrapperConfig.MaxRetries,
-		})
-		providers = append(providers, providerpkg.NewConsistencyWrapper(buzzProvider, f.wrapperConfig))
-	} else {
-		providers = append(providers, buzzProvider)
+// ProviderDescriptor describes a registered provider with its default settings
+type ProviderDescriptor struct {
+	Name         string   `json:"name"`          // Name used in the config and --providers
+	DisplayName  string   `json:"display_name"`
+	MaxFileSize  int64    `json:"max_file_size"` // 0 means unlimited
+	Extensions   []string `json:"extensions"`
+	RequiresAuth bool     `json:"requires_auth"` // Cannot upload until credentials or a server are configured
+}
+
+// ListProviders describes every registered provider as created with its
+// default settings, without making any network calls
+func (f *Factory) ListProviders() ([]ProviderDescriptor, error) {
+	names := providerpkg.RegisteredProviders()
+	providers := make([]providerpkg.Provider, 0, len(names))
+	for _, name := range names {
+		registration, _ := providerpkg.LookupProvider(name)
+		settings := registration.SetupSettings
+		if settings == nil {
+			settings = map[string]interface{}{}
+		}
+		provider, err := f.CreateProviderWithWrapper(config.ProviderConfig{Name: name, Settings: settings}, false)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
 	}
+	return DescribeProviders(providers), nil
+}
 
-	// GoFile provider with default settings
-	logging.ProviderConfig("gofile", map[string]interface{}{"mode": "all_providers_defaults"})
-	gofileProvider, err := gofile.New(map[string]interface{}{})
-	if err != nil {
-		logging.ErrorContext("create_all_providers", err, map[string]interface{}{
-			"provider": "gofile",
+// DescribeProviders describes created providers as they are configured, so
+// overrides such as allowed_extensions and max_file_size show in the limits
+func DescribeProviders(providers []providerpkg.Provider) []ProviderDescriptor {
+	descriptors := make([]ProviderDescriptor, 0, len(providers))
+	for _, provider := range providers {
+		name := canonicalName(provider.Name())
+		registration, _ := providerpkg.LookupProvider(name)
+
+		extensions := provider.GetSupportedExtensions()
+		sort.Strings(extensions)
+		descriptors = append(descriptors, ProviderDescriptor{
+			Name:         name,
+			DisplayName:  provider.Name(),
+			MaxFileSize:  provider.GetMaxFileSize(),
+			Extensions:   extensions,
+			RequiresAuth: registration.RequiresSetup(),
 		})
-		return nil, fmt.Errorf("failed to create gofile provider: %w", err)
 	}
+	return descriptors
+}
 
-	// Apply consistency wrapper if enabled
-	if enableWrapper {
-		logging.ProviderConfig(gofileProvider.Name(), map[string]interface{}{
-			"wrapper_enabled":         true,
-			"validation_enabled":      f.wrapperConfig.PreUploadValidation,
-			"auto_retry_enabled":      f.wrapperConfig.AutoRetry,
-			"max_retries":             f.wrapperConfig.MaxRetries,
-		})
-		providers = append(providers, providerpkg.NewConsistencyWrapper(gofileProvider, f.wrapperConfig))
-	} else {
-		providers = append(providers, gofileProvider)
+// CreateAllProviders creates all available providers with consistency wrapper enabled
+func (f *Factory) CreateAllProviders() ([]providerpkg.Provider, error) {
+	return f.CreateAllProvidersWithWrapper(DefaultFactoryConfig().EnableConsistencyWrapper)
+}
+
+// CreateAllProvidersWithWrapper creates every registered provider that works
+// without setup, with default settings and an optional consistency wrapper
+func (f *Factory) CreateAllProvidersWithWrapper(enableWrapper bool) ([]providerpkg.Provider, error) {
+	var providers []providerpkg.Provider
+	for _, name := range providerpkg.RegisteredProviders() {
+		registration, _ := providerpkg.LookupProvider(name)
+		if registration.RequiresSetup() {
+			continue
+		}
+
+		logging.ProviderConfig(name, map[string]interface{}{"mode": "all_providers_defaults"})
+		provider, err := registration.New(f.applyTimeouts(map[string]interface{}{}))
+		if err != nil {
+			logging.ErrorContext("create_all_providers", err, map[string]interface{}{
+				"provider": name,
+			})
+			return nil, fmt.Errorf("failed to create %s provider: %w", name, err)
+		}
+		providers = append(providers, f.wrap(provider, enableWrapper))
 	}
 
 	return providers, nil
-}
\ No newline at end of file
+}
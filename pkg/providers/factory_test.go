@@ -0,0 +1,207 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/parnexcodes/woof/internal/config"
+	"github.com/parnexcodes/woof/internal/logging"
+	providerpkg "github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestCreateProviders_OrdersByPriority(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	configs := []config.ProviderConfig{
+		{Name: "catbox", Enabled: true, Settings: map[string]interface{}{"priority": 2}},
+		{Name: "uguu", Enabled: true, Settings: map[string]interface{}{}},
+		{Name: "gofile", Enabled: true, Settings: map[string]interface{}{"priority": "1"}},
+		{Name: "tmpfiles", Enabled: true, Settings: map[string]interface{}{"priority": 2}},
+		{Name: "0x0", Enabled: true},
+	}
+
+	providers, err := NewFactory().CreateProvidersWithWrapper(configs, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, provider := range providers {
+		names = append(names, provider.Name())
+	}
+
+	// Lowest priority first, ties and unprioritized providers in config order
+	expected := []string{"GoFile", "Catbox", "Tmpfiles", "Uguu", "0x0"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+	if configs[0].Name != "catbox" {
+		t.Error("expected the configuration order to be left untouched")
+	}
+}
+
+func TestCreateProviders_RejectsInvalidPriority(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	_, err := NewFactory().CreateProviders([]config.ProviderConfig{
+		{Name: "catbox", Enabled: true, Settings: map[string]interface{}{"priority": "first"}},
+	})
+	if err == nil {
+		t.Error("expected an error for a non-numeric priority")
+	}
+}
+
+// fakeProvider is registered as "fake" for the factory tests
+type fakeProvider struct {
+	settings map[string]interface{}
+}
+
+func (p *fakeProvider) Name() string { return "Fake" }
+
+func (p *fakeProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providerpkg.ProviderResponse, error) {
+	return &providerpkg.ProviderResponse{URL: "https://example.com/fake"}, nil
+}
+
+func (p *fakeProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
+	return nil
+}
+
+func (p *fakeProvider) GetMaxFileSize() int64 { return 0 }
+
+func (p *fakeProvider) GetSupportedExtensions() []string { return []string{"*"} }
+
+var registerFake sync.Once
+
+func registerFakeProvider() {
+	registerFake.Do(func() {
+		providerpkg.Register("fake", func(settings map[string]interface{}) (providerpkg.Provider, error) {
+			return &fakeProvider{settings: settings}, nil
+		})
+	})
+}
+
+func TestCreateProvider_FromRegistry(t *testing.T) {
+	logging.Init(false, io.Discard)
+	registerFakeProvider()
+
+	provider, err := NewFactory().CreateProviderWithWrapper(config.ProviderConfig{
+		Name:     "Fake",
+		Settings: map[string]interface{}{"token": "secret"},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fake, ok := provider.(*fakeProvider)
+	if !ok {
+		t.Fatalf("expected the registered constructor's provider, got %T", provider)
+	}
+	if fake.settings["token"] != "secret" {
+		t.Errorf("expected the configured settings, got %v", fake.settings)
+	}
+
+	if _, err := NewFactory().CreateProvider(config.ProviderConfig{Name: "unregistered"}); err == nil {
+		t.Error("expected an error for an unregistered provider")
+	}
+}
+
+func TestCreateAllProviders_UsesRegistry(t *testing.T) {
+	logging.Init(false, io.Discard)
+	registerFakeProvider()
+
+	providers, err := NewFactory().CreateAllProvidersWithWrapper(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, provider := range providers {
+		names[provider.Name()] = true
+	}
+	if !names["Fake"] || !names["GoFile"] || !names["0x0"] {
+		t.Errorf("expected every registered provider, got %v", names)
+	}
+	if names["WebDAV"] {
+		t.Error("expected providers that need setup to be skipped")
+	}
+}
+
+func TestCreateAllProviders_DurableHostsFirst(t *testing.T) {
+	logging.Init(false, io.Discard)
+	registerFakeProvider()
+
+	providers, err := NewFactory().CreateAllProvidersWithWrapper(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, provider := range providers {
+		names = append(names, provider.Name())
+	}
+
+	// Registered priorities, then providers without one such as the fake
+	expected := []string{"BuzzHeavier", "GoFile", "0x0", "Catbox", "FileIO", "Uguu", "Litterbox", "Tmpfiles", "Fake"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestCreateProvidersFromNames_EnablesNamedProviders(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	configs := []config.ProviderConfig{
+		{Name: "gofile", Enabled: true},
+		{Name: "0x0", Enabled: false},
+		{Name: "catbox", Enabled: false},
+	}
+	providers, err := NewFactory().CreateProvidersFromNames([]string{"0x0", "catbox"}, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, provider := range providers {
+		names = append(names, provider.Name())
+	}
+	if expected := []string{"0x0", "Catbox"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the opt-in providers that were named, got %v", names)
+	}
+	if configs[1].Enabled {
+		t.Error("expected the configuration to be left untouched")
+	}
+}
+
+func TestCreateProvidersFromNames_ResolvesAliases(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	configs := []config.ProviderConfig{
+		{Name: "0x0", Enabled: true},
+		{Name: "File.io", Enabled: false},
+	}
+	providers, err := NewFactory().CreateProvidersFromNames([]string{"null0x0", "fileio"}, configs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, provider := range providers {
+		names = append(names, provider.Name())
+	}
+	if expected := []string{"0x0", "FileIO"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected aliases to select their providers, got %v", names)
+	}
+}
+
+func TestCreateProvidersFromNames_ReportsUnconfiguredProviders(t *testing.T) {
+	logging.Init(false, io.Discard)
+
+	configs := []config.ProviderConfig{{Name: "0x0", Enabled: true}}
+	_, err := NewFactory().CreateProvidersFromNames([]string{"webdav"}, configs)
+	if err == nil || !strings.Contains(err.Error(), "require configuration") {
+		t.Errorf("expected webdav to be reported as needing configuration, got %v", err)
+	}
+
+	_, err = NewFactory().CreateProvidersFromNames([]string{"nosuch"}, configs)
+	if err == nil || !strings.Contains(err.Error(), "unknown providers") {
+		t.Errorf("expected an unknown provider error, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package fileio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// FileIOResponse represents the API response format
+type FileIOResponse struct {
+	Success bool   `json:"success"`
+	Status  int    `json:"status"`
+	Key     string `json:"key"`
+	Link    string `json:"link"`
+	Name    string `json:"name"`
+	Expires string `json:"expires"`
+	Message string `json:"message"`
+}
+
+// FileIOProvider implements the provider interface for file.io. Links are
+// one-time: the file is deleted after its first download or when it expires.
+// The embedded BaseProvider holds the HTTP client, request signer, timeout,
+// size and extension limits and the concurrency cap.
+type FileIOProvider struct {
+	*providers.BaseProvider
+	UploadURL string
+	// Expires is the lifetime sent with the upload, e.g. "14d" or "1w"; empty keeps the server default
+	Expires string
+}
+
+var (
+	_ providers.Provider           = (*FileIOProvider)(nil)
+	_ providers.TimeoutProvider    = (*FileIOProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*FileIOProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("fileio", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 5,
+		Aliases:  []string{"file.io"},
+	})
+}
+
+// New creates a new file.io provider
+func New(config map[string]interface{}) (*FileIOProvider, error) {
+	uploadURL, ok := config["upload_url"].(string)
+	if !ok {
+		uploadURL = "https://file.io"
+	}
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "FileIO",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	expires, _ := config["expires"].(string)
+
+	providerConfig := map[string]interface{}{
+		"upload_url": uploadURL,
+		"timeout":    timeout.String(),
+		"expires":    expires,
+	}
+	logging.ProviderConfig("FileIO", providerConfig)
+
+	// 0 leaves size enforcement to the server
+	maxSize := int64(0)
+	if size, ok := config["max_file_size"].(int64); ok {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("FileIO", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &FileIOProvider{
+		BaseProvider: base,
+		UploadURL:    uploadURL,
+		Expires:      expires,
+	}, nil
+}
+
+// Upload uploads a file to file.io and returns a structured response
+func (p *FileIOProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Base(filePath)
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+	actualSize := int64(len(buf))
+
+	body, contentType, err := p.buildMultipartBody(filename, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", body.Len()),
+	})
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	var response FileIOResponse
+	responseBody, err := p.ParseResponse(resp, duration, &response)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		message := response.Message
+		if message == "" {
+			message = "success=false"
+		}
+		return nil, providers.NewAPIError(providers.CodeUploadError, fmt.Sprintf("upload failed: %s", message), nil)
+	}
+
+	if response.Link == "" {
+		return nil, providers.ErrMissingDownloadURL()
+	}
+
+	result := &providers.ProviderResponse{
+		URL:         response.Link,
+		DownloadURL: response.Link,
+		ID:          response.Key,
+		Metadata: map[string]string{
+			"provider":      "FileIO",
+			"upload_method": "multipart_form",
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"one_time_link": "true",
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+		ProviderData: &response,
+	}
+
+	if response.Expires != "" {
+		expires, err := time.Parse(time.RFC3339, response.Expires)
+		if err != nil {
+			// The upload succeeded; an unreadable expiry only loses the timestamp
+			p.LogProviderError("expires_parse", err, map[string]interface{}{
+				"expires": response.Expires,
+			})
+		} else {
+			result.Expires = &expires
+		}
+	}
+
+	logging.UploadComplete(filename, response.Link, duration)
+
+	return result, nil
+}
+
+// buildMultipartBody writes the upload form: the file part followed by the optional expires field
+func (p *FileIOProvider) buildMultipartBody(filename string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to write form file", err)
+	}
+
+	if p.Expires != "" {
+		if err := writer.WriteField("expires", p.Expires); err != nil {
+			return nil, "", providers.NewNetworkError("failed to write expires field", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return nil, "", providers.NewNetworkError("failed to close form writer", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
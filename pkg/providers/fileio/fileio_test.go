@@ -0,0 +1,95 @@
+package fileio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New(map[string]interface{}{"expires": "14d"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://file.io", provider.UploadURL)
+	assert.Equal(t, "14d", provider.Expires)
+	assert.Equal(t, "FileIO", provider.Name())
+}
+
+func TestUpload_SetsExpires(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, []string{"14d"}, r.MultipartForm.Value["expires"])
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "notes.txt", header.Filename)
+		assert.Equal(t, "one time", string(content))
+
+		w.Write([]byte(`{"success":true,"status":200,"key":"AbC123","link":"https://file.io/AbC123","expires":"2026-10-29T12:30:00.000Z"}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL, "expires": "14d"})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "/tmp/notes.txt", bytes.NewReader([]byte("one time")), 8)
+	require.NoError(t, err)
+	assert.Equal(t, "https://file.io/AbC123", resp.URL)
+	assert.Equal(t, "https://file.io/AbC123", resp.DownloadURL)
+	assert.Equal(t, "AbC123", resp.ID)
+	require.NotNil(t, resp.Expires)
+	assert.True(t, resp.Expires.Equal(time.Date(2026, 10, 29, 12, 30, 0, 0, time.UTC)))
+}
+
+func TestUpload_WithoutExpires(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Empty(t, r.MultipartForm.Value["expires"])
+		w.Write([]byte(`{"success":true,"key":"k","link":"https://file.io/k"}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "notes.txt", bytes.NewReader([]byte("x")), 1)
+	require.NoError(t, err)
+	assert.Nil(t, resp.Expires)
+}
+
+func TestUpload_Unsuccessful(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"status":400,"message":"Invalid expiry"}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "notes.txt", bytes.NewReader([]byte("x")), 1)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.ErrorTypeAPI, providerErr.Type)
+	assert.Equal(t, providers.CodeUploadError, providerErr.Code)
+	assert.Contains(t, providerErr.Message, "Invalid expiry")
+}
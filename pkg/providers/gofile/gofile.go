@@ -1,6 +1,7 @@
 package gofile
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -8,8 +9,13 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/parnexcodes/woof/internal/logging"
 	"github.com/parnexcodes/woof/internal/providers"
@@ -25,15 +31,68 @@ type GoFileResponse struct {
 	} `json:"data"`
 }
 
-// GoFileProvider implements the provider interface for GoFile
+// GoFileProvider implements the provider interface for GoFile. The embedded
+// BaseProvider holds the HTTP client, request signer, timeout, extension
+// limits and concurrency cap; GoFile has no file size limit.
 type GoFileProvider struct {
-	UploadURL            string
-	Timeout              time.Duration
-	HTTPClient           *http.Client
-	OptionalFolderID     string
-	// Provider capabilities - GoFile has no file size limits
-	MaxFileSize          int64
-	SupportedExtensions  map[string]bool
+	*providers.BaseProvider
+	UploadURL        string
+	OptionalFolderID string
+	// Optional fixed multipart boundary for servers that require one
+	Boundary string
+	// Deterministic makes request bodies byte-identical for identical input
+	// (fixed boundary, stable field order). Intended for tests only.
+	Deterministic bool
+	// SelectServer picks an upload server from ServersURL during Initialize
+	// instead of using UploadURL as configured
+	SelectServer bool
+	ServersURL   string
+	// Optional preferred server zone (e.g. "eu" or "na") for server selection
+	Zone string
+	// APIURL and the account Token are used for deleting uploaded content
+	// and for the health check
+	APIURL string
+	Token  string
+
+	initOnce sync.Once
+	initErr  error
+}
+
+// GoFileServersResponse represents the server list returned by the servers endpoint
+type GoFileServersResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Servers []GoFileServer `json:"servers"`
+	} `json:"data"`
+}
+
+// GoFileServer is one upload server of the server list
+type GoFileServer struct {
+	Name string `json:"name"`
+	Zone string `json:"zone"`
+}
+
+// serverUploadURLFormat builds the upload URL for a selected server name
+const serverUploadURLFormat = "https://%s.gofile.io/contents/uploadfile"
+
+var (
+	_ providers.Provider           = (*GoFileProvider)(nil)
+	_ providers.TimeoutProvider    = (*GoFileProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*GoFileProvider)(nil)
+	_ providers.Initializer        = (*GoFileProvider)(nil)
+	_ providers.Deleter            = (*GoFileProvider)(nil)
+	_ providers.StreamingProvider  = (*GoFileProvider)(nil)
+	_ providers.OptionsUploader    = (*GoFileProvider)(nil)
+	_ providers.HealthChecker      = (*GoFileProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("gofile", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 2,
+	})
 }
 
 // New creates a new GoFile provider
@@ -59,170 +118,316 @@ func New(config map[string]interface{}) (*GoFileProvider, error) {
 
 	optionalFolderID, _ := config["folder_id"].(string)
 
-	providerConfig := map[string]interface{}{
-		"upload_url": uploadURL,
-		"timeout":    timeout.String(),
-		"folder_id":  optionalFolderID,
+	boundary, _ := config["boundary"].(string)
+	if boundary != "" {
+		if err := multipart.NewWriter(io.Discard).SetBoundary(boundary); err != nil {
+			return nil, fmt.Errorf("invalid multipart boundary %q: %w", boundary, err)
+		}
 	}
-	logging.ProviderConfig("GoFile", providerConfig)
 
-	// GoFile has no file size limits - set to 0 (unlimited)
-	maxSize := int64(0)
+	selectServer, _ := config["select_server"].(bool)
+	serversURL, ok := config["servers_url"].(string)
+	if !ok {
+		serversURL = "https://api.gofile.io/servers"
+	}
+	zone, _ := config["zone"].(string)
+	apiURL, ok := config["api_url"].(string)
+	if !ok {
+		apiURL = "https://api.gofile.io"
+	}
+	token, _ := config["token"].(string)
 
-	// Support all file types by default
-	supportedExtensions := make(map[string]bool)
-	supportedExtensions["*"] = true
+	providerConfig := map[string]interface{}{
+		"upload_url":    uploadURL,
+		"timeout":       timeout.String(),
+		"folder_id":     optionalFolderID,
+		"select_server": selectServer,
+		"zone":          zone,
+	}
+	logging.ProviderConfig("GoFile", providerConfig)
 
+	// GoFile has no file size limits, so the maximum is 0 (unlimited); all
+	// file types are supported unless allowed_extensions restricts them
+	base, err := providers.NewBaseProviderFromSettings("GoFile", config, timeout, 0)
+	if err != nil {
+		return nil, err
+	}
 	return &GoFileProvider{
-		UploadURL:            uploadURL,
-		Timeout:              timeout,
-		HTTPClient: &http.Client{
-			Timeout: timeout,
-		},
-		OptionalFolderID:     optionalFolderID,
-		MaxFileSize:          maxSize,
-		SupportedExtensions:  supportedExtensions,
+		BaseProvider:     base,
+		UploadURL:        uploadURL,
+		OptionalFolderID: optionalFolderID,
+		Boundary:         boundary,
+		SelectServer:     selectServer,
+		ServersURL:       serversURL,
+		Zone:             zone,
+		APIURL:           apiURL,
+		Token:            token,
 	}, nil
 }
 
-// Name returns the provider name
-func (p *GoFileProvider) Name() string {
-	return "GoFile"
+// Initialize picks an upload server when server selection is enabled. The
+// lookup runs once; later calls return the first result. A dry run keeps
+// the configured UploadURL and makes no request.
+func (p *GoFileProvider) Initialize(ctx context.Context) error {
+	if !p.SelectServer || providers.IsDryRun(ctx) {
+		return nil
+	}
+	p.initOnce.Do(func() {
+		p.initErr = p.selectServer(ctx)
+	})
+	return p.initErr
 }
 
-// uploadWithResponse implements the upload method with standardized response
-func (p *GoFileProvider) uploadWithResponse(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
-	// Validate the file first
-	if err := p.ValidateFile(ctx, filePath, size); err != nil {
-		return nil, err
+// selectServer queries the server list and points UploadURL at the chosen server,
+// preferring one in Zone when it is set
+func (p *GoFileProvider) selectServer(ctx context.Context) error {
+	servers, err := p.fetchServers(ctx, "server_selection")
+	if err != nil {
+		return err
+	}
+
+	chosen := servers[0].Name
+	if p.Zone != "" {
+		for _, server := range servers {
+			if strings.EqualFold(server.Zone, p.Zone) {
+				chosen = server.Name
+				break
+			}
+		}
 	}
 
-	// Extract filename from path
-	filename := filepath.Base(filePath)
+	p.UploadURL = fmt.Sprintf(serverUploadURLFormat, chosen)
+	logging.ProviderConfig("GoFile", map[string]interface{}{
+		"selected_server": chosen,
+		"upload_url":      p.UploadURL,
+	})
+	return nil
+}
 
-	// Read entire content to ensure we have the complete data
-	buf, err := io.ReadAll(file)
+// fetchServers queries ServersURL for the available upload servers
+func (p *GoFileProvider) fetchServers(ctx context.Context, operation string) ([]GoFileServer, error) {
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodGet, p.ServersURL, nil, nil)
 	if err != nil {
-		p.logProviderError("file_read", err, map[string]interface{}{
-			"file": filename,
-			"size": size,
+		p.LogProviderError(operation, err, map[string]interface{}{
+			"url": p.ServersURL,
 		})
-		return nil, providers.NewNetworkError("failed to read file", err)
+		return nil, err
+	}
+
+	var response GoFileServersResponse
+	responseBody, err := p.ParseResponse(resp, time.Since(start), &response)
+	if resp.StatusCode != http.StatusOK {
+		return nil, providers.ErrAPIStatus(resp.StatusCode, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if response.Status != "ok" {
+		return nil, providers.ErrUploadRejected(response.Status)
 	}
-	actualSize := int64(len(buf))
 
-	// Create multipart form
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+	servers := response.Data.Servers
+	if len(servers) == 0 {
+		return nil, providers.NewTemporaryError("no GoFile upload servers available", nil)
+	}
+	return servers, nil
+}
 
-	// Add file field
-	part, err := writer.CreateFormFile("file", filename)
+// HealthCheck confirms the server list is reachable and, when a token is
+// configured, that GoFile accepts it
+func (p *GoFileProvider) HealthCheck(ctx context.Context) error {
+	if _, err := p.fetchServers(ctx, "health_check"); err != nil {
+		return err
+	}
+	if p.Token == "" {
+		return nil
+	}
+
+	accountURL := strings.TrimRight(p.APIURL, "/") + "/accounts/getid"
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodGet, accountURL, nil, map[string]string{
+		"Authorization": "Bearer " + p.Token,
+	})
 	if err != nil {
-		p.logProviderError("form_file_create", err, map[string]interface{}{
-			"filename": filename,
-		})
-		return nil, providers.NewNetworkError("failed to create form file", err)
+		return err
 	}
 
-	_, err = part.Write(buf)
+	var response struct {
+		Status string `json:"status"`
+	}
+	responseBody, err := p.ParseResponse(resp, time.Since(start), &response)
+	if statusErr := tokenStatusError(resp, responseBody); statusErr != nil {
+		return statusErr
+	}
 	if err != nil {
-		p.logProviderError("form_file_write", err, map[string]interface{}{
-			"filename": filename,
-		})
-		return nil, providers.NewNetworkError("failed to write form file", err)
+		return err
+	}
+	if response.Status != "ok" {
+		return providers.NewAuthenticationError("GoFile rejected the token", providers.ErrUploadRejected(response.Status))
 	}
+	return nil
+}
 
-	// Add optional folder ID field
-	if p.OptionalFolderID != "" {
-		err = writer.WriteField("folderId", p.OptionalFolderID)
-		if err != nil {
-			p.logProviderError("form_folder_write", err, map[string]interface{}{
-				"folder_id": p.OptionalFolderID,
-			})
-			return nil, providers.NewNetworkError("failed to write folder ID", err)
-		}
+// Delete removes uploaded content by its ID, the ID of the upload response.
+// GoFile only deletes content owned by the account of the configured token.
+func (p *GoFileProvider) Delete(ctx context.Context, deleteToken string) error {
+	if p.Token == "" {
+		return providers.NewAuthenticationError("deleting GoFile content requires the account token setting", nil)
+	}
+	if deleteToken == "" {
+		return providers.ErrMissingID()
 	}
 
-	// Close the writer to finalize the form
-	err = writer.Close()
+	body, err := json.Marshal(map[string]string{"contentsId": deleteToken})
+	if err != nil {
+		return providers.ErrRequestCreate(err)
+	}
+	deleteURL := strings.TrimRight(p.APIURL, "/") + "/contents"
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodDelete, deleteURL, bytes.NewReader(body), map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + p.Token,
+	})
 	if err != nil {
-		p.logProviderError("form_close", err, nil)
-		return nil, providers.NewNetworkError("failed to close form writer", err)
+		return err
 	}
 
-	// Create HTTP request with context
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.UploadURL, &body)
+	var response struct {
+		Status string `json:"status"`
+	}
+	responseBody, err := p.ParseResponse(resp, time.Since(start), &response)
+	if statusErr := tokenStatusError(resp, responseBody); statusErr != nil {
+		return statusErr
+	}
 	if err != nil {
-		p.logProviderError("http_request_create", err, map[string]interface{}{
-			"method": http.MethodPost,
-			"url":    p.UploadURL,
-		})
-		return nil, providers.NewNetworkError("failed to create request", err)
+		return err
+	}
+	if response.Status != "ok" {
+		return providers.ErrUploadRejected(response.Status)
 	}
+	return nil
+}
 
-	// Set content type and content length
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Content-Length", fmt.Sprintf("%d", body.Len()))
+// tokenStatusError maps the HTTP status of a token-authenticated API call to
+// an error: an authentication error when GoFile rejects the token, an API
+// error for any other status but 200 OK, and nil otherwise
+func tokenStatusError(resp *http.Response, responseBody []byte) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return providers.NewAuthenticationError("GoFile rejected the token", providers.ErrAPIStatus(resp.StatusCode, string(responseBody)))
+	default:
+		return providers.ErrAPIStatus(resp.StatusCode, string(responseBody))
+	}
+}
 
-	// Log HTTP request details
-	logging.HTTPRequest(http.MethodPost, p.UploadURL, map[string]string{
-		"Content-Type":   writer.FormDataContentType(),
-		"Content-Length": fmt.Sprintf("%d", body.Len()),
-		"folder_id":      p.OptionalFolderID,
-	})
+// UploadWithOptions uploads a file to GoFile and returns a structured response
+func (p *GoFileProvider) UploadWithOptions(ctx context.Context, file io.Reader, opts providers.UploadOptions) (*providers.ProviderResponse, error) {
+	size := opts.Size
 
+	// Validate the file first
+	if err := p.ValidateFile(ctx, opts.FilePath, size); err != nil {
+		return nil, err
+	}
+
+	// Make the filename safe for the Content-Disposition header
+	filename := sanitizeFormFilename(opts.FileName())
+
+	// Label the file part with its type unless the caller chose one
+	partContentType := opts.ContentType
+
+	var (
+		body    io.Reader
+		headers map[string]string
+		sent    func() int64 // File bytes in the form, known once the request is sent
+	)
+	if size < 0 {
+		// A body of unknown length, such as standard input, goes into the
+		// form as it is read instead of being held in memory
+		source := bufio.NewReaderSize(file, sniffLen)
+		if partContentType == "" {
+			head, _ := source.Peek(sniffLen)
+			partContentType = providers.DetectContentType(filename, head)
+		}
+		counter := &countingReader{reader: source}
+		stream, contentType, err := p.streamMultipartBody(filename, partContentType, counter)
+		if err != nil {
+			return nil, err
+		}
+		// Stops the form writer if the request ends early
+		defer stream.Close()
+		body, headers, sent = stream, map[string]string{"Content-Type": contentType}, counter.Count
+	} else {
+		// Read entire content to ensure we have the complete data
+		buf, err := io.ReadAll(file)
+		if err != nil {
+			p.LogProviderError("file_read", err, map[string]interface{}{
+				"file": filename,
+				"size": size,
+			})
+			return nil, providers.ErrFileRead(err)
+		}
+		if partContentType == "" {
+			partContentType = providers.DetectContentType(filename, buf)
+		}
+
+		// Create multipart form
+		form, contentType, err := p.buildMultipartBody(filename, partContentType, buf)
+		if err != nil {
+			return nil, err
+		}
+		body = form
+		headers = map[string]string{
+			"Content-Type":   contentType,
+			"Content-Length": fmt.Sprintf("%d", form.Len()),
+		}
+		sent = func() int64 { return int64(len(buf)) }
+	}
 	// Make request and measure duration
 	start := time.Now()
-	resp, err := p.HTTPClient.Do(req)
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, headers)
 	duration := time.Since(start)
-
 	if err != nil {
-		p.logProviderError("http_request", err, map[string]interface{}{
-			"url": p.UploadURL,
-		})
-		return nil, providers.NewNetworkError("failed to upload file", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	// Read response body for debugging
-	responseBody, _ := io.ReadAll(resp.Body)
+	actualSize := sent()
 
-	// Log HTTP response
-	logging.HTTPResponse(resp.StatusCode, string(responseBody), duration)
+	var response GoFileResponse
+	responseBody, err := p.ParseResponse(resp, duration, &response)
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, providers.NewAPIError(
-			fmt.Sprintf("%d", resp.StatusCode),
-			fmt.Sprintf("upload failed with status %d: %s", resp.StatusCode, string(responseBody)),
-			err,
-		)
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
 	}
-
-	// Parse JSON response (from already read body)
-	var response GoFileResponse
-	if err := json.Unmarshal(responseBody, &response); err != nil {
-		p.logProviderError("json_parse", err, map[string]interface{}{
-			"response": string(responseBody),
-		})
-		return nil, providers.NewAPIError("JSON_PARSE_ERROR", "failed to parse response", err)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check response status
+	// Check the status GoFile reports in the body
 	if response.Status != "ok" {
-		return nil, providers.NewAPIError(
-			"UPLOAD_ERROR",
-			fmt.Sprintf("upload failed with status: %s", response.Status),
-			nil,
-		)
+		if quotaErr := providers.QuotaError(p.Name(), 0, string(responseBody)); quotaErr != nil {
+			return nil, quotaErr
+		}
+		return nil, providers.ErrUploadRejected(response.Status)
 	}
 
 	if response.Data.DownloadPage == "" {
-		return nil, providers.NewAPIError("MISSING_DOWNLOAD_URL", "upload response missing download URL", nil)
+		return nil, providers.ErrMissingDownloadURL()
 	}
 
 	if response.Data.ID == "" {
-		return nil, providers.NewAPIError("MISSING_ID", "upload response missing file ID", nil)
+		return nil, providers.ErrMissingID()
+	}
+
+	// A different echoed size means the connection dropped part of the body
+	if err := providers.VerifyEchoedSize(echoedSize(responseBody), actualSize); err != nil {
+		p.LogProviderError("short_body", err, map[string]interface{}{
+			"file": filename,
+			"size": actualSize,
+		})
+		return nil, err
 	}
 
 	// Create structured response
@@ -238,6 +443,8 @@ func (p *GoFileProvider) uploadWithResponse(ctx context.Context, filePath string
 			"upload_size":   fmt.Sprintf("%d", actualSize),
 			"gofile_id":     response.Data.ID,
 			"gofile_name":   response.Data.FileName,
+			"content_type":  partContentType,
+			"endpoint":      providers.ResponseEndpoint(resp),
 		},
 		ProviderData: &GoFileResponse{
 			Status: response.Status,
@@ -254,36 +461,176 @@ func (p *GoFileProvider) uploadWithResponse(ctx context.Context, filePath string
 	return result, nil
 }
 
-// ValidateFile validates a file before upload
-func (p *GoFileProvider) ValidateFile(ctx context.Context, filePath string, size int64) error {
-	// GoFile has no file size limits, so no size validation needed
-	return nil
+// deterministicBoundary is used when Deterministic is set and no Boundary is configured
+const deterministicBoundary = "woof-deterministic-multipart-boundary"
+
+// sniffLen is how much of a streamed body is read ahead to detect its content type
+const sniffLen = 512
+
+// buildMultipartBody writes the upload form into memory
+func (p *GoFileProvider) buildMultipartBody(filename, partContentType string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer, err := p.newMultipartWriter(body)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := p.writeMultipartForm(writer, filename, partContentType, bytes.NewReader(content)); err != nil {
+		return nil, "", err
+	}
+	return body, writer.FormDataContentType(), nil
 }
 
-// GetMaxFileSize returns the maximum file size supported by the provider
-func (p *GoFileProvider) GetMaxFileSize() int64 {
-	return p.MaxFileSize // 0 means unlimited
+// streamMultipartBody writes the upload form into a pipe while the request
+// reads it, so only a buffer's worth of content is in memory at a time.
+// Closing the returned body stops the writer.
+func (p *GoFileProvider) streamMultipartBody(filename, partContentType string, content io.Reader) (io.ReadCloser, string, error) {
+	reader, pipe := io.Pipe()
+	writer, err := p.newMultipartWriter(pipe)
+	if err != nil {
+		return nil, "", err
+	}
+	go func() {
+		pipe.CloseWithError(p.writeMultipartForm(writer, filename, partContentType, content))
+	}()
+	return reader, writer.FormDataContentType(), nil
 }
 
-// GetSupportedExtensions returns the list of supported file extensions
-func (p *GoFileProvider) GetSupportedExtensions() []string {
-	var extensions []string
-	for ext := range p.SupportedExtensions {
-		extensions = append(extensions, ext)
+// newMultipartWriter returns a form writer using the configured boundary
+func (p *GoFileProvider) newMultipartWriter(w io.Writer) (*multipart.Writer, error) {
+	writer := multipart.NewWriter(w)
+
+	boundary := p.Boundary
+	if boundary == "" && p.Deterministic {
+		boundary = deterministicBoundary
+	}
+	if boundary != "" {
+		if err := writer.SetBoundary(boundary); err != nil {
+			return nil, providers.NewUnsupportedError("invalid multipart boundary", err)
+		}
 	}
-	return extensions
+	return writer, nil
 }
 
-// logProviderError logs provider errors with context
-func (p *GoFileProvider) logProviderError(operation string, err error, fields map[string]interface{}) {
-	if fields == nil {
-		fields = make(map[string]interface{})
+// writeMultipartForm writes the upload form and closes writer. Fields are
+// always written in the same order: the file part first, followed by the
+// optional folder ID. An empty partContentType keeps the multipart writer's
+// application/octet-stream.
+func (p *GoFileProvider) writeMultipartForm(writer *multipart.Writer, filename, partContentType string, content io.Reader) error {
+	// Add file field
+	var part io.Writer
+	var err error
+	if partContentType == "" {
+		part, err = writer.CreateFormFile("file", filename)
+	} else {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(filename)))
+		header.Set("Content-Type", partContentType)
+		part, err = writer.CreatePart(header)
+	}
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := io.Copy(part, content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return providers.NewNetworkError("failed to write form file", err)
+	}
+
+	// Add optional folder ID field
+	if p.OptionalFolderID != "" {
+		if err := writer.WriteField("folderId", p.OptionalFolderID); err != nil {
+			p.LogProviderError("form_folder_write", err, map[string]interface{}{
+				"folder_id": p.OptionalFolderID,
+			})
+			return providers.NewNetworkError("failed to write folder ID", err)
+		}
 	}
-	fields["provider"] = "GoFile"
-	logging.ErrorContext(operation, err, fields)
+
+	// Close the writer to finalize the form
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return providers.NewNetworkError("failed to close form writer", err)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	n      atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// Count returns the bytes read so far
+func (r *countingReader) Count() int64 {
+	return r.n.Load()
+}
+
+// maxFormFilenameBytes bounds the filename placed in the multipart header
+const maxFormFilenameBytes = 255
+
+// quoteEscaper escapes a form filename the way the multipart writer does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// sanitizeFormFilename replaces control characters (including CR and LF, which
+// would break the part header) and trims overly long names while keeping the
+// extension. Quotes and backslashes are escaped by the multipart writer.
+func sanitizeFormFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if len(name) <= maxFormFilenameBytes {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	if len(ext) >= maxFormFilenameBytes {
+		ext = ""
+	}
+	base := name[:maxFormFilenameBytes-len(ext)]
+	// Avoid cutting a multi-byte character in half
+	for !utf8.ValidString(base) {
+		base = base[:len(base)-1]
+	}
+	return base + ext
+}
+
+// AcceptsUnknownLength reports that GoFile takes bodies of unknown length;
+// the multipart form is streamed as the body is read and the upload is sized
+// from the bytes actually read. A streamed form cannot be hashed before it is
+// sent, so signed uploads need their length known up front.
+func (p *GoFileProvider) AcceptsUnknownLength() bool {
+	return !p.SignsRequests()
 }
 
 // Upload uploads a file to GoFile and returns a structured response
 func (p *GoFileProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
-	return p.uploadWithResponse(ctx, filePath, file, size)
-}
\ No newline at end of file
+	return p.UploadWithOptions(ctx, file, providers.UploadOptions{FilePath: filePath, Size: size})
+}
+
+// echoedSize extracts the stored size from an upload response, nil when the server does not report one
+func echoedSize(responseBody []byte) *int64 {
+	var echoed struct {
+		Data struct {
+			Size *int64 `json:"size"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(responseBody, &echoed); err != nil {
+		return nil
+	}
+	return echoed.Data.Size
+}
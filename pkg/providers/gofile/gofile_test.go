@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,17 +33,16 @@ func TestNew(t *testing.T) {
 		name     string
 		config   map[string]interface{}
 		expected *GoFileProvider
+		timeout  time.Duration
 	}{
 		{
 			name:   "default config",
 			config: map[string]interface{}{},
 			expected: &GoFileProvider{
-				UploadURL:           "https://upload.gofile.io/uploadFile",
-				Timeout:             10 * time.Minute,
-				OptionalFolderID:    "",
-				MaxFileSize:         0,
-				SupportedExtensions: map[string]bool{"*": true},
+				UploadURL:        "https://upload.gofile.io/uploadFile",
+				OptionalFolderID: "",
 			},
+			timeout: 10 * time.Minute,
 		},
 		{
 			name: "custom config",
@@ -52,12 +52,10 @@ func TestNew(t *testing.T) {
 				"folder_id":  "folder123",
 			},
 			expected: &GoFileProvider{
-				UploadURL:           "https://custom.upload.example.com",
-				Timeout:             5 * time.Minute,
-				OptionalFolderID:    "folder123",
-				MaxFileSize:         0,
-				SupportedExtensions: map[string]bool{"*": true},
+				UploadURL:        "https://custom.upload.example.com",
+				OptionalFolderID: "folder123",
 			},
+			timeout: 5 * time.Minute,
 		},
 		{
 			name: "invalid timeout uses default",
@@ -65,12 +63,10 @@ func TestNew(t *testing.T) {
 				"timeout": "invalid",
 			},
 			expected: &GoFileProvider{
-				UploadURL:           "https://upload.gofile.io/uploadFile",
-				Timeout:             10 * time.Minute,
-				OptionalFolderID:    "",
-				MaxFileSize:         0,
-				SupportedExtensions: map[string]bool{"*": true},
+				UploadURL:        "https://upload.gofile.io/uploadFile",
+				OptionalFolderID: "",
 			},
+			timeout: 10 * time.Minute,
 		},
 	}
 
@@ -80,10 +76,10 @@ func TestNew(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.expected.UploadURL, provider.UploadURL)
-			assert.Equal(t, tt.expected.Timeout, provider.Timeout)
+			assert.Equal(t, tt.timeout, provider.GetTimeout())
 			assert.Equal(t, tt.expected.OptionalFolderID, provider.OptionalFolderID)
-			assert.Equal(t, tt.expected.MaxFileSize, provider.MaxFileSize)
-			assert.Equal(t, tt.expected.SupportedExtensions, provider.SupportedExtensions)
+			assert.Equal(t, int64(0), provider.GetMaxFileSize())
+			assert.Equal(t, []string{"*"}, provider.GetSupportedExtensions())
 		})
 	}
 }
@@ -183,6 +179,7 @@ func TestUpload_Success(t *testing.T) {
 	assert.Equal(t, "test.txt", response.Metadata["original_name"])
 	assert.Equal(t, "abc123", response.Metadata["gofile_id"])
 	assert.Equal(t, "testfolder", response.Metadata["folder_id"])
+	assert.Equal(t, server.URL, response.Metadata["endpoint"])
 
 	// Verify provider data
 	providerData, ok := response.ProviderData.(*GoFileResponse)
@@ -262,6 +259,8 @@ func TestUpload_HTTPError(t *testing.T) {
 	var apiErr *providers.ProviderError
 	assert.True(t, errors.As(err, &apiErr))
 	assert.Equal(t, providers.ErrorTypeAPI, apiErr.Type)
+	assert.Equal(t, "500", apiErr.Code)
+	assert.Equal(t, providers.ErrUploadStatus(500, "Internal Server Error").Message, apiErr.Message)
 }
 
 func TestUpload_InvalidJSON(t *testing.T) {
@@ -285,7 +284,48 @@ func TestUpload_InvalidJSON(t *testing.T) {
 	var apiErr *providers.ProviderError
 	assert.True(t, errors.As(err, &apiErr))
 	assert.Equal(t, providers.ErrorTypeAPI, apiErr.Type)
-	assert.Equal(t, "JSON_PARSE_ERROR", apiErr.Code)
+	assert.Equal(t, providers.CodeJSONParse, apiErr.Code)
+}
+
+func TestUpload_EmptyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+	})
+	require.NoError(t, err)
+
+	file := bytes.NewBufferString("test content")
+	response, err := provider.Upload(context.Background(), "test.txt", file, int64(file.Len()))
+	assert.Nil(t, response)
+
+	var apiErr *providers.ProviderError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, providers.CodeJSONParse, apiErr.Code)
+}
+
+func TestUpload_SignsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("X-Signature"), "HMAC-SHA256 KeyId=k,"))
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		w.Write([]byte(`{"status":"ok","data":{"downloadPage":"https://gofile.io/d/abc","id":"abc"}}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url":     server.URL + "/uploadFile",
+		"signing_key":    "secret",
+		"signing_key_id": "k",
+	})
+	require.NoError(t, err)
+
+	assert.False(t, provider.AcceptsUnknownLength())
+
+	file := bytes.NewBufferString("test content")
+	response, err := provider.Upload(context.Background(), "test.txt", file, int64(file.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, "https://gofile.io/d/abc", response.URL)
 }
 
 func TestUpload_APIErrorStatus(t *testing.T) {
@@ -313,7 +353,7 @@ func TestUpload_APIErrorStatus(t *testing.T) {
 	var apiErr *providers.ProviderError
 	assert.True(t, errors.As(err, &apiErr))
 	assert.Equal(t, providers.ErrorTypeAPI, apiErr.Type)
-	assert.Equal(t, "UPLOAD_ERROR", apiErr.Code)
+	assert.Equal(t, providers.CodeUploadError, apiErr.Code)
 }
 
 func TestUpload_MissingDownloadURL(t *testing.T) {
@@ -345,7 +385,7 @@ func TestUpload_MissingDownloadURL(t *testing.T) {
 	var apiErr *providers.ProviderError
 	assert.True(t, errors.As(err, &apiErr))
 	assert.Equal(t, providers.ErrorTypeAPI, apiErr.Type)
-	assert.Equal(t, "MISSING_DOWNLOAD_URL", apiErr.Code)
+	assert.Equal(t, providers.CodeMissingDownloadURL, apiErr.Code)
 }
 
 func TestUpload_MissingID(t *testing.T) {
@@ -377,7 +417,7 @@ func TestUpload_MissingID(t *testing.T) {
 	var apiErr *providers.ProviderError
 	assert.True(t, errors.As(err, &apiErr))
 	assert.Equal(t, providers.ErrorTypeAPI, apiErr.Type)
-	assert.Equal(t, "MISSING_ID", apiErr.Code)
+	assert.Equal(t, providers.CodeMissingID, apiErr.Code)
 }
 
 func TestUpload_FileReadError(t *testing.T) {
@@ -486,3 +526,446 @@ func TestUpload_ZeroSizeFile(t *testing.T) {
 	assert.Equal(t, "empty", response.ID)
 	assert.Equal(t, "0", response.Metadata["upload_size"])
 }
+
+// gatedSource yields first bytes, then waits for open before yielding rest
+// more. A provider that reads the whole source before sending blocks on it.
+type gatedSource struct {
+	first, rest int
+	open        <-chan struct{}
+	read        int
+}
+
+func (s *gatedSource) Read(p []byte) (int, error) {
+	total := s.first + s.rest
+	if s.read >= total {
+		return 0, io.EOF
+	}
+	limit := s.first
+	if s.read >= s.first {
+		select {
+		case <-s.open:
+		case <-time.After(5 * time.Second):
+			return 0, errors.New("source read past its first part before any of it reached the server")
+		}
+		limit = total
+	}
+	n := min(len(p), limit-s.read)
+	for i := range p[:n] {
+		p[i] = 'x'
+	}
+	s.read += n
+	return n, nil
+}
+
+func TestUpload_UnknownLengthIsStreamed(t *testing.T) {
+	const first, rest = 1 << 20, 1 << 20
+	open := make(chan struct{})
+	var received int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, int64(-1), r.ContentLength, "expected a chunked body of unknown length")
+		reader, err := r.MultipartReader()
+		require.NoError(t, err)
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "stdin", part.FileName())
+
+		buf := make([]byte, 32<<10)
+		for {
+			n, err := part.Read(buf)
+			received += int64(n)
+			if received >= first && open != nil {
+				close(open) // The first part arrived before the source was read to the end
+				open = nil
+			}
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		fmt.Fprintf(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/s","id":"s","size":%d}}`, received)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+	})
+	require.NoError(t, err)
+	assert.True(t, provider.AcceptsUnknownLength())
+
+	response, err := provider.Upload(context.Background(), "stdin", &gatedSource{first: first, rest: rest, open: open}, -1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(first+rest), received)
+	assert.Equal(t, fmt.Sprintf("%d", first+rest), response.Metadata["upload_size"])
+}
+
+func TestUpload_FilenameWithQuoteAndNewline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(10 << 20)
+		require.NoError(t, err)
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+
+		// The quote survives escaping, the newline is replaced
+		assert.Equal(t, "my \"best\"_photo.jpg", header.Filename)
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "image bytes", string(content))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/q1","id":"q1","fileName":"x"}}`)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+	})
+	require.NoError(t, err)
+
+	file := bytes.NewBufferString("image bytes")
+	response, err := provider.Upload(context.Background(), "/tmp/my \"best\"\nphoto.jpg", file, int64(file.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, "my \"best\"_photo.jpg", response.Metadata["original_name"])
+}
+
+func TestUploadWithOptions_ContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+
+		assert.Equal(t, "my \"best\".png", header.Filename)
+		assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/o1","id":"o1","fileName":"x"}}`)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+	})
+	require.NoError(t, err)
+
+	file := bytes.NewBufferString("png bytes")
+	response, err := provider.UploadWithOptions(context.Background(), file, providers.UploadOptions{
+		FilePath:    "/tmp/my \"best\".png",
+		Size:        int64(file.Len()),
+		ContentType: "image/png",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "o1", response.ID)
+}
+
+func TestUpload_DetectsPartContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		content  string
+		want     string
+	}{
+		{"png", "/tmp/photo.png", "png bytes", "image/png"},
+		{"json", "/tmp/data.json", `{"a":1}`, "application/json"},
+		{"extensionless binary", "/tmp/blob", "\x00\x01\x02\xfe\xff", "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseMultipartForm(10<<20))
+				file, header, err := r.FormFile("file")
+				require.NoError(t, err)
+				defer file.Close()
+				assert.Equal(t, tt.want, header.Header.Get("Content-Type"))
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/d1","id":"d1","fileName":"x"}}`)
+			}))
+			defer server.Close()
+
+			provider, err := New(map[string]interface{}{
+				"upload_url": server.URL + "/uploadFile",
+			})
+			require.NoError(t, err)
+
+			file := bytes.NewBufferString(tt.content)
+			response, err := provider.Upload(context.Background(), tt.filePath, file, int64(file.Len()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, response.Metadata["content_type"])
+		})
+	}
+}
+
+func TestUpload_CustomBoundary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "multipart/form-data; boundary=woof-fixed-boundary", r.Header.Get("Content-Type"))
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/b1","id":"b1","fileName":"x"}}`)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+		"boundary":   "woof-fixed-boundary",
+	})
+	require.NoError(t, err)
+
+	file := bytes.NewBufferString("content")
+	_, err = provider.Upload(context.Background(), "test.txt", file, int64(file.Len()))
+	require.NoError(t, err)
+}
+
+func TestNew_InvalidBoundary(t *testing.T) {
+	_, err := New(map[string]interface{}{
+		"boundary": "bad\nboundary",
+	})
+	assert.Error(t, err)
+}
+
+func TestSanitizeFormFilename_LongName(t *testing.T) {
+	name := strings.Repeat("é", 200) + ".txt"
+	sanitized := sanitizeFormFilename(name)
+	assert.LessOrEqual(t, len(sanitized), maxFormFilenameBytes)
+	assert.True(t, strings.HasSuffix(sanitized, ".txt"))
+	assert.True(t, utf8.ValidString(sanitized))
+}
+
+func TestUpload_DeterministicMultipartBody(t *testing.T) {
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/d1","id":"d1","fileName":"x"}}`)
+	}))
+	defer server.Close()
+
+	upload := func(deterministic bool) {
+		provider, err := New(map[string]interface{}{
+			"upload_url": server.URL + "/uploadFile",
+			"folder_id":  "folder-1",
+		})
+		require.NoError(t, err)
+		provider.Deterministic = deterministic
+
+		file := bytes.NewBufferString("same content")
+		_, err = provider.Upload(context.Background(), "report.txt", file, int64(file.Len()))
+		require.NoError(t, err)
+	}
+
+	upload(true)
+	upload(true)
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1], "deterministic bodies should be byte-identical")
+	assert.Contains(t, string(bodies[0]), deterministicBoundary)
+
+	upload(false)
+	upload(false)
+	require.Len(t, bodies, 4)
+	assert.NotEqual(t, bodies[2], bodies[3], "production bodies should use random boundaries")
+}
+
+func TestUpload_ShortBodyIsRetriedWithFullFile(t *testing.T) {
+	content := "the complete file content"
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		file.Close()
+		received = append(received, string(data))
+
+		// The first attempt only stores part of the body
+		size := len(data)
+		if len(received) == 1 {
+			size -= 5
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/s1","id":"s1","fileName":"x","size":%d}}`, size)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+	})
+	require.NoError(t, err)
+
+	config := providers.DefaultWrapperConfig()
+	config.RetryDelay = time.Millisecond
+	config.Jitter = false
+	wrapped := providers.NewConsistencyWrapper(provider, config)
+
+	response, err := wrapped.Upload(context.Background(), "test.txt", bytes.NewReader([]byte(content)), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "https://gofile.io/d/s1", response.URL)
+	require.Len(t, received, 2)
+	assert.Equal(t, content, received[1], "retry should resend the full file")
+}
+
+func TestUpload_ShortBodyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok","data":{"downloadPage":"https://gofile.io/d/s2","id":"s2","fileName":"x","size":3}}`)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url": server.URL + "/uploadFile",
+	})
+	require.NoError(t, err)
+
+	file := bytes.NewBufferString("0123456789")
+	_, err = provider.Upload(context.Background(), "test.txt", file, int64(file.Len()))
+	require.Error(t, err)
+
+	var provErr *providers.ProviderError
+	require.True(t, errors.As(err, &provErr))
+	assert.Equal(t, providers.CodeShortBody, provErr.Code)
+	assert.True(t, providers.IsRetryable(err))
+}
+
+func TestInitialize_SelectsServerOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.Write([]byte(`{"status":"ok","data":{"servers":[{"name":"store1","zone":"eu"},{"name":"store7","zone":"na"}]}}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"select_server": true,
+		"servers_url":   server.URL,
+		"zone":          "na",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Initialize(context.Background()))
+	require.NoError(t, provider.Initialize(context.Background()))
+	assert.Equal(t, "https://store7.gofile.io/contents/uploadfile", provider.UploadURL)
+	assert.Equal(t, 1, requests)
+}
+
+func TestInitialize_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok","data":{"servers":[]}}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"select_server": true,
+		"servers_url":   server.URL,
+	})
+	require.NoError(t, err)
+
+	err = provider.Initialize(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "https://upload.gofile.io/uploadFile", provider.UploadURL)
+}
+
+func TestInitialize_DisabledByDefault(t *testing.T) {
+	provider, err := New(map[string]interface{}{"servers_url": "http://127.0.0.1:0"})
+	require.NoError(t, err)
+
+	assert.NoError(t, provider.Initialize(context.Background()))
+	assert.Equal(t, "https://upload.gofile.io/uploadFile", provider.UploadURL)
+}
+
+func TestDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/contents", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "file-id-1", body["contentsId"])
+
+		w.Write([]byte(`{"status":"ok","data":{}}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"api_url": server.URL, "token": "secret"})
+	require.NoError(t, err)
+	assert.NoError(t, provider.Delete(context.Background(), "file-id-1"))
+}
+
+func TestDelete_RequiresToken(t *testing.T) {
+	provider, err := New(map[string]interface{}{})
+	require.NoError(t, err)
+
+	err = provider.Delete(context.Background(), "file-id-1")
+	assert.Equal(t, providers.ErrorTypeAuthentication, providers.GetErrorType(err))
+}
+
+func TestDelete_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"error-notFound"}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"api_url": server.URL, "token": "secret"})
+	require.NoError(t, err)
+	assert.Error(t, provider.Delete(context.Background(), "missing"))
+}
+
+func TestDelete_TokenRejectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":"error-auth"}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"api_url": server.URL, "token": "wrong"})
+	require.NoError(t, err)
+
+	err = provider.Delete(context.Background(), "file-id-1")
+	assert.Equal(t, providers.ErrorTypeAuthentication, providers.GetErrorType(err))
+}
+
+func TestHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/servers":
+			w.Write([]byte(`{"status":"ok","data":{"servers":[{"name":"store1","zone":"eu"}]}}`))
+		case "/accounts/getid":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"status":"ok","data":{"id":"account-1"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"servers_url": server.URL + "/servers", "api_url": server.URL})
+	require.NoError(t, err)
+	assert.NoError(t, provider.HealthCheck(context.Background()), "anonymous uploads only need the server list")
+
+	provider, err = New(map[string]interface{}{"servers_url": server.URL + "/servers", "api_url": server.URL, "token": "secret"})
+	require.NoError(t, err)
+	assert.NoError(t, provider.HealthCheck(context.Background()))
+
+	provider, err = New(map[string]interface{}{"servers_url": server.URL + "/servers", "api_url": server.URL, "token": "wrong"})
+	require.NoError(t, err)
+	err = provider.HealthCheck(context.Background())
+	assert.Equal(t, providers.ErrorTypeAuthentication, providers.GetErrorType(err))
+}
+
+func TestHealthCheck_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"servers_url": server.URL})
+	require.NoError(t, err)
+	assert.Error(t, provider.HealthCheck(context.Background()))
+}
@@ -0,0 +1,242 @@
+package litterbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// maxUploadSize is the upload limit enforced by litterbox (1 GB)
+const maxUploadSize = int64(1024 * 1024 * 1024)
+
+// defaultRetention is used when no retention is configured
+const defaultRetention = "1h"
+
+// retentionWindows lists the retention values litterbox accepts
+var retentionWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"12h": 12 * time.Hour,
+	"24h": 24 * time.Hour,
+	"72h": 72 * time.Hour,
+}
+
+// LitterboxProvider implements the provider interface for litterbox.catbox.moe,
+// catbox's temporary host. The embedded BaseProvider holds the HTTP client,
+// request signer, timeout, size and extension limits and the concurrency cap.
+type LitterboxProvider struct {
+	*providers.BaseProvider
+	UploadURL string
+	// Retention is how long the file is kept: 1h, 12h, 24h or 72h
+	Retention string
+
+	now func() time.Time
+}
+
+var (
+	_ providers.Provider           = (*LitterboxProvider)(nil)
+	_ providers.TimeoutProvider    = (*LitterboxProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*LitterboxProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("litterbox", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 7,
+	})
+}
+
+// New creates a new litterbox provider
+func New(config map[string]interface{}) (*LitterboxProvider, error) {
+	uploadURL, ok := config["upload_url"].(string)
+	if !ok {
+		uploadURL = "https://litterbox.catbox.moe/resources/internals/api.php"
+	}
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "Litterbox",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	retention, _ := config["retention"].(string)
+	if retention == "" {
+		retention = defaultRetention
+	}
+	if _, ok := retentionWindows[retention]; !ok {
+		return nil, fmt.Errorf("invalid retention %q: must be one of 1h, 12h, 24h or 72h", retention)
+	}
+
+	providerConfig := map[string]interface{}{
+		"upload_url": uploadURL,
+		"timeout":    timeout.String(),
+		"retention":  retention,
+	}
+	logging.ProviderConfig("Litterbox", providerConfig)
+
+	// litterbox rejects files over 1 GB
+	maxSize := maxUploadSize
+	if size, ok := config["max_file_size"].(int64); ok && size > 0 && size < maxUploadSize {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("Litterbox", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &LitterboxProvider{
+		BaseProvider: base,
+		UploadURL:    uploadURL,
+		Retention:    retention,
+		now:          time.Now,
+	}, nil
+}
+
+// Upload uploads a file to litterbox and returns a structured response
+func (p *LitterboxProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Base(filePath)
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+	actualSize := int64(len(buf))
+
+	// The declared size may be unknown; check what was actually read as well
+	if err := p.ValidateFile(ctx, filePath, actualSize); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := p.buildMultipartBody(filename, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", body.Len()),
+	})
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := p.ParseResponse(resp, duration, nil)
+	if resp.StatusCode != http.StatusOK {
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// litterbox answers with the file URL as plain text
+	fileURL, err := providers.ParsePlainTextURL(responseBody)
+	if err != nil {
+		p.LogProviderError("url_parse", err, map[string]interface{}{
+			"response": string(responseBody),
+		})
+		// Limits are reported as a plain-text message in place of the URL
+		if quotaErr := providers.QuotaError(p.Name(), 0, string(responseBody)); quotaErr != nil {
+			return nil, quotaErr
+		}
+		return nil, err
+	}
+
+	// The retention window starts when the upload is accepted
+	expires := p.now().Add(retentionWindows[p.Retention])
+
+	result := &providers.ProviderResponse{
+		URL:         fileURL,
+		DownloadURL: fileURL,
+		ID:          fileID(fileURL),
+		Expires:     &expires,
+		Metadata: map[string]string{
+			"provider":      "Litterbox",
+			"upload_method": "multipart_form",
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"retention":     p.Retention,
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+	}
+
+	logging.UploadComplete(filename, fileURL, duration)
+
+	return result, nil
+}
+
+// buildMultipartBody writes the upload form: reqtype, time and the file part
+func (p *LitterboxProvider) buildMultipartBody(filename string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("reqtype", "fileupload"); err != nil {
+		return nil, "", providers.NewNetworkError("failed to write reqtype field", err)
+	}
+
+	if err := writer.WriteField("time", p.Retention); err != nil {
+		return nil, "", providers.NewNetworkError("failed to write time field", err)
+	}
+
+	part, err := writer.CreateFormFile("fileToUpload", filename)
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to write form file", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return nil, "", providers.NewNetworkError("failed to close form writer", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
+
+// fileID returns the name litterbox assigned to the file, e.g. "abc123.png"
+func fileID(fileURL string) string {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(parsed.Path)
+}
@@ -0,0 +1,64 @@
+package litterbox
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+func TestNew_Retention(t *testing.T) {
+	provider, err := New(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "1h", provider.Retention)
+
+	provider, err = New(map[string]interface{}{"retention": "72h"})
+	require.NoError(t, err)
+	assert.Equal(t, "72h", provider.Retention)
+
+	for _, invalid := range []string{"2h", "1d", "forever"} {
+		_, err := New(map[string]interface{}{"retention": invalid})
+		assert.Error(t, err, "retention %q should be rejected", invalid)
+	}
+}
+
+func TestUpload_ExpiresFromRetention(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, []string{"fileupload"}, r.MultipartForm.Value["reqtype"])
+		assert.Equal(t, []string{"24h"}, r.MultipartForm.Value["time"])
+		_, header, err := r.FormFile("fileToUpload")
+		require.NoError(t, err)
+		assert.Equal(t, "clip.mp4", header.Filename)
+
+		w.Write([]byte("https://litter.catbox.moe/abc123.mp4"))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL, "retention": "24h"})
+	require.NoError(t, err)
+	uploadedAt := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return uploadedAt }
+
+	resp, err := provider.Upload(context.Background(), "clip.mp4", bytes.NewReader([]byte("video")), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "https://litter.catbox.moe/abc123.mp4", resp.URL)
+	assert.Equal(t, "abc123.mp4", resp.ID)
+	require.NotNil(t, resp.Expires)
+	assert.Equal(t, uploadedAt.Add(24*time.Hour), *resp.Expires)
+	assert.Equal(t, "24h", resp.Metadata["retention"])
+}
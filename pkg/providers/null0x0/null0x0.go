@@ -0,0 +1,289 @@
+package null0x0
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// maxUploadSize is the upload limit enforced by 0x0.st (512 MiB)
+const maxUploadSize = int64(512 * 1024 * 1024)
+
+// Null0x0Provider implements the provider interface for 0x0.st. The embedded
+// BaseProvider holds the HTTP client, request signer, timeout, size and
+// extension limits and the concurrency cap.
+type Null0x0Provider struct {
+	*providers.BaseProvider
+	UploadURL string
+	// ExpiresHours asks the server to delete the file after this many hours (0 keeps the server default)
+	ExpiresHours int
+	// Secret requests a hard-to-guess URL when set
+	Secret bool
+}
+
+var (
+	_ providers.Provider           = (*Null0x0Provider)(nil)
+	_ providers.TimeoutProvider    = (*Null0x0Provider)(nil)
+	_ providers.ConcurrencyLimiter = (*Null0x0Provider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("0x0", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 3,
+		Aliases:  []string{"null0x0"},
+	})
+}
+
+// New creates a new 0x0.st provider
+func New(config map[string]interface{}) (*Null0x0Provider, error) {
+	uploadURL, ok := config["upload_url"].(string)
+	if !ok {
+		uploadURL = "https://0x0.st"
+	}
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "0x0",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	expiresHours, err := intSetting(config["expires_hours"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid expires_hours: %w", err)
+	}
+	if expiresHours < 0 {
+		return nil, fmt.Errorf("invalid expires_hours: %d must not be negative", expiresHours)
+	}
+
+	secret, err := boolSetting(config["secret"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret: %w", err)
+	}
+
+	providerConfig := map[string]interface{}{
+		"upload_url":    uploadURL,
+		"timeout":       timeout.String(),
+		"expires_hours": expiresHours,
+		"secret":        secret,
+	}
+	logging.ProviderConfig("0x0", providerConfig)
+
+	// 0x0.st rejects files over 512 MiB
+	maxSize := maxUploadSize
+	if size, ok := config["max_file_size"].(int64); ok && size > 0 && size < maxUploadSize {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("0x0", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Null0x0Provider{
+		BaseProvider: base,
+		UploadURL:    uploadURL,
+		ExpiresHours: expiresHours,
+		Secret:       secret,
+	}, nil
+}
+
+// Upload uploads a file to 0x0.st and returns a structured response
+func (p *Null0x0Provider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Base(filePath)
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+	actualSize := int64(len(buf))
+
+	// The declared size may be unknown; check what was actually read as well
+	if err := p.ValidateFile(ctx, filePath, actualSize); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := p.buildMultipartBody(filename, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", body.Len()),
+	})
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	// The answer is plain text, so it is not parsed as JSON
+	responseBody, err := p.ParseResponse(resp, duration, nil)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 0x0.st answers with the file URL as plain text
+	fileURL, err := providers.ParsePlainTextURL(responseBody)
+	if err != nil {
+		p.LogProviderError("url_parse", err, map[string]interface{}{
+			"response": string(responseBody),
+		})
+		// Limits are reported as a plain-text message in place of the URL
+		if quotaErr := providers.QuotaError(p.Name(), 0, string(responseBody)); quotaErr != nil {
+			return nil, quotaErr
+		}
+		return nil, err
+	}
+
+	result := &providers.ProviderResponse{
+		URL:         fileURL,
+		DownloadURL: fileURL,
+		ID:          fileID(fileURL),
+		Metadata: map[string]string{
+			"provider":      "0x0",
+			"upload_method": "multipart_form",
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+	}
+
+	if p.ExpiresHours > 0 {
+		result.Metadata["expires_hours"] = strconv.Itoa(p.ExpiresHours)
+	}
+	// The management token allows deleting the file or changing its expiry later
+	if token := resp.Header.Get("X-Token"); token != "" {
+		result.Metadata["management_token"] = token
+	}
+
+	logging.UploadComplete(filename, fileURL, duration)
+
+	return result, nil
+}
+
+// buildMultipartBody writes the upload form: the file part followed by the
+// optional expires and secret fields
+func (p *Null0x0Provider) buildMultipartBody(filename string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to write form file", err)
+	}
+
+	if p.ExpiresHours > 0 {
+		if err := writer.WriteField("expires", strconv.Itoa(p.ExpiresHours)); err != nil {
+			return nil, "", providers.NewNetworkError("failed to write expires field", err)
+		}
+	}
+
+	if p.Secret {
+		if err := writer.WriteField("secret", ""); err != nil {
+			return nil, "", providers.NewNetworkError("failed to write secret field", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return nil, "", providers.NewNetworkError("failed to close form writer", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
+
+// fileID returns the short name 0x0.st assigned to the file, e.g. "abc.txt"
+func fileID(fileURL string) string {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(parsed.Path)
+}
+
+// intSetting reads an integer setting that may be decoded from YAML, JSON or a string
+func intSetting(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("%v is not a whole number", v)
+		}
+		return int(v), nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+// boolSetting reads a boolean setting that may be given as a bool or a string
+func boolSetting(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		if v == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(v)
+	default:
+		return false, fmt.Errorf("unsupported type %T", value)
+	}
+}
@@ -0,0 +1,160 @@
+package null0x0
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://0x0.st", provider.UploadURL)
+	assert.Equal(t, int64(512*1024*1024), provider.GetMaxFileSize())
+	assert.Equal(t, 0, provider.ExpiresHours)
+	assert.False(t, provider.Secret)
+	assert.Equal(t, "0x0", provider.Name())
+
+	provider, err = New(map[string]interface{}{
+		"expires_hours": float64(24),
+		"secret":        true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 24, provider.ExpiresHours)
+	assert.True(t, provider.Secret)
+
+	_, err = New(map[string]interface{}{"expires_hours": "soon"})
+	assert.Error(t, err)
+
+	_, err = New(map[string]interface{}{"expires_hours": -1})
+	assert.Error(t, err)
+}
+
+func TestUpload_MultipartBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "test.txt", header.Filename)
+		assert.Equal(t, "hello 0x0", string(content))
+		assert.Empty(t, r.MultipartForm.Value["expires"])
+		assert.Empty(t, r.MultipartForm.Value["secret"])
+
+		w.Header().Set("X-Token", "manage-me")
+		w.Write([]byte("https://0x0.st/abc.txt\n"))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	content := []byte("hello 0x0")
+	resp, err := provider.Upload(context.Background(), "/tmp/test.txt", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "https://0x0.st/abc.txt", resp.URL)
+	assert.Equal(t, "https://0x0.st/abc.txt", resp.DownloadURL)
+	assert.Equal(t, "abc.txt", resp.ID)
+	assert.Equal(t, "manage-me", resp.Metadata["management_token"])
+}
+
+func TestUpload_ExpiresAndSecretFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, []string{"48"}, r.MultipartForm.Value["expires"])
+		assert.Equal(t, []string{""}, r.MultipartForm.Value["secret"])
+		w.Write([]byte("https://0x0.st/s/longsecret/abc.txt"))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"upload_url":    server.URL,
+		"expires_hours": 48,
+		"secret":        "true",
+	})
+	require.NoError(t, err)
+
+	content := []byte("expiring")
+	resp, err := provider.Upload(context.Background(), "test.txt", bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "48", resp.Metadata["expires_hours"])
+}
+
+func TestUpload_InvalidBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Bad gateway</html>"))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "test.txt", strings.NewReader("x"), 1)
+	require.Error(t, err)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.CodeInvalidURL, providerErr.Code)
+}
+
+func TestUpload_RateLimitBodyIsQuotaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>Too many requests</html>"))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "test.txt", strings.NewReader("x"), 1)
+	require.Error(t, err)
+	assert.True(t, providers.IsQuotaError(err))
+	assert.False(t, providers.IsRetryable(err))
+	assert.Contains(t, err.Error(), "try again later or use another provider")
+}
+
+func TestValidateFile_TooLarge(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	err = provider.ValidateFile(context.Background(), "big.bin", provider.GetMaxFileSize()+1)
+	require.Error(t, err)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.ErrorTypeFileTooLarge, providerErr.Type)
+	assert.NoError(t, provider.ValidateFile(context.Background(), "ok.bin", provider.GetMaxFileSize()))
+
+	// Oversized content is rejected before anything is sent
+	provider, err = New(map[string]interface{}{"upload_url": server.URL, "max_file_size": int64(4)})
+	require.NoError(t, err)
+	_, err = provider.Upload(context.Background(), "big.bin", strings.NewReader("too big"), -1)
+	require.Error(t, err)
+	assert.Equal(t, 0, requests)
+}
@@ -0,0 +1,223 @@
+package tmpfiles
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// maxUploadSize is the upload limit enforced by tmpfiles.org (100 MB)
+const maxUploadSize = int64(100 * 1024 * 1024)
+
+// TmpfilesResponse represents the API response format
+type TmpfilesResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		URL string `json:"url"`
+	} `json:"data"`
+}
+
+// TmpfilesProvider implements the provider interface for tmpfiles.org. The
+// embedded BaseProvider holds the HTTP client, request signer, timeout, size
+// and extension limits and the concurrency cap.
+type TmpfilesProvider struct {
+	*providers.BaseProvider
+	UploadURL string
+}
+
+var (
+	_ providers.Provider           = (*TmpfilesProvider)(nil)
+	_ providers.TimeoutProvider    = (*TmpfilesProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*TmpfilesProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("tmpfiles", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 8,
+	})
+}
+
+// New creates a new tmpfiles.org provider
+func New(config map[string]interface{}) (*TmpfilesProvider, error) {
+	uploadURL, ok := config["upload_url"].(string)
+	if !ok {
+		uploadURL = "https://tmpfiles.org/api/v1/upload"
+	}
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "Tmpfiles",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	providerConfig := map[string]interface{}{
+		"upload_url": uploadURL,
+		"timeout":    timeout.String(),
+	}
+	logging.ProviderConfig("Tmpfiles", providerConfig)
+
+	// tmpfiles.org rejects files over 100 MB
+	maxSize := maxUploadSize
+	if size, ok := config["max_file_size"].(int64); ok && size > 0 && size < maxUploadSize {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("Tmpfiles", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &TmpfilesProvider{BaseProvider: base, UploadURL: uploadURL}, nil
+}
+
+// Upload uploads a file to tmpfiles.org and returns a structured response
+func (p *TmpfilesProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Base(filePath)
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+	actualSize := int64(len(buf))
+
+	// The declared size may be unknown; check what was actually read as well
+	if err := p.ValidateFile(ctx, filePath, actualSize); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := p.buildMultipartBody(filename, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", body.Len()),
+	})
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	var response TmpfilesResponse
+	responseBody, err := p.ParseResponse(resp, duration, &response)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Status != "success" {
+		if quotaErr := providers.QuotaError(p.Name(), 0, string(responseBody)); quotaErr != nil {
+			return nil, quotaErr
+		}
+		return nil, providers.ErrUploadRejected(response.Status)
+	}
+
+	if response.Data.URL == "" {
+		return nil, providers.ErrMissingDownloadURL()
+	}
+
+	downloadURL, err := directDownloadURL(response.Data.URL)
+	if err != nil {
+		p.LogProviderError("download_url", err, map[string]interface{}{
+			"url": response.Data.URL,
+		})
+		return nil, providers.NewAPIError(providers.CodeInvalidURL, fmt.Sprintf("invalid page URL %q", response.Data.URL), err)
+	}
+
+	result := &providers.ProviderResponse{
+		URL:         response.Data.URL,
+		DownloadURL: downloadURL,
+		Metadata: map[string]string{
+			"provider":      "Tmpfiles",
+			"upload_method": "multipart_form",
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+		ProviderData: &response,
+	}
+
+	logging.UploadComplete(filename, response.Data.URL, duration)
+
+	return result, nil
+}
+
+// directDownloadURL turns a tmpfiles page URL such as https://tmpfiles.org/123/a.txt
+// into its direct link https://tmpfiles.org/dl/123/a.txt
+func directDownloadURL(pageURL string) (string, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+	if parsed.Path != "/dl" && !strings.HasPrefix(parsed.Path, "/dl/") {
+		parsed.Path = "/dl" + "/" + strings.TrimPrefix(parsed.Path, "/")
+		parsed.RawPath = ""
+	}
+	return parsed.String(), nil
+}
+
+// buildMultipartBody writes the upload form with the file in the file field
+func (p *TmpfilesProvider) buildMultipartBody(filename string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to write form file", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return nil, "", providers.NewNetworkError("failed to close form writer", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
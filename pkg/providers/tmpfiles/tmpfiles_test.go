@@ -0,0 +1,83 @@
+package tmpfiles
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+func TestDirectDownloadURL(t *testing.T) {
+	tests := []struct {
+		page     string
+		expected string
+	}{
+		{page: "https://tmpfiles.org/12345/file.txt", expected: "https://tmpfiles.org/dl/12345/file.txt"},
+		{page: "http://tmpfiles.org/987/photo.jpg", expected: "http://tmpfiles.org/dl/987/photo.jpg"},
+		{page: "https://tmpfiles.org/42/my%20report.pdf", expected: "https://tmpfiles.org/dl/42/my%20report.pdf"},
+		{page: "https://tmpfiles.org/7/archive.tar.gz?x=1", expected: "https://tmpfiles.org/dl/7/archive.tar.gz?x=1"},
+		{page: "https://tmpfiles.org/dl/12345/file.txt", expected: "https://tmpfiles.org/dl/12345/file.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.page, func(t *testing.T) {
+			got, err := directDownloadURL(tt.page)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	_, err := directDownloadURL("not a url/123")
+	assert.Error(t, err)
+}
+
+func TestUpload_PageAndDownloadURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "file.txt", header.Filename)
+		assert.Equal(t, "data", string(content))
+
+		w.Write([]byte(`{"status":"success","data":{"url":"https://tmpfiles.org/12345/file.txt"}}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "/tmp/file.txt", bytes.NewReader([]byte("data")), 4)
+	require.NoError(t, err)
+	assert.Equal(t, "https://tmpfiles.org/12345/file.txt", resp.URL)
+	assert.Equal(t, "https://tmpfiles.org/dl/12345/file.txt", resp.DownloadURL)
+}
+
+func TestUpload_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"error","data":{}}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "file.txt", bytes.NewReader([]byte("data")), 4)
+	assert.Error(t, err)
+}
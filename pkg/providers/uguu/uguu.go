@@ -0,0 +1,209 @@
+package uguu
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// maxUploadSize is the upload limit enforced by uguu.se (128 MB)
+const maxUploadSize = int64(128 * 1024 * 1024)
+
+// UguuFile is a single uploaded file in the API response
+type UguuFile struct {
+	Hash     string `json:"hash"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	Size     int64  `json:"size"`
+}
+
+// UguuResponse represents the API response format
+type UguuResponse struct {
+	Success     bool       `json:"success"`
+	Files       []UguuFile `json:"files"`
+	ErrorCode   int        `json:"errorcode"`
+	Description string     `json:"description"`
+}
+
+// UguuProvider implements the provider interface for uguu.se. The embedded
+// BaseProvider holds the HTTP client, request signer, timeout, size and
+// extension limits and the concurrency cap.
+type UguuProvider struct {
+	*providers.BaseProvider
+	UploadURL string
+}
+
+var (
+	_ providers.Provider           = (*UguuProvider)(nil)
+	_ providers.TimeoutProvider    = (*UguuProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*UguuProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("uguu", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 6,
+	})
+}
+
+// New creates a new uguu.se provider
+func New(config map[string]interface{}) (*UguuProvider, error) {
+	uploadURL, ok := config["upload_url"].(string)
+	if !ok {
+		uploadURL = "https://uguu.se/upload.php"
+	}
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "Uguu",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	providerConfig := map[string]interface{}{
+		"upload_url": uploadURL,
+		"timeout":    timeout.String(),
+	}
+	logging.ProviderConfig("Uguu", providerConfig)
+
+	// uguu.se rejects files over 128 MB
+	maxSize := maxUploadSize
+	if size, ok := config["max_file_size"].(int64); ok && size > 0 && size < maxUploadSize {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("Uguu", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &UguuProvider{BaseProvider: base, UploadURL: uploadURL}, nil
+}
+
+// Upload uploads a file to uguu.se and returns a structured response
+func (p *UguuProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	filename := filepath.Base(filePath)
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filename,
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+	actualSize := int64(len(buf))
+
+	// The declared size may be unknown; check what was actually read as well
+	if err := p.ValidateFile(ctx, filePath, actualSize); err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := p.buildMultipartBody(filename, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPost, p.UploadURL, body, map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": fmt.Sprintf("%d", body.Len()),
+	})
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	var response UguuResponse
+	responseBody, err := p.ParseResponse(resp, duration, &response)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		message := response.Description
+		if message == "" {
+			message = "success=false"
+		}
+		return nil, providers.NewAPIError(providers.CodeUploadError, fmt.Sprintf("upload failed: %s", message), nil)
+	}
+
+	// Only one file is sent, so the first entry is ours
+	if len(response.Files) == 0 || response.Files[0].URL == "" {
+		return nil, providers.ErrMissingDownloadURL()
+	}
+	uploaded := response.Files[0]
+
+	result := &providers.ProviderResponse{
+		URL:         uploaded.URL,
+		DownloadURL: uploaded.URL,
+		ID:          uploaded.Filename,
+		Metadata: map[string]string{
+			"provider":      "Uguu",
+			"upload_method": "multipart_form",
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"uguu_hash":     uploaded.Hash,
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+		ProviderData: &response,
+	}
+
+	logging.UploadComplete(filename, uploaded.URL, duration)
+
+	return result, nil
+}
+
+// buildMultipartBody writes the upload form with the file in the files[] field
+func (p *UguuProvider) buildMultipartBody(filename string, content []byte) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("files[]", filename)
+	if err != nil {
+		p.LogProviderError("form_file_create", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to create form file", err)
+	}
+
+	if _, err := part.Write(content); err != nil {
+		p.LogProviderError("form_file_write", err, map[string]interface{}{
+			"filename": filename,
+		})
+		return nil, "", providers.NewNetworkError("failed to write form file", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		p.LogProviderError("form_close", err, nil)
+		return nil, "", providers.NewNetworkError("failed to close form writer", err)
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
@@ -0,0 +1,94 @@
+package uguu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+func TestUpload_NestedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+
+		file, header, err := r.FormFile("files[]")
+		require.NoError(t, err)
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "photo.jpg", header.Filename)
+		assert.Equal(t, "jpeg bytes", string(content))
+
+		w.Write([]byte(`{"success":true,"files":[{"hash":"abc","filename":"XyZ.jpg","url":"https://a.uguu.se/XyZ.jpg","size":10,"dupe":false}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "/tmp/photo.jpg", bytes.NewReader([]byte("jpeg bytes")), 10)
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.uguu.se/XyZ.jpg", resp.URL)
+	assert.Equal(t, "https://a.uguu.se/XyZ.jpg", resp.DownloadURL)
+	assert.Equal(t, "XyZ.jpg", resp.ID)
+	assert.Equal(t, "Uguu", resp.Metadata["provider"])
+	assert.Equal(t, "photo.jpg", resp.Metadata["original_name"])
+}
+
+func TestUpload_EmptyFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"files":[]}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "photo.jpg", strings.NewReader("x"), 1)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.CodeMissingDownloadURL, providerErr.Code)
+}
+
+func TestUpload_Unsuccessful(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"errorcode":400,"description":"No input file(s)"}`))
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{"upload_url": server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "photo.jpg", strings.NewReader("x"), 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "No input file(s)")
+}
+
+func TestValidateFile_SizeLimit(t *testing.T) {
+	provider, err := New(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(128*1024*1024), provider.GetMaxFileSize())
+
+	err = provider.ValidateFile(context.Background(), "big.bin", provider.GetMaxFileSize()+1)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.ErrorTypeFileTooLarge, providerErr.Type)
+	assert.NoError(t, provider.ValidateFile(context.Background(), "ok.bin", provider.GetMaxFileSize()))
+}
@@ -0,0 +1,306 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+// methodMkcol creates a WebDAV collection (directory)
+const methodMkcol = "MKCOL"
+
+// WebDAVProvider implements the provider interface for WebDAV servers. The
+// embedded BaseProvider holds the HTTP client, request signer, timeout, size
+// and extension limits and the concurrency cap.
+type WebDAVProvider struct {
+	*providers.BaseProvider
+	BaseURL string
+	// Auth holds the credentials, usually username and password for basic authentication
+	Auth providers.Auth
+	// RemoteDir is the collection, relative to BaseURL, that files are stored in
+	RemoteDir string
+	// CreateDirs creates RemoteDir and its parents with MKCOL before the first upload
+	CreateDirs bool
+	// ChunkedUploads sends large files as PUTs with Content-Range, which the server must support
+	ChunkedUploads bool
+
+	dirsOnce sync.Once
+	dirsErr  error
+}
+
+var (
+	_ providers.Provider           = (*WebDAVProvider)(nil)
+	_ providers.TimeoutProvider    = (*WebDAVProvider)(nil)
+	_ providers.ConcurrencyLimiter = (*WebDAVProvider)(nil)
+	_ providers.Initializer        = (*WebDAVProvider)(nil)
+	_ providers.Chunked            = (*WebDAVProvider)(nil)
+)
+
+func init() {
+	providers.RegisterProvider("webdav", providers.Registration{
+		New: func(settings map[string]interface{}) (providers.Provider, error) {
+			return New(settings)
+		},
+		Priority: 9,
+		// A placeholder server lets the provider list read the limits
+		SetupSettings: map[string]interface{}{"base_url": "https://webdav.invalid"},
+	})
+}
+
+// New creates a new WebDAV provider
+func New(config map[string]interface{}) (*WebDAVProvider, error) {
+	baseURL, _ := config["base_url"].(string)
+	if baseURL == "" {
+		return nil, fmt.Errorf("base_url is required")
+	}
+	if parsed, err := url.Parse(baseURL); err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid base_url %q", baseURL)
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	timeoutStr, ok := config["timeout"].(string)
+	if !ok {
+		timeoutStr = "10m"
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 10 * time.Minute // Default timeout
+		logging.ErrorContext("provider_config", err, map[string]interface{}{
+			"provider": "WebDAV",
+			"setting":  "timeout",
+			"value":    timeoutStr,
+		})
+	}
+
+	auth := providers.ParseAuth(config)
+	remoteDir, _ := config["remote_dir"].(string)
+	remoteDir = strings.Trim(remoteDir, "/")
+	createDirs, _ := config["create_dirs"].(bool)
+	chunked, _ := config["chunked_uploads"].(bool)
+
+	providerConfig := map[string]interface{}{
+		"base_url":        baseURL,
+		"timeout":         timeout.String(),
+		"username":        auth.Username,
+		"password":        auth.Password,
+		"bearer_token":    auth.BearerToken,
+		"remote_dir":      remoteDir,
+		"create_dirs":     createDirs,
+		"chunked_uploads": chunked,
+	}
+	logging.ProviderConfig("WebDAV", providerConfig)
+
+	// Limits depend on the server; 0 leaves size enforcement to it
+	maxSize := int64(0)
+	if size, ok := config["max_file_size"].(int64); ok {
+		maxSize = size
+	}
+
+	// The base provider supports all file types unless allowed_extensions
+	// restricts them
+	base, err := providers.NewBaseProviderFromSettings("WebDAV", config, timeout, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &WebDAVProvider{
+		BaseProvider:   base,
+		BaseURL:        baseURL,
+		Auth:           auth,
+		RemoteDir:      remoteDir,
+		CreateDirs:     createDirs,
+		ChunkedUploads: chunked,
+	}, nil
+}
+
+// Initialize creates the remote directory when CreateDirs is set; a dry run
+// leaves the server untouched
+func (p *WebDAVProvider) Initialize(ctx context.Context) error {
+	if providers.IsDryRun(ctx) {
+		return nil
+	}
+	return p.ensureDirs(ctx)
+}
+
+// ensureDirs issues MKCOL for every level of RemoteDir once; later calls
+// return the first result
+func (p *WebDAVProvider) ensureDirs(ctx context.Context) error {
+	if !p.CreateDirs || p.RemoteDir == "" {
+		return nil
+	}
+	p.dirsOnce.Do(func() {
+		var current []string
+		for _, segment := range strings.Split(p.RemoteDir, "/") {
+			if segment == "" {
+				continue
+			}
+			current = append(current, segment)
+			if err := p.mkcol(ctx, p.resourceURL(current...)+"/"); err != nil {
+				p.dirsErr = err
+				return
+			}
+		}
+	})
+	return p.dirsErr
+}
+
+// mkcol creates one collection; an existing collection is not an error
+func (p *WebDAVProvider) mkcol(ctx context.Context, collectionURL string) error {
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, methodMkcol, collectionURL, nil, p.Auth.Headers())
+	duration := time.Since(start)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// An existing collection answers 405, so the body is read here rather
+	// than by ParseResponse, which would log it as an API error
+	responseBody, _ := providers.ReadResponseBody(resp)
+	logging.HTTPResponse(resp.StatusCode, string(responseBody), duration)
+
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusOK:
+		return nil
+	case http.StatusMethodNotAllowed:
+		return nil // The collection already exists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return providers.NewAuthenticationError(fmt.Sprintf("WebDAV server rejected credentials (status %d)", resp.StatusCode), nil)
+	default:
+		return providers.ErrAPIStatus(resp.StatusCode, string(responseBody))
+	}
+}
+
+// Upload stores a file on the WebDAV server and returns a structured response
+func (p *WebDAVProvider) Upload(ctx context.Context, filePath string, file io.Reader, size int64) (*providers.ProviderResponse, error) {
+	if err := p.ValidateFile(ctx, filePath, size); err != nil {
+		return nil, err
+	}
+
+	if err := p.ensureDirs(ctx); err != nil {
+		return nil, err
+	}
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file": filepath.Base(filePath),
+			"size": size,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+
+	return p.put(ctx, filePath, buf, "")
+}
+
+// SupportsChunks reports whether chunked_uploads is enabled
+func (p *WebDAVProvider) SupportsChunks() bool {
+	return p.ChunkedUploads
+}
+
+// UploadChunk stores length bytes at offset of the file with a ranged PUT
+func (p *WebDAVProvider) UploadChunk(ctx context.Context, filePath string, chunk io.Reader, offset, length, size int64) (*providers.ProviderResponse, error) {
+	if offset == 0 {
+		if err := p.ValidateFile(ctx, filePath, size); err != nil {
+			return nil, err
+		}
+		if err := p.ensureDirs(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(chunk, length))
+	if err != nil {
+		p.LogProviderError("file_read", err, map[string]interface{}{
+			"file":   filepath.Base(filePath),
+			"offset": offset,
+		})
+		return nil, providers.ErrFileRead(err)
+	}
+
+	contentRange := fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(buf))-1, size)
+	return p.put(ctx, filePath, buf, contentRange)
+}
+
+// put sends buf to the file's URL, as the byte range contentRange when set
+func (p *WebDAVProvider) put(ctx context.Context, filePath string, buf []byte, contentRange string) (*providers.ProviderResponse, error) {
+	filename := filepath.Base(filePath)
+	segments := []string{filename}
+	if p.RemoteDir != "" {
+		segments = append(strings.Split(p.RemoteDir, "/"), filename)
+	}
+	fileURL := p.resourceURL(segments...)
+	actualSize := int64(len(buf))
+
+	headers := p.Auth.Headers()
+	headers["Content-Type"] = "application/octet-stream"
+	headers["Content-Length"] = fmt.Sprintf("%d", actualSize)
+	if contentRange != "" {
+		headers["Content-Range"] = contentRange
+	}
+
+	start := time.Now()
+	resp, err := p.MakeRequest(ctx, http.MethodPut, fileURL, bytes.NewReader(buf), headers)
+	duration := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := p.ParseResponse(resp, duration, nil)
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	case http.StatusUnauthorized:
+		return nil, providers.NewAuthenticationError("WebDAV server rejected credentials", nil)
+	default:
+		return nil, providers.ErrUploadStatusFor(p.Name(), resp, string(responseBody))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uploadMethod := "put"
+	if contentRange != "" {
+		uploadMethod = "ranged_put"
+	}
+	result := &providers.ProviderResponse{
+		URL:         fileURL,
+		DownloadURL: fileURL,
+		ID:          strings.Join(segments, "/"),
+		Metadata: map[string]string{
+			"provider":      "WebDAV",
+			"upload_method": uploadMethod,
+			"duration_ms":   fmt.Sprintf("%d", duration.Milliseconds()),
+			"original_name": filename,
+			"upload_size":   fmt.Sprintf("%d", actualSize),
+			"remote_dir":    p.RemoteDir,
+			"endpoint":      providers.ResponseEndpoint(resp),
+		},
+	}
+	if contentRange != "" {
+		result.Metadata["content_range"] = contentRange
+	}
+
+	logging.UploadComplete(filename, fileURL, duration)
+
+	return result, nil
+}
+
+// resourceURL joins path segments onto BaseURL, escaping each one
+func (p *WebDAVProvider) resourceURL(segments ...string) string {
+	escaped := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment != "" {
+			escaped = append(escaped, url.PathEscape(segment))
+		}
+	}
+	return p.BaseURL + "/" + strings.Join(escaped, "/")
+}
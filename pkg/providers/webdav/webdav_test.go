@@ -0,0 +1,211 @@
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parnexcodes/woof/internal/logging"
+	"github.com/parnexcodes/woof/internal/providers"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logging for tests
+	logging.Init(false, os.Stderr)
+	os.Exit(m.Run())
+}
+
+// davServer records every request as "METHOD path"
+type davServer struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   map[string]string
+	status   map[string]int // Optional status per "METHOD path"
+}
+
+func newDAVServer(t *testing.T) (*davServer, *httptest.Server) {
+	dav := &davServer{bodies: make(map[string]string), status: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		key := r.Method + " " + r.URL.EscapedPath()
+		body, _ := io.ReadAll(r.Body)
+
+		dav.mu.Lock()
+		dav.requests = append(dav.requests, key)
+		dav.bodies[key] = string(body)
+		status, ok := dav.status[key]
+		dav.mu.Unlock()
+
+		if !ok {
+			status = http.StatusCreated
+		}
+		w.WriteHeader(status)
+	}))
+	return dav, server
+}
+
+func TestUpload_CreatesCollectionsThenPuts(t *testing.T) {
+	dav, server := newDAVServer(t)
+	defer server.Close()
+	// The first level already exists
+	dav.status["MKCOL /dav/backups/"] = http.StatusMethodNotAllowed
+
+	provider, err := New(map[string]interface{}{
+		"base_url":    server.URL + "/dav/",
+		"username":    "alice",
+		"password":    "secret",
+		"remote_dir":  "/backups/2026 q4/",
+		"create_dirs": true,
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "/tmp/report.pdf", bytes.NewReader([]byte("pdf")), 3)
+	require.NoError(t, err)
+	_, err = provider.Upload(context.Background(), "/tmp/notes.txt", bytes.NewReader([]byte("txt")), 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"MKCOL /dav/backups/",
+		"MKCOL /dav/backups/2026%20q4/",
+		"PUT /dav/backups/2026%20q4/report.pdf",
+		"PUT /dav/backups/2026%20q4/notes.txt",
+	}, dav.requests)
+	assert.Equal(t, "pdf", dav.bodies["PUT /dav/backups/2026%20q4/report.pdf"])
+	assert.Equal(t, server.URL+"/dav/backups/2026%20q4/report.pdf", resp.URL)
+	assert.Equal(t, resp.URL, resp.DownloadURL)
+}
+
+func TestUpload_WithoutCreateDirs(t *testing.T) {
+	dav, server := newDAVServer(t)
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"base_url":   server.URL,
+		"username":   "alice",
+		"password":   "secret",
+		"remote_dir": "uploads",
+	})
+	require.NoError(t, err)
+
+	resp, err := provider.Upload(context.Background(), "a.txt", bytes.NewReader([]byte("a")), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PUT /uploads/a.txt"}, dav.requests)
+	assert.Equal(t, server.URL+"/uploads/a.txt", resp.URL)
+}
+
+func TestUpload_Unauthorized(t *testing.T) {
+	_, server := newDAVServer(t)
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"base_url": server.URL,
+		"username": "alice",
+		"password": "wrong",
+	})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "a.txt", bytes.NewReader([]byte("a")), 1)
+	var providerErr *providers.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, providers.ErrorTypeAuthentication, providerErr.Type)
+}
+
+func TestUpload_SignsRequest(t *testing.T) {
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok && user == "alice" && pass == "secret", "basic auth kept alongside the signature")
+		signature = r.Header.Get("X-Signature")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"base_url":       server.URL,
+		"username":       "alice",
+		"password":       "secret",
+		"signing_key":    "key",
+		"signing_key_id": "k",
+		"signing_header": "X-Signature",
+	})
+	require.NoError(t, err)
+
+	_, err = provider.Upload(context.Background(), "a.txt", bytes.NewReader([]byte("a")), 1)
+	require.NoError(t, err)
+	assert.Contains(t, signature, "HMAC-SHA256 KeyId=k,")
+}
+
+func TestNew_RequiresBaseURL(t *testing.T) {
+	_, err := New(map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestUploadChunk_ReassemblesRangedPuts(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		stored []byte
+		ranges []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start, end, total int64
+		contentRange := r.Header.Get("Content-Range")
+		if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if int64(len(stored)) != start || end-start+1 != int64(len(body)) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		stored = append(stored, body...)
+		ranges = append(ranges, contentRange)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	provider, err := New(map[string]interface{}{
+		"base_url":        server.URL,
+		"chunked_uploads": true,
+	})
+	require.NoError(t, err)
+	require.True(t, providers.SupportsChunks(provider))
+
+	data := []byte("0123456789abcdefghij")
+	size := int64(len(data))
+	var resp *providers.ProviderResponse
+	for offset := int64(0); offset < size; offset += 8 {
+		length := min(8, size-offset)
+		resp, err = provider.UploadChunk(context.Background(), "big.bin", bytes.NewReader(data[offset:offset+length]), offset, length, size)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, data, stored)
+	assert.Equal(t, []string{"bytes 0-7/20", "bytes 8-15/20", "bytes 16-19/20"}, ranges)
+	assert.Equal(t, server.URL+"/big.bin", resp.URL)
+	assert.Equal(t, "ranged_put", resp.Metadata["upload_method"])
+}
+
+func TestSupportsChunks_OffByDefault(t *testing.T) {
+	provider, err := New(map[string]interface{}{"base_url": "https://dav.example.com"})
+	require.NoError(t, err)
+	assert.False(t, providers.SupportsChunks(provider))
+}